@@ -23,26 +23,36 @@ import (
 
 	"github.com/scttfrdmn/globus-go-cli/pkg/config"
 	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
 	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
 	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
 )
 
-// resolveManagerURL looks up the endpoint's GCS Manager base URL via the
-// Transfer API. Returns an error if the endpoint is not a GCSv5 endpoint (no
-// gcs_manager_url).
-func resolveManagerURL(ctx context.Context, endpointID string) (string, error) {
+// getTransferClient builds a Transfer client from the profile's login tokens.
+func getTransferClient(ctx context.Context) (*transfer.Client, error) {
 	profile := viper.GetString("profile")
 	clientCfg, err := config.LoadClientConfig()
 	if err != nil {
-		return "", fmt.Errorf("failed to load client configuration: %w", err)
+		return nil, fmt.Errorf("failed to load client configuration: %w", err)
 	}
 	cfg, err := globusauth.ClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, globusauth.ServiceTransfer)
 	if err != nil {
-		return "", fmt.Errorf("not logged in: %w", err)
+		return nil, fmt.Errorf("not logged in: %w", err)
 	}
 	tc, err := transfer.NewClient(ctx, cfg)
 	if err != nil {
-		return "", fmt.Errorf("failed to create transfer client: %w", err)
+		return nil, fmt.Errorf("failed to create transfer client: %w", err)
+	}
+	return tc, nil
+}
+
+// resolveManagerURL looks up the endpoint's GCS Manager base URL via the
+// Transfer API. Returns an error if the endpoint is not a GCSv5 endpoint (no
+// gcs_manager_url).
+func resolveManagerURL(ctx context.Context, endpointID string) (string, error) {
+	tc, err := getTransferClient(ctx)
+	if err != nil {
+		return "", err
 	}
 	ep, err := tc.GetEndpoint(ctx, endpointID)
 	if err != nil {
@@ -54,16 +64,24 @@ func resolveManagerURL(ctx context.Context, endpointID string) (string, error) {
 	return ep.GCSManagerURL, nil
 }
 
-// getManagerClient builds a GCS CollectionClient for managing the given
-// endpoint. It resolves the manager URL from the endpoint document and obtains
-// a manage_collections authorizer for the endpoint, escalating consent on first
+// managerConfig resolves the endpoint's GCS Manager URL and obtains a
+// manage_collections authorizer for the endpoint, escalating consent on first
 // use (a browser/paste-code login prompt).
-func getManagerClient(ctx context.Context, endpointID string) (*gcs.CollectionClient, error) {
+func managerConfig(ctx context.Context, endpointID string) (string, *core.Config, error) {
 	managerURL, err := resolveManagerURL(ctx, endpointID)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+	cfg, err := managerAuth(ctx, endpointID, true)
+	if err != nil {
+		return "", nil, err
 	}
+	return managerURL, cfg, nil
+}
 
+// managerAuth obtains a manage_collections authorizer for the endpoint. With
+// allowConsent false a missing consent is an error rather than a login prompt.
+func managerAuth(ctx context.Context, endpointID string, allowConsent bool) (*core.Config, error) {
 	profile := viper.GetString("profile")
 	clientCfg, err := config.LoadClientConfig()
 	if err != nil {
@@ -74,7 +92,15 @@ func getManagerClient(ctx context.Context, endpointID string) (*gcs.CollectionCl
 	// endpoint scope in URN format), keyed on the endpoint ID as resource
 	// server. Escalate consent if we have no token for it yet.
 	scope := gcs.EndpointManageCollectionsScope(endpointID)
-	cfg, err := globusauth.ScopedClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, endpointID, scope, true)
+	return globusauth.ScopedClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, endpointID, scope, allowConsent)
+}
+
+// getManagerClient builds a GCS CollectionClient for managing the given
+// endpoint. It resolves the manager URL from the endpoint document and obtains
+// a manage_collections authorizer for the endpoint, escalating consent on first
+// use (a browser/paste-code login prompt).
+func getManagerClient(ctx context.Context, endpointID string) (*gcs.CollectionClient, error) {
+	managerURL, cfg, err := managerConfig(ctx, endpointID)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +115,29 @@ func getManagerClient(ctx context.Context, endpointID string) (*gcs.CollectionCl
 	return client, nil
 }
 
+// getManagerAPI builds a raw client for the endpoint's GCS Manager API, for
+// documents the SDK's typed builders cannot carry (connector policies,
+// restrict_paths, identity mappings). Paths are relative to /api.
+func getManagerAPI(ctx context.Context, endpointID string) (*core.Client, error) {
+	managerURL, cfg, err := managerConfig(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := strings.TrimRight(managerURL, "/")
+	if !strings.HasPrefix(addr, "https://") && !strings.HasPrefix(addr, "http://") {
+		addr = "https://" + addr
+	}
+	apiCfg := *cfg
+	apiCfg.BaseURL = addr + "/api"
+
+	client, err := core.NewClient(&apiCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS Manager client: %w", err)
+	}
+	return client, nil
+}
+
 // collectionHTTPSToken obtains a raw access token for a specific collection's
 // data-plane `https` scope, escalating consent on first use. Unlike the
 // management scope, data access is a collection scope (URL format, keyed on the
@@ -122,3 +171,19 @@ func collectionHTTPSToken(ctx context.Context, collectionID string) (string, err
 	}
 	return strings.TrimPrefix(header, "Bearer "), nil
 }
+
+// managerResponse is the GCS Manager result envelope, decoded loosely so that
+// connector-specific fields survive.
+type managerResponse struct {
+	DataType string                   `json:"DATA_TYPE"`
+	Code     string                   `json:"code"`
+	Data     []map[string]interface{} `json:"data"`
+}
+
+// first returns the first document in the envelope, or nil.
+func (r *managerResponse) first() map[string]interface{} {
+	if len(r.Data) == 0 {
+		return nil
+	}
+	return r.Data[0]
+}
@@ -41,6 +41,10 @@ manage_collections consent on first use (a paste-code login prompt).`,
 		collectionUpdateCmd(),
 		collectionDeleteCmd(),
 		collectionCatCmd(),
+		collectionLsCmd(),
+		collectionStatCmd(),
+		collectionPolicyCmd(),
+		collectionShareCmd(),
 	)
 
 	return collectionCmd
@@ -5,6 +5,8 @@ package collection
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"time"
 
@@ -56,31 +58,99 @@ func catCollectionFile(cmd *cobra.Command, endpointID, collectionID, path string
 		return fmt.Errorf("collection %s does not have HTTPS enabled (no https_url); cannot read files over HTTPS", collectionID)
 	}
 
-	// Obtain a data-access (https) token for this collection.
-	token, err := collectionHTTPSToken(ctx, collectionID)
+	downloader, err := NewHTTPSDownloader(ctx, collectionID, coll.HTTPSURL)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = downloader.Close() }()
+
+	fileURI := HTTPSFileURL(coll.HTTPSURL, path)
+	data, err := downloader.ReadFile(ctx, fileURI)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", fileURI, err)
+	}
+
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
 
-	// Build the file URI: <https_url>/<path> (single slash join).
-	fileURI := strings.TrimRight(coll.HTTPSURL, "/") + "/" + strings.TrimPrefix(path, "/")
+// NewHTTPSDownloader returns a GCS Downloader for a collection's HTTPS data
+// plane rooted at httpsURL, authorized with the collection's data-access
+// token (escalating consent on first use). The caller must Close it.
+func NewHTTPSDownloader(ctx context.Context, collectionID, httpsURL string) (*gcs.Downloader, error) {
+	token, err := collectionHTTPSToken(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
 
 	// A CollectionClient is required to construct the Downloader; the raw token
 	// passed to NewDownloaderWithToken is what actually authorizes the
 	// data-plane request.
-	dlClient, err := gcs.NewCollectionClient(ctx, coll.HTTPSURL, collectionID,
+	dlClient, err := gcs.NewCollectionClient(ctx, httpsURL, collectionID,
 		&core.Config{Authorizer: authorizers.NewAccessTokenAuthorizer(token)})
 	if err != nil {
-		return fmt.Errorf("failed to create data-plane client: %w", err)
+		return nil, fmt.Errorf("failed to create data-plane client: %w", err)
 	}
-	downloader := gcs.NewDownloaderWithToken(dlClient, token)
-	defer func() { _ = downloader.Close() }()
+	return gcs.NewDownloaderWithToken(dlClient, token), nil
+}
 
-	data, err := downloader.ReadFile(ctx, fileURI)
+// httpsDataClient talks to collections' HTTPS servers. Its timeouts bound
+// connecting and waiting for a response but not reading the body, so a large
+// download runs as long as its context allows while a stalled server fails.
+var httpsDataClient = newHTTPSDataClient()
+
+func newHTTPSDataClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSHandshakeTimeout = 15 * time.Second
+	transport.ResponseHeaderTimeout = 60 * time.Second
+	return &http.Client{Transport: transport}
+}
+
+// HTTPSFileReader streams files from a collection's HTTPS data plane. Unlike
+// the GCS Downloader it never holds a whole file in memory, so it suits
+// checksumming large files.
+type HTTPSFileReader struct {
+	client *http.Client
+	token  string
+}
+
+// NewHTTPSFileReader returns a reader authorized with the collection's
+// data-access token (escalating consent on first use).
+func NewHTTPSFileReader(ctx context.Context, collectionID string) (*HTTPSFileReader, error) {
+	token, err := collectionHTTPSToken(ctx, collectionID)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", fileURI, err)
+		return nil, err
 	}
+	return &HTTPSFileReader{client: httpsDataClient, token: token}, nil
+}
 
-	_, err = cmd.OutOrStdout().Write(data)
-	return err
+// Open starts downloading fileURI and returns its body, which the caller
+// must close.
+func (r *HTTPSFileReader) Open(ctx context.Context, fileURI string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", fileURI, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		_ = resp.Body.Close()
+		return nil, &core.APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("GET %s failed: HTTP %d: %s", fileURI, resp.StatusCode, strings.TrimSpace(string(body))),
+		}
+	}
+	return resp.Body, nil
+}
+
+// HTTPSFileURL joins a collection's HTTPS base URL and a collection path with
+// a single slash: <https_url>/<path>.
+func HTTPSFileURL(httpsURL, path string) string {
+	return strings.TrimRight(httpsURL, "/") + "/" + strings.TrimPrefix(path, "/")
 }
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+)
+
+// TestHTTPSFileReader checks the request and that failures carry the status.
+func TestHTTPSFileReader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" || r.URL.Path != "/data/x.bin" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("payload"))
+	}))
+	defer srv.Close()
+
+	r := &HTTPSFileReader{client: srv.Client(), token: "tok"}
+	body, err := r.Open(context.Background(), HTTPSFileURL(srv.URL, "/data/x.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil || string(data) != "payload" {
+		t.Errorf("body = %q, err = %v", data, err)
+	}
+
+	var apiErr *core.APIError
+	if _, err := r.Open(context.Background(), HTTPSFileURL(srv.URL, "/missing")); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("missing file: err = %v", err)
+	}
+}
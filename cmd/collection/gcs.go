@@ -16,23 +16,28 @@ import (
 
 // GCSCmd returns the gcs command tree for endpoint-level GCS Manager admin.
 //
-// Every subcommand takes the owning endpoint's ID as its first positional
-// argument, used by getManagerClient to resolve the GCS Manager URL and
-// manage_collections consent.
+// Every subcommand except inventory takes the owning endpoint's ID as its
+// first positional argument, used by getManagerClient to resolve the GCS
+// Manager URL and manage_collections consent.
 func GCSCmd() *cobra.Command {
 	gcsCmd := &cobra.Command{
 		Use:   "gcs",
 		Short: "Commands for administering a Globus Connect Server endpoint",
 		Long: `Endpoint-level administration of a Globus Connect Server v5 endpoint's
-GCS Manager, including server info, storage gateways, and role assignments.
+GCS Manager, including server info, storage gateways, identity mappings, user
+credentials, and role assignments.
 
-Every subcommand takes the endpoint's ID as its first argument.`,
+Every subcommand except inventory takes the endpoint's ID as its first
+argument; inventory reports on many endpoints at once.`,
 	}
 
 	gcsCmd.AddCommand(
 		gcsInfoCmd(),
 		gcsStorageGatewayCmd(),
+		gcsIdentityMappingCmd(),
+		gcsUserCredentialCmd(),
 		gcsRoleCmd(),
+		gcsInventoryCmd(),
 	)
 
 	return gcsCmd
@@ -63,12 +68,15 @@ func gcsStorageGatewayCmd() *cobra.Command {
 	sgCmd := &cobra.Command{
 		Use:   "storage-gateway",
 		Short: "Commands for managing storage gateways",
-		Long:  `List and show storage gateways on the given endpoint's GCS Manager.`,
+		Long:  `List, show, create, update, and delete storage gateways on the given endpoint's GCS Manager.`,
 	}
 
 	sgCmd.AddCommand(
 		storageGatewayListCmd(),
 		storageGatewayShowCmd(),
+		storageGatewayCreateCmd(),
+		storageGatewayUpdateCmd(),
+		storageGatewayDeleteCmd(),
 	)
 
 	return sgCmd
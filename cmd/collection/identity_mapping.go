@@ -0,0 +1,569 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// gcsIdentityMappingCmd returns the identity-mapping subgroup.
+func gcsIdentityMappingCmd() *cobra.Command {
+	imCmd := &cobra.Command{
+		Use:   "identity-mapping",
+		Short: "Commands for inspecting and testing storage gateway identity mappings",
+		Long: `Show, replace, and test the identity mappings a storage gateway uses to
+map Globus identities to local accounts.`,
+	}
+
+	imCmd.AddCommand(
+		identityMappingShowCmd(),
+		identityMappingSetCmd(),
+		identityMappingTestCmd(),
+	)
+
+	return imCmd
+}
+
+// identityMappingShowCmd returns the identity-mapping show command.
+func identityMappingShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show ENDPOINT_ID STORAGE_GATEWAY_ID",
+		Short: "Show a storage gateway's identity mappings",
+		Long: `Show the identity mappings of a storage gateway: the rules of each
+expression mapping, and the command of each external mapping.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerAPI(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			gw, err := fetchStorageGateway(ctx, client, args[1], false)
+			if err != nil {
+				return err
+			}
+			mappings, _ := gw["identity_mappings"].([]interface{})
+
+			formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+			if formatter.Format != output.FormatText {
+				return formatter.FormatOutput(mappings, nil)
+			}
+			domains, _ := stringList(gw["allowed_domains"])
+			printIdentityMappings(cmd.OutOrStdout(), mappings, domains)
+			return nil
+		},
+	}
+}
+
+// identityMappingSetCmd returns the identity-mapping set command.
+func identityMappingSetCmd() *cobra.Command {
+	var (
+		mappings []string
+		clear    bool
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set ENDPOINT_ID STORAGE_GATEWAY_ID",
+		Short: "Replace a storage gateway's identity mappings",
+		Long: `Replace the identity mappings of a storage gateway.
+
+Each --mapping is an expression mapping document (JSON or file:PATH, a
+single document or a list) or external:COMMAND for a mapping program; they
+are tried in the order given. --clear removes every mapping, restoring the
+default of mapping identities in the gateway's allowed domains to their
+username without the domain.
+
+Try expression mappings with 'globus gcs identity-mapping test --mapping'
+before setting them.
+
+Examples:
+  globus gcs identity-mapping set ENDPOINT_ID GATEWAY_ID --mapping file:mapping.json
+  globus gcs identity-mapping set ENDPOINT_ID GATEWAY_ID --mapping "external:/usr/local/sbin/map-identity"
+  globus gcs identity-mapping set ENDPOINT_ID GATEWAY_ID --clear`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clear == (len(mappings) > 0) {
+				return fmt.Errorf("give either --mapping or --clear")
+			}
+			list, err := parseIdentityMappings(mappings)
+			if err != nil {
+				return err
+			}
+			doc := map[string]interface{}{
+				"DATA_TYPE":         "storage_gateway#1.0.0",
+				"identity_mappings": list,
+			}
+			if err := validateStorageGatewayDoc(doc, false); err != nil {
+				return err
+			}
+			if dryRun {
+				return printDryRun(cmd, doc)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerAPI(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			var resp managerResponse
+			if err := client.DoRequest(ctx, "PATCH", "/storage_gateways/"+url.PathEscape(args[1]), nil, doc, &resp); err != nil {
+				return fmt.Errorf("failed to update storage gateway: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %d identity mapping(s) on storage gateway %s\n", len(list), args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&mappings, "mapping", nil, "Identity mapping as JSON, file:PATH or external:COMMAND; may be given multiple times")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove all identity mappings")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate and print the update without submitting it")
+
+	return cmd
+}
+
+// identityMappingTestCmd returns the identity-mapping test command.
+func identityMappingTestCmd() *cobra.Command {
+	var (
+		mappings     []string
+		identityFile string
+		identity     = map[string]*string{}
+	)
+
+	cmd := &cobra.Command{
+		Use:   "test [ENDPOINT_ID STORAGE_GATEWAY_ID]",
+		Short: "Show how an identity maps to a local account",
+		Long: `Evaluate a storage gateway's expression identity mappings locally against
+an identity and show which rule matched and the resulting local username.
+
+The mappings are read from the storage gateway, or from --mapping (the same
+forms 'identity-mapping set' takes) without contacting the GCS Manager at
+all. Describe the identity with --username, --email, --idp and the other
+field flags, or pass a Globus Auth identity document with --identity
+(for example from 'globus get-identities -F json').
+
+Rules are tried in order and the first whose match expression matches the
+whole expanded source wins. External mapping programs cannot be run locally;
+they are reported and skipped. A gateway without mappings uses the default
+mapping: an identity in one of its allowed domains maps to its username
+without the domain.
+
+The command exits with status 1 when no rule matches.
+
+Examples:
+  globus gcs identity-mapping test ENDPOINT_ID GATEWAY_ID --username alice@example.org
+  globus gcs identity-mapping test --mapping file:mapping.json --username alice@example.org --idp example.org`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("give ENDPOINT_ID and STORAGE_GATEWAY_ID, or --mapping")
+			}
+			if len(args) == 0 && len(mappings) == 0 {
+				return fmt.Errorf("give ENDPOINT_ID and STORAGE_GATEWAY_ID, or --mapping")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := identityDocument(identityFile, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			for field, v := range identity {
+				if cmd.Flags().Changed(identityFieldFlags[field]) {
+					id[field] = *v
+				}
+			}
+			if len(id) == 0 {
+				return fmt.Errorf("describe the identity with --username, --email, --idp or --identity")
+			}
+
+			var (
+				list    []interface{}
+				domains []string
+			)
+			if len(mappings) > 0 {
+				if list, err = parseIdentityMappings(mappings); err != nil {
+					return err
+				}
+			} else {
+				ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+				defer cancel()
+
+				client, err := getManagerAPI(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				gw, err := fetchStorageGateway(ctx, client, args[1], false)
+				if err != nil {
+					return err
+				}
+				list, _ = gw["identity_mappings"].([]interface{})
+				domains, _ = stringList(gw["allowed_domains"])
+			}
+
+			result := evaluateIdentityMappings(list, id, domains)
+
+			formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+			if formatter.Format != output.FormatText {
+				if err := formatter.FormatOutput(result, nil); err != nil {
+					return err
+				}
+			} else {
+				printMappingTest(cmd.OutOrStdout(), result)
+			}
+
+			if !result.Matched {
+				// The report is the verdict: don't follow it with cobra's usage text.
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				return &output.ExitCodeError{Code: 1}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&mappings, "mapping", nil, "Test this identity mapping (JSON, file:PATH or external:COMMAND) instead of the gateway's; may be given multiple times")
+	cmd.Flags().StringVar(&identityFile, "identity", "", "Read the identity from a Globus Auth identity JSON document ('-' for stdin)")
+	for _, field := range identityFields {
+		identity[field] = new(string)
+		cmd.Flags().StringVar(identity[field], identityFieldFlags[field], "", "Identity "+field)
+	}
+
+	return cmd
+}
+
+// identityFields are the identity document fields a mapping source may use,
+// and identityFieldFlags the test flags that set them.
+var (
+	identityFields     = []string{"username", "email", "identity_provider", "id", "name", "organization"}
+	identityFieldFlags = map[string]string{
+		"username":          "username",
+		"email":             "email",
+		"identity_provider": "idp",
+		"id":                "id",
+		"name":              "name",
+		"organization":      "organization",
+	}
+)
+
+// identityDocument reads an identity from file, accepting either a single
+// identity or an Auth identities response (the first identity is used).
+func identityDocument(file string, stdin io.Reader) (map[string]string, error) {
+	id := map[string]string{}
+	if file == "" {
+		return id, nil
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --identity: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("--identity is not a JSON object: %w", err)
+	}
+	if list, ok := doc["identities"].([]interface{}); ok {
+		if len(list) == 0 {
+			return nil, fmt.Errorf("--identity lists no identities")
+		}
+		doc, _ = list[0].(map[string]interface{})
+	}
+	for _, field := range identityFields {
+		if s := docString(doc, field); s != "" {
+			id[field] = s
+		}
+	}
+	return id, nil
+}
+
+// parseIdentityMappings parses --mapping values into an identity_mappings
+// list.
+func parseIdentityMappings(values []string) ([]interface{}, error) {
+	list := []interface{}{}
+	for _, v := range values {
+		m, err := parseIdentityMapping(v)
+		if err != nil {
+			return nil, fmt.Errorf("--mapping: %w", err)
+		}
+		list = append(list, m...)
+	}
+	return list, nil
+}
+
+// mappingStep is one rule evaluated by identity-mapping test.
+type mappingStep struct {
+	Mapping int    `json:"mapping"`
+	Rule    int    `json:"rule,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Match   string `json:"match,omitempty"`
+	Result  string `json:"result"`
+	Output  string `json:"output,omitempty"`
+}
+
+// Step results.
+const (
+	stepMatched = "matched"
+	stepNoMatch = "no match"
+	stepSkipped = "skipped"
+	stepError   = "error"
+)
+
+// mappingTestResult is the outcome of identity-mapping test.
+type mappingTestResult struct {
+	Identity map[string]string `json:"identity"`
+	Steps    []mappingStep     `json:"steps"`
+	Matched  bool              `json:"matched"`
+	Username string            `json:"username,omitempty"`
+	// Uncertain is set when an external mapping was skipped before the
+	// result was decided, so the GCS Manager may map differently.
+	Uncertain bool `json:"uncertain"`
+}
+
+// evaluateIdentityMappings applies mappings to identity in order, stopping at
+// the first rule that matches. With no mappings, the default mapping strips
+// an allowed domain from the username.
+func evaluateIdentityMappings(mappings []interface{}, identity map[string]string, allowedDomains []string) mappingTestResult {
+	result := mappingTestResult{Identity: identity, Steps: []mappingStep{}}
+
+	if len(mappings) == 0 {
+		step := mappingStep{Source: identity["username"], Result: stepNoMatch}
+		if local, domain, ok := strings.Cut(identity["username"], "@"); ok {
+			step.Match = "default: username in " + strings.Join(allowedDomains, ", ")
+			for _, d := range allowedDomains {
+				if strings.EqualFold(d, domain) {
+					step.Result, step.Output = stepMatched, local
+					result.Matched, result.Username = true, local
+				}
+			}
+		}
+		result.Steps = append(result.Steps, step)
+		return result
+	}
+
+	for i, item := range mappings {
+		m, _ := item.(map[string]interface{})
+		dt := docString(m, "DATA_TYPE")
+		if strings.HasPrefix(dt, "external_identity_mapping#") {
+			command, _ := stringList(m["command"])
+			result.Steps = append(result.Steps, mappingStep{
+				Mapping: i + 1,
+				Source:  strings.Join(command, " "),
+				Result:  stepSkipped,
+				Output:  "external program; not run locally",
+			})
+			result.Uncertain = true
+			continue
+		}
+		if !strings.HasPrefix(dt, "expression_identity_mapping#") {
+			result.Steps = append(result.Steps, mappingStep{Mapping: i + 1, Result: stepError, Output: fmt.Sprintf("unknown DATA_TYPE %q", dt)})
+			continue
+		}
+
+		rules, _ := m["mappings"].([]interface{})
+		for j, r := range rules {
+			rule, _ := r.(map[string]interface{})
+			step := evaluateMappingRule(rule, identity)
+			step.Mapping, step.Rule = i+1, j+1
+			result.Steps = append(result.Steps, step)
+			if step.Result == stepMatched {
+				result.Matched, result.Username = true, step.Output
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// evaluateMappingRule applies one expression rule. The source template is
+// expanded from the identity; match must match all of it (as a regular
+// expression unless literal is set); output is expanded with {0}, {1}, ...
+// naming the capture groups.
+func evaluateMappingRule(rule map[string]interface{}, identity map[string]string) mappingStep {
+	step := mappingStep{Match: docString(rule, "match")}
+	fail := func(err error) mappingStep {
+		step.Result, step.Output = stepError, err.Error()
+		return step
+	}
+
+	source, err := expandMappingTemplate(docString(rule, "source"), func(name string) (string, bool) {
+		v, ok := identity[name]
+		return v, ok
+	})
+	if err != nil {
+		return fail(fmt.Errorf("source: %w", err))
+	}
+	step.Source = source
+
+	ignoreCase, _ := rule["ignore_case"].(bool)
+	literal, _ := rule["literal"].(bool)
+	var groups []string
+	if literal {
+		if !(source == step.Match || ignoreCase && strings.EqualFold(source, step.Match)) {
+			step.Result = stepNoMatch
+			return step
+		}
+	} else {
+		expr := "^(?:" + step.Match + ")$"
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fail(fmt.Errorf("match: %w", err))
+		}
+		sub := re.FindStringSubmatch(source)
+		if sub == nil {
+			step.Result = stepNoMatch
+			return step
+		}
+		groups = sub[1:]
+	}
+
+	out, err := expandMappingTemplate(docString(rule, "output"), func(name string) (string, bool) {
+		if n, err := strconv.Atoi(name); err == nil {
+			if n >= 0 && n < len(groups) {
+				return groups[n], true
+			}
+			return "", false
+		}
+		v, ok := identity[name]
+		return v, ok
+	})
+	if err != nil {
+		return fail(fmt.Errorf("output: %w", err))
+	}
+	step.Result, step.Output = stepMatched, out
+	return step
+}
+
+// expandMappingTemplate replaces {name} references using lookup; "{{" and
+// "}}" stand for literal braces.
+func expandMappingTemplate(tmpl string, lookup func(string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(tmpl); i++ {
+		c := tmpl[i]
+		switch {
+		case c == '{' && strings.HasPrefix(tmpl[i:], "{{"):
+			b.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(tmpl[i:], "}}"):
+			b.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unclosed { in %q", tmpl)
+			}
+			name := tmpl[i+1 : i+end]
+			v, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("{%s} is not set", name)
+			}
+			b.WriteString(v)
+			i += end
+		case c == '}':
+			return "", fmt.Errorf("unmatched } in %q", tmpl)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// printIdentityMappings prints each mapping's rules or command.
+func printIdentityMappings(w io.Writer, mappings []interface{}, allowedDomains []string) {
+	if len(mappings) == 0 {
+		fmt.Fprintf(w, "No identity mappings; identities in %s map to their username without the domain.\n", strings.Join(allowedDomains, ", "))
+		return
+	}
+	for i, item := range mappings {
+		m, _ := item.(map[string]interface{})
+		dt := docString(m, "DATA_TYPE")
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		switch {
+		case strings.HasPrefix(dt, "external_identity_mapping#"):
+			command, _ := stringList(m["command"])
+			fmt.Fprintf(w, "Mapping %d (external)\n  Command: %s\n", i+1, strings.Join(command, " "))
+		case strings.HasPrefix(dt, "expression_identity_mapping#"):
+			fmt.Fprintf(w, "Mapping %d (expression)\n", i+1)
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "  Rule\tSource\tMatch\tOutput\tFlags")
+			rules, _ := m["mappings"].([]interface{})
+			for j, r := range rules {
+				rule, _ := r.(map[string]interface{})
+				var flags []string
+				for _, flag := range []string{"ignore_case", "literal"} {
+					if on, _ := rule[flag].(bool); on {
+						flags = append(flags, flag)
+					}
+				}
+				fmt.Fprintf(tw, "  %d\t%s\t%s\t%s\t%s\n", j+1, docString(rule, "source"), docString(rule, "match"), docString(rule, "output"), strings.Join(flags, ","))
+			}
+			tw.Flush()
+		default:
+			fmt.Fprintf(w, "Mapping %d (%s)\n", i+1, dt)
+		}
+	}
+}
+
+// printMappingTest prints the evaluated rules and the verdict.
+func printMappingTest(w io.Writer, result mappingTestResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Mapping\tRule\tSource\tMatch\tResult")
+	for _, s := range result.Steps {
+		mapping, rule := "default", "-"
+		if s.Mapping > 0 {
+			mapping = strconv.Itoa(s.Mapping)
+		}
+		if s.Rule > 0 {
+			rule = strconv.Itoa(s.Rule)
+		}
+		verdict := s.Result
+		if s.Output != "" {
+			verdict += ": " + s.Output
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", mapping, rule, s.Source, s.Match, verdict)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	if result.Matched {
+		fmt.Fprintf(w, "Local username: %s\n", result.Username)
+	} else {
+		fmt.Fprintln(w, "No rule matched; this identity has no local account.")
+	}
+	switch {
+	case result.Uncertain && result.Matched:
+		fmt.Fprintln(w, "Note: an external mapping program is tried before this rule and may map the identity first.")
+	case result.Uncertain:
+		fmt.Fprintln(w, "Note: an external mapping program was skipped and may still map this identity.")
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+const testMapping = `{
+  "DATA_TYPE": "expression_identity_mapping#1.0.0",
+  "mappings": [
+    {"source": "{email}", "match": "admin@example\\.org", "output": "root", "literal": false},
+    {"source": "{username}", "match": "(.*)@EXAMPLE\\.ORG", "output": "{0}", "ignore_case": true},
+    {"source": "{username}/{identity_provider}", "match": "(.*)@partner\\.edu/(.*)", "output": "p_{0}"}
+  ]
+}`
+
+// TestEvaluateIdentityMappings checks rule order, case folding, capture
+// groups and external mappings.
+func TestEvaluateIdentityMappings(t *testing.T) {
+	mappings, err := parseIdentityMappings([]string{"external:/usr/sbin/map-user", testMapping})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		identity map[string]string
+		username string
+		rule     int
+	}{
+		{"ignore_case", map[string]string{"username": "alice@example.org", "email": "alice@example.org"}, "alice", 2},
+		{"first rule wins", map[string]string{"username": "bob@example.org", "email": "admin@example.org"}, "root", 1},
+		{"multiple fields", map[string]string{"username": "carol@partner.edu", "email": "c@partner.edu", "identity_provider": "idp-1"}, "p_carol", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluateIdentityMappings(mappings, tt.identity, nil)
+			if !result.Matched || result.Username != tt.username {
+				t.Fatalf("result = %+v, want %s", result, tt.username)
+			}
+			last := result.Steps[len(result.Steps)-1]
+			if last.Mapping != 2 || last.Rule != tt.rule {
+				t.Errorf("matched mapping %d rule %d, want 2/%d", last.Mapping, last.Rule, tt.rule)
+			}
+			if result.Steps[0].Result != stepSkipped || !result.Uncertain {
+				t.Errorf("external mapping step = %+v", result.Steps[0])
+			}
+		})
+	}
+
+	// carol has no identity_provider, so rule 3's source cannot be expanded.
+	result := evaluateIdentityMappings(mappings[1:], map[string]string{"username": "carol@partner.edu", "email": "c@partner.edu"}, nil)
+	if result.Matched || result.Steps[2].Result != stepError || !strings.Contains(result.Steps[2].Output, "{identity_provider} is not set") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+// TestEvaluateDefaultMapping checks the allowed-domain default.
+func TestEvaluateDefaultMapping(t *testing.T) {
+	result := evaluateIdentityMappings(nil, map[string]string{"username": "alice@Example.org"}, []string{"example.org"})
+	if !result.Matched || result.Username != "alice" {
+		t.Errorf("result = %+v", result)
+	}
+	result = evaluateIdentityMappings(nil, map[string]string{"username": "alice@other.org"}, []string{"example.org"})
+	if result.Matched {
+		t.Errorf("result = %+v, want no match", result)
+	}
+}
+
+// TestExpandMappingTemplate checks escapes and errors.
+func TestExpandMappingTemplate(t *testing.T) {
+	lookup := func(name string) (string, bool) { return map[string]string{"0": "alice"}[name], name == "0" }
+	if got, err := expandMappingTemplate("{{{0}}}", lookup); err != nil || got != "{alice}" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	for _, tmpl := range []string{"{0", "0}", "{1}"} {
+		if _, err := expandMappingTemplate(tmpl, lookup); err == nil {
+			t.Errorf("%q: expected an error", tmpl)
+		}
+	}
+}
+
+// TestIdentityMappingTestCmd runs the test command offline and checks the
+// exit status when nothing matches.
+func TestIdentityMappingTestCmd(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		cmd := identityMappingTestCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"--mapping", testMapping}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("--username", "alice@example.org")
+	if err != nil || !strings.Contains(out, "Local username: alice") {
+		t.Errorf("out = %q, err = %v", out, err)
+	}
+
+	out, err = run("--username", "dave@elsewhere.org", "--email", "dave@elsewhere.org")
+	var exit *output.ExitCodeError
+	if !errors.As(err, &exit) || exit.Code != 1 || !strings.Contains(out, "No rule matched") {
+		t.Errorf("out = %q, err = %v", out, err)
+	}
+}
@@ -0,0 +1,540 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// inventoryPageSize is the GCS Manager page size used while walking lists.
+const inventoryPageSize = 100
+
+// inventoryOptions holds the gcs inventory flags.
+type inventoryOptions struct {
+	FilterScope string
+	Concurrency int
+	Markdown    bool
+}
+
+// endpointInventory is everything gathered from one endpoint. Error is set when
+// the endpoint could not be inventoried at all; Errors records sections that
+// failed while the rest succeeded.
+type endpointInventory struct {
+	EndpointID      string               `json:"endpoint_id"`
+	DisplayName     string               `json:"display_name,omitempty"`
+	ManagerURL      string               `json:"gcs_manager_url,omitempty"`
+	Info            *gcs.GCSInfo         `json:"info,omitempty"`
+	StorageGateways []gcs.StorageGateway `json:"storage_gateways"`
+	Collections     []gcs.Collection     `json:"collections"`
+	Roles           []gcs.GCSRole        `json:"roles"`
+	HTTPSURLs       []string             `json:"https_urls"`
+	Error           string               `json:"error,omitempty"`
+	Errors          []string             `json:"errors,omitempty"`
+}
+
+// failed reports whether anything about the endpoint could not be gathered.
+func (e *endpointInventory) failed() bool {
+	return e.Error != "" || len(e.Errors) > 0
+}
+
+// status is a one-line summary of the endpoint's errors, or "ok".
+func (e *endpointInventory) status() string {
+	if e.Error != "" {
+		return e.Error
+	}
+	if len(e.Errors) > 0 {
+		return strings.Join(e.Errors, "; ")
+	}
+	return "ok"
+}
+
+// inventoryReport is the consolidated report across endpoints.
+type inventoryReport struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Endpoints   []*endpointInventory `json:"endpoints"`
+}
+
+// managerLister is the subset of the GCS CollectionClient the inventory reads.
+type managerLister interface {
+	GetGCSInfo(ctx context.Context) (*gcs.GCSInfo, error)
+	GetStorageGatewayList(ctx context.Context, options *gcs.StorageGatewayListOptions) (*gcs.StorageGatewayListResponse, error)
+	ListCollections(ctx context.Context, options *gcs.ListCollectionsOptions) (*gcs.CollectionListResponse, error)
+	GetRoleList(ctx context.Context, options *gcs.RoleListOptions) (*gcs.RoleListResponse, error)
+}
+
+// gcsInventoryCmd returns the gcs inventory command.
+func gcsInventoryCmd() *cobra.Command {
+	opts := &inventoryOptions{}
+	cmd := &cobra.Command{
+		Use:   "inventory [ENDPOINT_ID...]",
+		Short: "Report storage gateways, collections and roles across endpoints",
+		Long: `Gather the GCS Manager info, storage gateways, collections, role assignments
+and collection HTTPS URLs of many endpoints at once and print one consolidated
+report.
+
+Name the endpoints as arguments, or use --filter-scope to inventory every
+GCSv5 endpoint in an endpoint search scope (for example administered-by-me).
+Endpoints are queried --concurrency at a time.
+
+An endpoint that cannot be reached, is not a GCSv5 endpoint, or has no stored
+manage_collections consent is reported with its error and does not stop the
+run; no consent prompts are shown. Grant consent for such an endpoint once with
+'globus gcs info ENDPOINT_ID'. The command exits 1 if any endpoint reported an
+error.
+
+-F json prints the full report, -F csv one row per collection, and --markdown
+a Markdown document with a section per endpoint. Text and unix output
+summarize one endpoint per line.`,
+		Example: `  globus gcs inventory 1a2b... 3c4d...
+  globus gcs inventory --filter-scope administered-by-me -F json > inventory.json
+  globus gcs inventory --filter-scope administered-by-me --markdown > INVENTORY.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInventory(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.FilterScope, "filter-scope", "", "Inventory the GCSv5 endpoints in this endpoint search scope (e.g. administered-by-me)")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "Number of endpoints queried at once")
+	cmd.Flags().BoolVar(&opts.Markdown, "markdown", false, "Print the report as Markdown")
+
+	return cmd
+}
+
+// runInventory resolves the endpoint list, gathers every endpoint and prints
+// the report.
+func runInventory(cmd *cobra.Command, args []string, opts *inventoryOptions) error {
+	if len(args) == 0 && opts.FilterScope == "" {
+		return fmt.Errorf("name one or more endpoints or use --filter-scope")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	tc, err := getTransferClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make([]transfer.Endpoint, 0, len(args))
+	for _, id := range args {
+		endpoints = append(endpoints, transfer.Endpoint{ID: id})
+	}
+	if opts.FilterScope != "" {
+		found, err := searchGCSEndpoints(ctx, tc, opts.FilterScope)
+		if err != nil {
+			return err
+		}
+		endpoints = append(endpoints, found...)
+	}
+	endpoints = uniqueEndpoints(endpoints)
+	if len(endpoints) == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "No GCSv5 endpoints found in scope %s.\n", opts.FilterScope)
+		return nil
+	}
+
+	report := &inventoryReport{
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Endpoints: gatherInventory(ctx, endpoints, opts.Concurrency, func(ctx context.Context, ep transfer.Endpoint) *endpointInventory {
+			return inventoryEndpoint(ctx, tc, ep)
+		}),
+	}
+
+	if err := printInventory(cmd, report, opts.Markdown); err != nil {
+		return err
+	}
+	for _, inv := range report.Endpoints {
+		if inv.failed() {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &output.ExitCodeError{Code: 1}
+		}
+	}
+	return nil
+}
+
+// searchGCSEndpoints pages through endpoint_search for the GCSv5 endpoints in
+// a filter scope.
+func searchGCSEndpoints(ctx context.Context, tc *transfer.Client, scope string) ([]transfer.Endpoint, error) {
+	opts := &transfer.EndpointSearchOptions{
+		FilterScope:      scope,
+		FilterEntityType: "GCSv5_endpoint",
+		Limit:            inventoryPageSize,
+	}
+	var found []transfer.Endpoint
+	for {
+		result, err := tc.EndpointSearch(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search endpoints: %w", err)
+		}
+		found = append(found, result.Data...)
+		opts.Offset += len(result.Data)
+		if !result.HasNextPage || len(result.Data) == 0 {
+			return found, nil
+		}
+	}
+}
+
+// uniqueEndpoints drops repeated endpoint IDs, keeping the first occurrence.
+func uniqueEndpoints(endpoints []transfer.Endpoint) []transfer.Endpoint {
+	seen := map[string]bool{}
+	out := endpoints[:0]
+	for _, ep := range endpoints {
+		id := strings.ToLower(ep.ID)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, ep)
+	}
+	return out
+}
+
+// gatherInventory runs fetch for every endpoint, concurrency at a time, and
+// returns the results in endpoint order.
+func gatherInventory(ctx context.Context, endpoints []transfer.Endpoint, concurrency int, fetch func(context.Context, transfer.Endpoint) *endpointInventory) []*endpointInventory {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]*endpointInventory, len(endpoints))
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetch(ctx, endpoints[i])
+			}
+		}()
+	}
+	for i := range endpoints {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// inventoryEndpoint gathers one endpoint. It never prompts for consent.
+func inventoryEndpoint(ctx context.Context, tc *transfer.Client, ep transfer.Endpoint) *endpointInventory {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	inv := &endpointInventory{EndpointID: ep.ID, DisplayName: ep.DisplayName, ManagerURL: ep.GCSManagerURL}
+	if inv.ManagerURL == "" {
+		doc, err := tc.GetEndpoint(ctx, ep.ID)
+		if err != nil {
+			inv.Error = fmt.Sprintf("failed to look up endpoint: %v", err)
+			return inv
+		}
+		inv.DisplayName = doc.DisplayName
+		inv.ManagerURL = doc.GCSManagerURL
+	}
+	if inv.ManagerURL == "" {
+		inv.Error = "not a Globus Connect Server v5 endpoint (no gcs_manager_url)"
+		return inv
+	}
+
+	cfg, err := managerAuth(ctx, ep.ID, false)
+	if err != nil {
+		inv.Error = fmt.Sprintf("no manage_collections consent (run 'globus gcs info %s' once to grant it): %v", ep.ID, err)
+		return inv
+	}
+	client, err := gcs.NewCollectionClient(ctx, inv.ManagerURL, ep.ID, cfg)
+	if err != nil {
+		inv.Error = fmt.Sprintf("failed to create GCS collection client: %v", err)
+		return inv
+	}
+
+	collectInventory(ctx, client, inv)
+	return inv
+}
+
+// collectInventory fills inv from the GCS Manager. A failing section is
+// recorded in inv.Errors and the remaining sections are still gathered; if
+// every section fails the manager is reported as unreachable.
+func collectInventory(ctx context.Context, client managerLister, inv *endpointInventory) {
+	var errs []string
+	record := func(section string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", section, err))
+		}
+	}
+
+	info, err := client.GetGCSInfo(ctx)
+	inv.Info = info
+	record("info", err)
+
+	inv.StorageGateways, err = listAllStorageGateways(ctx, client)
+	record("storage gateways", err)
+
+	inv.Collections, err = listAllCollections(ctx, client)
+	record("collections", err)
+
+	inv.Roles, err = listAllRoles(ctx, client)
+	record("roles", err)
+
+	inv.HTTPSURLs = collectionHTTPSURLs(inv.Collections)
+
+	if len(errs) == 4 {
+		inv.Error = "GCS Manager unreachable: " + strings.Join(errs, "; ")
+		return
+	}
+	inv.Errors = errs
+}
+
+// listAllStorageGateways pages through the storage gateway list.
+func listAllStorageGateways(ctx context.Context, client managerLister) ([]gcs.StorageGateway, error) {
+	gateways := []gcs.StorageGateway{}
+	opts := &gcs.StorageGatewayListOptions{PageSize: inventoryPageSize}
+	for {
+		resp, err := client.GetStorageGatewayList(ctx, opts)
+		if err != nil {
+			return gateways, err
+		}
+		gateways = append(gateways, resp.Data...)
+		if !resp.HasNextPage || resp.Marker == "" {
+			return gateways, nil
+		}
+		opts.Marker = resp.Marker
+	}
+}
+
+// listAllCollections pages through the collection list.
+func listAllCollections(ctx context.Context, client managerLister) ([]gcs.Collection, error) {
+	collections := []gcs.Collection{}
+	opts := &gcs.ListCollectionsOptions{PageSize: inventoryPageSize}
+	for {
+		resp, err := client.ListCollections(ctx, opts)
+		if err != nil {
+			return collections, err
+		}
+		collections = append(collections, resp.Data...)
+		if !resp.HasNextPage || resp.Marker == "" {
+			return collections, nil
+		}
+		opts.Marker = resp.Marker
+	}
+}
+
+// listAllRoles pages through the role list.
+func listAllRoles(ctx context.Context, client managerLister) ([]gcs.GCSRole, error) {
+	roles := []gcs.GCSRole{}
+	opts := &gcs.RoleListOptions{PageSize: inventoryPageSize}
+	for {
+		resp, err := client.GetRoleList(ctx, opts)
+		if err != nil {
+			return roles, err
+		}
+		roles = append(roles, resp.Data...)
+		if !resp.HasNextPage || resp.Marker == "" {
+			return roles, nil
+		}
+		opts.Marker = resp.Marker
+	}
+}
+
+// collectionHTTPSURLs returns the distinct HTTPS URLs of the collections,
+// sorted.
+func collectionHTTPSURLs(collections []gcs.Collection) []string {
+	urls := []string{}
+	seen := map[string]bool{}
+	for _, c := range collections {
+		if c.HTTPSURL == "" || seen[c.HTTPSURL] {
+			continue
+		}
+		seen[c.HTTPSURL] = true
+		urls = append(urls, c.HTTPSURL)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// collectionRoleCounts counts role assignments per collection ID.
+func collectionRoleCounts(roles []gcs.GCSRole) map[string]int {
+	counts := map[string]int{}
+	for _, r := range roles {
+		if r.Collection != nil {
+			counts[strings.ToLower(*r.Collection)]++
+		}
+	}
+	return counts
+}
+
+// printInventory writes the report in the selected format.
+func printInventory(cmd *cobra.Command, report *inventoryReport, markdown bool) error {
+	w := cmd.OutOrStdout()
+	if markdown {
+		writeInventoryMarkdown(w, report)
+		return nil
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), w)
+	switch formatter.Format {
+	case output.FormatJSON:
+		return formatter.FormatOutput(report, nil)
+	case output.FormatCSV:
+		return writeInventoryCSV(w, report)
+	}
+
+	type endpointRow struct {
+		EndpointID      string
+		DisplayName     string
+		StorageGateways string
+		Collections     string
+		Roles           string
+		Status          string
+	}
+	rows := make([]endpointRow, 0, len(report.Endpoints))
+	for _, inv := range report.Endpoints {
+		row := endpointRow{EndpointID: inv.EndpointID, DisplayName: inv.DisplayName, Status: inv.status()}
+		if inv.Error == "" {
+			row.StorageGateways = strconv.Itoa(len(inv.StorageGateways))
+			row.Collections = strconv.Itoa(len(inv.Collections))
+			row.Roles = strconv.Itoa(len(inv.Roles))
+		}
+		rows = append(rows, row)
+	}
+	return formatter.FormatOutput(rows, []string{"EndpointID", "DisplayName", "StorageGateways", "Collections", "Roles", "Status"})
+}
+
+// inventoryCSVHeader is the column list of the CSV report.
+var inventoryCSVHeader = []string{
+	"endpoint_id", "endpoint_name", "gcs_manager_url", "status",
+	"collection_id", "collection_name", "collection_type", "storage_gateway_id",
+	"connector", "https_url", "roles",
+}
+
+// writeInventoryCSV writes one row per collection. An endpoint with no
+// collections, or that failed, gets a single row with the collection columns
+// empty.
+func writeInventoryCSV(w io.Writer, report *inventoryReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(inventoryCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, inv := range report.Endpoints {
+		endpoint := []string{inv.EndpointID, inv.DisplayName, inv.ManagerURL, inv.status()}
+		if len(inv.Collections) == 0 {
+			if err := cw.Write(append(endpoint, "", "", "", "", "", "", "")); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+			continue
+		}
+		gateways := map[string]gcs.StorageGateway{}
+		for _, g := range inv.StorageGateways {
+			gateways[g.ID] = g
+		}
+		roles := collectionRoleCounts(inv.Roles)
+		for _, c := range inv.Collections {
+			connector := c.ConnectorID
+			if g, ok := gateways[c.StorageGatewayID]; ok && connector == "" {
+				connector = g.ConnectorID
+			}
+			row := append(append([]string{}, endpoint...),
+				c.ID, c.DisplayName, c.CollectionType, c.StorageGatewayID,
+				connectorName(connector), c.HTTPSURL, strconv.Itoa(roles[strings.ToLower(c.ID)]))
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// connectorName maps a connector ID to its short name, or returns the ID.
+func connectorName(id string) string {
+	for _, c := range storageConnectors {
+		if strings.EqualFold(c.ID, id) {
+			return c.Name
+		}
+	}
+	return id
+}
+
+// writeInventoryMarkdown writes a Markdown document with a summary table and
+// a section per endpoint.
+func writeInventoryMarkdown(w io.Writer, report *inventoryReport) {
+	fmt.Fprintf(w, "# GCS inventory\n\nGenerated %s.\n\n", report.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintln(w, "| Endpoint | Name | Storage gateways | Collections | Roles | Status |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|")
+	for _, inv := range report.Endpoints {
+		counts := []string{"", "", ""}
+		if inv.Error == "" {
+			counts = []string{strconv.Itoa(len(inv.StorageGateways)), strconv.Itoa(len(inv.Collections)), strconv.Itoa(len(inv.Roles))}
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s | %s |\n", inv.EndpointID, mdCell(inv.DisplayName),
+			counts[0], counts[1], counts[2], mdCell(inv.status()))
+	}
+
+	for _, inv := range report.Endpoints {
+		name := inv.DisplayName
+		if name == "" {
+			name = inv.EndpointID
+		}
+		fmt.Fprintf(w, "\n## %s\n\n- Endpoint ID: `%s`\n", name, inv.EndpointID)
+		if inv.ManagerURL != "" {
+			fmt.Fprintf(w, "- GCS Manager: %s\n", inv.ManagerURL)
+		}
+		if inv.Error != "" {
+			fmt.Fprintf(w, "- **Error:** %s\n", mdCell(inv.Error))
+			continue
+		}
+		for _, e := range inv.Errors {
+			fmt.Fprintf(w, "- **Error:** %s\n", mdCell(e))
+		}
+
+		if len(inv.StorageGateways) > 0 {
+			fmt.Fprintln(w, "\n### Storage gateways\n\n| ID | Name | Connector | High assurance |\n|---|---|---|---|")
+			for _, g := range inv.StorageGateways {
+				fmt.Fprintf(w, "| `%s` | %s | %s | %t |\n", g.ID, mdCell(g.DisplayName), connectorName(g.ConnectorID), g.HighAssurance)
+			}
+		}
+
+		if len(inv.Collections) > 0 {
+			roles := collectionRoleCounts(inv.Roles)
+			fmt.Fprintln(w, "\n### Collections\n\n| ID | Name | Type | Storage gateway | HTTPS URL | Roles |\n|---|---|---|---|---|---|")
+			for _, c := range inv.Collections {
+				fmt.Fprintf(w, "| `%s` | %s | %s | `%s` | %s | %d |\n", c.ID, mdCell(c.DisplayName), c.CollectionType,
+					c.StorageGatewayID, c.HTTPSURL, roles[strings.ToLower(c.ID)])
+			}
+		}
+
+		if len(inv.Roles) > 0 {
+			fmt.Fprintln(w, "\n### Roles\n\n| Principal | Role | Collection |\n|---|---|---|")
+			for _, r := range inv.Roles {
+				collection := "(endpoint)"
+				if r.Collection != nil {
+					collection = "`" + *r.Collection + "`"
+				}
+				fmt.Fprintf(w, "| %s | %s | %s |\n", r.Principal, r.Role, collection)
+			}
+		}
+	}
+}
+
+// mdCell escapes text for a Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
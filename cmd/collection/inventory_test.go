@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// stubManager serves two pages of collections and fails the role list.
+type stubManager struct {
+	collectionCalls int
+}
+
+func (s *stubManager) GetGCSInfo(context.Context) (*gcs.GCSInfo, error) {
+	return &gcs.GCSInfo{ClientID: "client", EndpointID: "ep"}, nil
+}
+
+func (s *stubManager) GetStorageGatewayList(context.Context, *gcs.StorageGatewayListOptions) (*gcs.StorageGatewayListResponse, error) {
+	return &gcs.StorageGatewayListResponse{Data: []gcs.StorageGateway{
+		{ID: "sg1", DisplayName: "POSIX", ConnectorID: "145812c8-decc-41f1-83cf-bb2a85a2a70b"},
+	}}, nil
+}
+
+func (s *stubManager) ListCollections(_ context.Context, opts *gcs.ListCollectionsOptions) (*gcs.CollectionListResponse, error) {
+	s.collectionCalls++
+	if opts.Marker == "" {
+		return &gcs.CollectionListResponse{
+			Data:        []gcs.Collection{{ID: "c1", HTTPSURL: "https://b.data.globus.org"}},
+			HasNextPage: true, Marker: "next",
+		}, nil
+	}
+	return &gcs.CollectionListResponse{Data: []gcs.Collection{
+		{ID: "c2", HTTPSURL: "https://a.data.globus.org"},
+		{ID: "c3", HTTPSURL: "https://b.data.globus.org"},
+	}}, nil
+}
+
+func (s *stubManager) GetRoleList(context.Context, *gcs.RoleListOptions) (*gcs.RoleListResponse, error) {
+	return nil, errors.New("403 forbidden")
+}
+
+// TestCollectInventory checks paging, URL collection and section errors.
+func TestCollectInventory(t *testing.T) {
+	stub := &stubManager{}
+	inv := &endpointInventory{EndpointID: "ep"}
+	collectInventory(context.Background(), stub, inv)
+
+	if stub.collectionCalls != 2 || len(inv.Collections) != 3 {
+		t.Errorf("collections = %d over %d calls", len(inv.Collections), stub.collectionCalls)
+	}
+	if got := strings.Join(inv.HTTPSURLs, ","); got != "https://a.data.globus.org,https://b.data.globus.org" {
+		t.Errorf("https urls = %s", got)
+	}
+	if inv.Error != "" || len(inv.Errors) != 1 || !strings.HasPrefix(inv.Errors[0], "roles:") {
+		t.Errorf("error = %q, errors = %v", inv.Error, inv.Errors)
+	}
+	if !inv.failed() {
+		t.Error("expected failed()")
+	}
+}
+
+// TestGatherInventory checks that results keep endpoint order and that one
+// failing endpoint does not affect the others.
+func TestGatherInventory(t *testing.T) {
+	endpoints := uniqueEndpoints([]transfer.Endpoint{{ID: "a"}, {ID: "b"}, {ID: "A"}, {ID: "c"}, {ID: "d"}})
+	if len(endpoints) != 4 {
+		t.Fatalf("unique endpoints = %v", endpoints)
+	}
+
+	var running, peak int32
+	results := gatherInventory(context.Background(), endpoints, 2, func(_ context.Context, ep transfer.Endpoint) *endpointInventory {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		inv := &endpointInventory{EndpointID: ep.ID}
+		if ep.ID == "b" {
+			inv.Error = "unreachable"
+		}
+		return inv
+	})
+
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.EndpointID+"="+r.status())
+	}
+	if got := strings.Join(ids, " "); got != "a=ok b=unreachable c=ok d=ok" {
+		t.Errorf("results = %s", got)
+	}
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+}
+
+// testReport is a report with one healthy and one failed endpoint.
+func testReport() *inventoryReport {
+	collection := "c1"
+	return &inventoryReport{
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Endpoints: []*endpointInventory{
+			{
+				EndpointID: "ep1", DisplayName: "Lab | Main", ManagerURL: "https://m1.example.org",
+				StorageGateways: []gcs.StorageGateway{{ID: "sg1", DisplayName: "POSIX", ConnectorID: "145812c8-decc-41f1-83cf-bb2a85a2a70b"}},
+				Collections: []gcs.Collection{
+					{ID: "c1", DisplayName: "Data", CollectionType: "mapped", StorageGatewayID: "sg1", HTTPSURL: "https://c1.data.globus.org"},
+					{ID: "c2", DisplayName: "Share", CollectionType: "guest", StorageGatewayID: "sg1"},
+				},
+				Roles: []gcs.GCSRole{
+					{Principal: "urn:globus:auth:identity:x", Role: "owner"},
+					{Principal: "urn:globus:auth:identity:y", Role: "administrator", Collection: &collection},
+				},
+			},
+			{EndpointID: "ep2", Error: "no manage_collections consent"},
+		},
+	}
+}
+
+// TestWriteInventoryCSV checks one row per collection plus a row for the
+// failed endpoint.
+func TestWriteInventoryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeInventoryCSV(&buf, testReport()); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("rows = %v", rows)
+	}
+	if got := strings.Join(rows[1], ","); got != "ep1,Lab | Main,https://m1.example.org,ok,c1,Data,mapped,sg1,posix,https://c1.data.globus.org,1" {
+		t.Errorf("row 1 = %s", got)
+	}
+	if rows[3][0] != "ep2" || rows[3][3] != "no manage_collections consent" || rows[3][4] != "" {
+		t.Errorf("row 3 = %v", rows[3])
+	}
+}
+
+// TestWriteInventoryMarkdown checks the summary table and per-endpoint
+// sections.
+func TestWriteInventoryMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	writeInventoryMarkdown(&buf, testReport())
+	out := buf.String()
+	for _, want := range []string{
+		"Generated 2026-01-02T03:04:05Z.",
+		"| `ep1` | Lab \\| Main | 1 | 2 | 2 | ok |",
+		"| `ep2` |  |  |  |  | no manage_collections consent |",
+		"## Lab | Main",
+		"| `sg1` | POSIX | posix | false |",
+		"| `c1` | Data | mapped | `sg1` | https://c1.data.globus.org | 1 |",
+		"| urn:globus:auth:identity:x | owner | (endpoint) |",
+		"## ep2",
+		"- **Error:** no manage_collections consent",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
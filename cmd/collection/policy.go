@@ -0,0 +1,597 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/config"
+	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
+	"github.com/scttfrdmn/globus-go-cli/pkg/ids"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+)
+
+// GCS role principals are URNs naming an identity or a group.
+const (
+	identityURNPrefix = "urn:globus:auth:identity:"
+	groupURNPrefix    = "urn:globus:groups:id:"
+)
+
+// gcsRoles are the role names the GCS Manager accepts.
+var gcsRoles = []string{"owner", "administrator", "access_manager", "activity_manager", "activity_monitor"}
+
+// policyRoleSpec is one role as written in a policy file. Exactly one of the
+// principal fields is set.
+type policyRoleSpec struct {
+	Identity  string `yaml:"identity,omitempty"`
+	Group     string `yaml:"group,omitempty"`
+	Principal string `yaml:"principal,omitempty"`
+	Role      string `yaml:"role"`
+}
+
+// policyRestrictPaths is the sharing_restrict_paths section of a policy file.
+type policyRestrictPaths struct {
+	Read      []string `yaml:"read,omitempty"`
+	ReadWrite []string `yaml:"read_write,omitempty"`
+	None      []string `yaml:"none,omitempty"`
+}
+
+// policySharing is the sharing section of a policy file.
+type policySharing struct {
+	RestrictPaths *policyRestrictPaths `yaml:"restrict_paths"`
+	UsersAllow    *[]string            `yaml:"users_allow"`
+	UsersDeny     *[]string            `yaml:"users_deny"`
+}
+
+// policyGuestCollections is the guest_collections section of a policy file.
+type policyGuestCollections struct {
+	Allow                  *bool `yaml:"allow"`
+	DisableAnonymousWrites *bool `yaml:"disable_anonymous_writes"`
+}
+
+// collectionPolicy is the document read by `collection policy apply -f`.
+// Sections and fields left out are not managed; a roles list, once given, is
+// the complete set of the collection's roles.
+type collectionPolicy struct {
+	Roles            *[]policyRoleSpec       `yaml:"roles"`
+	Sharing          *policySharing          `yaml:"sharing"`
+	GuestCollections *policyGuestCollections `yaml:"guest_collections"`
+}
+
+// policyRole is a role with its principal resolved to a URN. Name is the
+// principal as the file wrote it, for display only.
+type policyRole struct {
+	ID        string `json:"id,omitempty"`
+	Principal string `json:"principal"`
+	Name      string `json:"principal_name,omitempty"`
+	Role      string `json:"role"`
+}
+
+// key identifies the role assignment.
+func (r policyRole) key() string {
+	return r.Principal + "\x00" + r.Role
+}
+
+// label renders the role's principal for plan output.
+func (r policyRole) label() string {
+	if r.Name != "" && r.Name != r.Principal {
+		return fmt.Sprintf("%s (%s)", r.Name, r.Principal)
+	}
+	return r.Principal
+}
+
+// policyChange is one step of a policy plan: a role to create or delete, or
+// a collection field to set.
+type policyChange struct {
+	Action  string      `json:"action"`
+	Role    *policyRole `json:"role,omitempty"`
+	Field   string      `json:"field,omitempty"`
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// collectionPolicyCmd returns the collection policy subgroup.
+func collectionPolicyCmd() *cobra.Command {
+	policyCmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Commands for declarative collection configuration",
+		Long:  `Keep a collection's roles and sharing settings in a file and apply it.`,
+	}
+
+	policyCmd.AddCommand(collectionPolicyApplyCmd())
+
+	return policyCmd
+}
+
+// collectionPolicyApplyCmd returns the collection policy apply command.
+func collectionPolicyApplyCmd() *cobra.Command {
+	var (
+		file string
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply ENDPOINT_ID COLLECTION_ID -f FILE",
+		Short: "Sync a collection's roles and sharing settings to a YAML file",
+		Long: `Make a collection's roles and sharing settings match a YAML policy file.
+
+The live collection document and role list are compared with the file and a
+plan of role creates and deletes and field changes is printed. Nothing
+changes unless --yes is given. Only the sections and fields the file declares
+are managed; a roles list is the complete set of the collection's roles, so
+roles it does not declare are deleted.
+
+Each role names its principal with exactly one of identity (a username or
+identity ID), group (a group ID) or principal (a GCS principal URN):
+
+  roles:
+    - identity: alice@uchicago.edu
+      role: administrator
+    - group: 0a2f6c40-6f58-11ee-b8d6-0242ac120002
+      role: access_manager
+  sharing:
+    restrict_paths:
+      read_write: [/projects/]
+      none: [/projects/private/]
+    users_allow: [alice, bob]
+    users_deny: []
+  guest_collections:
+    allow: true
+    disable_anonymous_writes: true
+
+An empty restrict_paths ({}) removes the sharing path restrictions. Role
+creates are applied first, then field changes, then role deletes.
+
+Examples:
+  globus collection policy apply ENDPOINT_ID COLLECTION_ID -f policy.yaml
+  globus collection policy apply ENDPOINT_ID COLLECTION_ID -f policy.yaml --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyCollectionPolicy(cmd, args[0], args[1], file, yes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file declaring the collection policy")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Apply the plan instead of only printing it")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// applyCollectionPolicy plans, prints and (with yes) applies a policy file.
+func applyCollectionPolicy(cmd *cobra.Command, endpointID, collectionID, file string, yes bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	policy, err := loadCollectionPolicy(file)
+	if err != nil {
+		return err
+	}
+
+	var desiredRoles []policyRole
+	if policy.Roles != nil {
+		if desiredRoles, err = resolvePolicyRoles(ctx, newIdentityLookup(), *policy.Roles); err != nil {
+			return err
+		}
+	}
+
+	api, err := getManagerAPI(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	var resp managerResponse
+	if err := api.DoRequest(ctx, "GET", "/collections/"+url.PathEscape(collectionID), nil, nil, &resp); err != nil {
+		return fmt.Errorf("failed to get collection: %w", err)
+	}
+	live := resp.first()
+	if live == nil {
+		return fmt.Errorf("collection %s: empty response", collectionID)
+	}
+
+	client, err := getManagerClient(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	var liveRoles []policyRole
+	if policy.Roles != nil {
+		if liveRoles, err = listCollectionRoles(ctx, client, collectionID); err != nil {
+			return err
+		}
+	}
+
+	plan := planCollectionPolicy(policy, desiredRoles, live, liveRoles)
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		if err := formatter.FormatOutput(plan, nil); err != nil {
+			return err
+		}
+	} else {
+		printPolicyPlan(cmd.OutOrStdout(), collectionID, plan)
+	}
+
+	if len(plan) == 0 || !yes {
+		if len(plan) > 0 && formatter.Format != output.FormatJSON {
+			fmt.Fprintln(cmd.OutOrStdout(), "\nRun again with --yes to apply these changes.")
+		}
+		return nil
+	}
+
+	done := 0
+	fail := func(what string, err error) error {
+		return fmt.Errorf("%s failed after %d of %d changes: %w", what, done, len(plan), err)
+	}
+	for _, c := range plan {
+		if c.Action != "create" {
+			continue
+		}
+		if _, err := client.CreateRole(ctx, &gcs.GCSRoleDocument{Collection: collectionID, Principal: c.Role.Principal, Role: c.Role.Role}); err != nil {
+			return fail("create role "+c.Role.Role+" for "+c.Role.label(), err)
+		}
+		done++
+		fmt.Fprintf(cmd.ErrOrStderr(), "  Created: %s for %s\n", c.Role.Role, c.Role.label())
+	}
+
+	if patch := policyPatch(plan, live); patch != nil {
+		if err := api.DoRequest(ctx, "PATCH", "/collections/"+url.PathEscape(collectionID), nil, patch, nil); err != nil {
+			return fail("update collection", err)
+		}
+		for _, c := range plan {
+			if c.Action == "set" {
+				done++
+				fmt.Fprintf(cmd.ErrOrStderr(), "  Set: %s\n", c.Field)
+			}
+		}
+	}
+
+	for _, c := range plan {
+		if c.Action != "delete" {
+			continue
+		}
+		if err := client.DeleteRole(ctx, c.Role.ID); err != nil {
+			return fail("delete role "+c.Role.Role+" for "+c.Role.label(), err)
+		}
+		done++
+		fmt.Fprintf(cmd.ErrOrStderr(), "  Deleted: %s for %s\n", c.Role.Role, c.Role.label())
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Applied %d change(s) to collection %s.\n", done, collectionID)
+	return nil
+}
+
+// loadCollectionPolicy reads and validates a policy file.
+func loadCollectionPolicy(file string) (*collectionPolicy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return parseCollectionPolicy(bytes.NewReader(data), file)
+}
+
+// parseCollectionPolicy is loadCollectionPolicy over an io.Reader.
+func parseCollectionPolicy(r io.Reader, name string) (*collectionPolicy, error) {
+	var policy collectionPolicy
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", name, err)
+	}
+	if policy.Roles != nil {
+		for i, spec := range *policy.Roles {
+			if err := validatePolicyRole(spec); err != nil {
+				return nil, fmt.Errorf("%s: role %d: %w", name, i+1, err)
+			}
+		}
+	}
+	if s := policy.Sharing; s != nil && s.RestrictPaths != nil {
+		rp := s.RestrictPaths
+		for _, list := range [][]string{rp.Read, rp.ReadWrite, rp.None} {
+			for _, p := range list {
+				if !strings.HasPrefix(p, "/") {
+					return nil, fmt.Errorf("%s: sharing.restrict_paths: %q is not an absolute path", name, p)
+				}
+			}
+		}
+	}
+	return &policy, nil
+}
+
+// validatePolicyRole checks a role for mistakes the API would otherwise
+// reject one call at a time.
+func validatePolicyRole(spec policyRoleSpec) error {
+	n := 0
+	for _, v := range []string{spec.Identity, spec.Group, spec.Principal} {
+		if v != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("set exactly one of identity, group or principal")
+	}
+	if spec.Group != "" && !ids.IsUUID(spec.Group) {
+		return fmt.Errorf("group must be a group ID, got %q", spec.Group)
+	}
+	if p := strings.ToLower(spec.Principal); p != "" && !strings.HasPrefix(p, identityURNPrefix) && !strings.HasPrefix(p, groupURNPrefix) {
+		return fmt.Errorf("principal must be an identity or group URN, got %q", spec.Principal)
+	}
+	for _, r := range gcsRoles {
+		if spec.Role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("role must be one of %s, got %q", strings.Join(gcsRoles, ", "), spec.Role)
+}
+
+// identityLookup returns the identity ID for a username.
+type identityLookup func(ctx context.Context, username string) (string, error)
+
+// newIdentityLookup returns a lookup backed by the current profile's Auth
+// token, built on first use so policies naming identities by ID need none.
+func newIdentityLookup() identityLookup {
+	var client *auth.Client
+	return func(ctx context.Context, username string) (string, error) {
+		if client == nil {
+			clientCfg, err := config.LoadClientConfig()
+			if err != nil {
+				return "", fmt.Errorf("failed to load client configuration: %w", err)
+			}
+			cfg, err := globusauth.AuthClientConfig(ctx, viper.GetString("profile"), clientCfg.ClientID, clientCfg.ClientSecret)
+			if err != nil {
+				return "", fmt.Errorf("not logged in: %w", err)
+			}
+			if client, err = auth.NewClient(ctx, cfg); err != nil {
+				return "", fmt.Errorf("failed to create auth client: %w", err)
+			}
+		}
+		identities, err := client.GetIdentities(ctx, &auth.GetIdentitiesOptions{
+			Usernames: []string{username},
+			Provision: true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve identity %q: %w", username, err)
+		}
+		if len(identities) == 0 || identities[0].ID == "" {
+			return "", fmt.Errorf("no identity found for username %q", username)
+		}
+		return identities[0].ID, nil
+	}
+}
+
+// resolvePolicyRoles resolves each role's principal to a URN.
+func resolvePolicyRoles(ctx context.Context, lookup identityLookup, specs []policyRoleSpec) ([]policyRole, error) {
+	roles := make([]policyRole, 0, len(specs))
+	for i, spec := range specs {
+		role := policyRole{Role: spec.Role}
+		switch {
+		case spec.Principal != "":
+			role.Principal = strings.ToLower(spec.Principal)
+		case spec.Group != "":
+			role.Principal, role.Name = groupURNPrefix+strings.ToLower(spec.Group), spec.Group
+		case ids.IsUUID(spec.Identity):
+			role.Principal, role.Name = identityURNPrefix+strings.ToLower(spec.Identity), spec.Identity
+		default:
+			id, err := lookup(ctx, spec.Identity)
+			if err != nil {
+				return nil, fmt.Errorf("role %d: %w", i+1, err)
+			}
+			role.Principal, role.Name = identityURNPrefix+strings.ToLower(id), spec.Identity
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// listCollectionRoles returns every role assigned on the collection.
+func listCollectionRoles(ctx context.Context, client *gcs.CollectionClient, collectionID string) ([]policyRole, error) {
+	var roles []policyRole
+	opts := &gcs.RoleListOptions{CollectionID: collectionID}
+	for {
+		resp, err := client.GetRoleList(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, r := range resp.Data {
+			// Endpoint-level roles are not the collection's to manage.
+			if r.Collection == nil || !strings.EqualFold(*r.Collection, collectionID) {
+				continue
+			}
+			roles = append(roles, policyRole{ID: r.ID, Principal: strings.ToLower(r.Principal), Role: r.Role})
+		}
+		if !resp.HasNextPage || resp.Marker == "" {
+			return roles, nil
+		}
+		opts.Marker = resp.Marker
+	}
+}
+
+// planCollectionPolicy compares the policy with the live collection and its
+// roles. The plan lists role creates in file order, then field changes, then
+// role deletes; it is applied in that order.
+func planCollectionPolicy(policy *collectionPolicy, desiredRoles []policyRole, live map[string]interface{}, liveRoles []policyRole) []policyChange {
+	// An empty plan prints as [] in JSON, not null.
+	plan := []policyChange{}
+	var deletes []policyChange
+
+	if policy.Roles != nil {
+		have := map[string]bool{}
+		for _, r := range liveRoles {
+			have[r.key()] = true
+		}
+		want := map[string]bool{}
+		for _, r := range desiredRoles {
+			if want[r.key()] {
+				continue
+			}
+			want[r.key()] = true
+			if !have[r.key()] {
+				role := r
+				plan = append(plan, policyChange{Action: "create", Role: &role})
+			}
+		}
+		for _, r := range liveRoles {
+			if !want[r.key()] {
+				role := r
+				deletes = append(deletes, policyChange{Action: "delete", Role: &role})
+			}
+		}
+	}
+
+	set := func(field string, desired interface{}, same func(a, b interface{}) bool) {
+		current := live[field]
+		if !same(current, desired) {
+			plan = append(plan, policyChange{Action: "set", Field: field, Current: current, Desired: desired})
+		}
+	}
+	if s := policy.Sharing; s != nil {
+		if s.RestrictPaths != nil {
+			set("sharing_restrict_paths", restrictPathsDoc(s.RestrictPaths), sameRestrictPaths)
+		}
+		if s.UsersAllow != nil {
+			set("sharing_users_allow", *s.UsersAllow, sameStringSet)
+		}
+		if s.UsersDeny != nil {
+			set("sharing_users_deny", *s.UsersDeny, sameStringSet)
+		}
+	}
+	if g := policy.GuestCollections; g != nil {
+		if g.Allow != nil {
+			set("allow_guest_collections", *g.Allow, sameBool)
+		}
+		if g.DisableAnonymousWrites != nil {
+			set("disable_anonymous_writes", *g.DisableAnonymousWrites, sameBool)
+		}
+	}
+
+	return append(plan, deletes...)
+}
+
+// restrictPathsDoc builds a path_restrictions document, or nil when the
+// policy lists no paths (no restrictions).
+func restrictPathsDoc(rp *policyRestrictPaths) map[string]interface{} {
+	doc := map[string]interface{}{"DATA_TYPE": "path_restrictions#1.0.0"}
+	for field, paths := range map[string][]string{"read": rp.Read, "read_write": rp.ReadWrite, "none": rp.None} {
+		if len(paths) > 0 {
+			doc[field] = paths
+		}
+	}
+	if len(doc) == 1 {
+		return nil
+	}
+	return doc
+}
+
+// sameRestrictPaths compares path_restrictions documents by their path sets.
+func sameRestrictPaths(a, b interface{}) bool {
+	am, _ := a.(map[string]interface{})
+	bm, _ := b.(map[string]interface{})
+	for _, field := range []string{"read", "read_write", "none"} {
+		if !sameStringSet(am[field], bm[field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStringSet compares two string lists ignoring order; a missing list is
+// empty.
+func sameStringSet(a, b interface{}) bool {
+	al, _ := stringList(a)
+	bl, _ := stringList(b)
+	al, bl = append([]string(nil), al...), append([]string(nil), bl...)
+	sort.Strings(al)
+	sort.Strings(bl)
+	return reflect.DeepEqual(al, bl)
+}
+
+// sameBool compares booleans; a missing value is false.
+func sameBool(a, b interface{}) bool {
+	av, _ := a.(bool)
+	bv, _ := b.(bool)
+	return av == bv
+}
+
+// policyPatch builds the collection update for the plan's field changes, or
+// nil if there are none. It carries the live document's DATA_TYPE so the
+// fields are read at the version the collection already uses.
+func policyPatch(plan []policyChange, live map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for _, c := range plan {
+		if c.Action == "set" {
+			patch[c.Field] = c.Desired
+		}
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	if dt := docString(live, "DATA_TYPE"); dt != "" {
+		patch["DATA_TYPE"] = dt
+	}
+	return patch
+}
+
+// printPolicyPlan prints a plan in text form, one line per change.
+func printPolicyPlan(w io.Writer, collectionID string, plan []policyChange) {
+	if len(plan) == 0 {
+		fmt.Fprintf(w, "Collection %s already matches the policy; no changes.\n", collectionID)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, c := range plan {
+		counts[c.Action]++
+	}
+	fmt.Fprintf(w, "Plan for collection %s: %d role(s) to create, %d field(s) to set, %d role(s) to delete\n\n",
+		collectionID, counts["create"], counts["set"], counts["delete"])
+
+	for _, c := range plan {
+		switch c.Action {
+		case "create":
+			fmt.Fprintf(w, "  + role    %s  %s\n", c.Role.Role, c.Role.label())
+		case "set":
+			fmt.Fprintf(w, "  ~ set     %s: %s -> %s\n", c.Field, formatPolicyValue(c.Current), formatPolicyValue(c.Desired))
+		case "delete":
+			fmt.Fprintf(w, "  - role    %s  %s  (role %s)\n", c.Role.Role, c.Role.label(), c.Role.ID)
+		}
+	}
+}
+
+// formatPolicyValue renders a field value for plan output.
+func formatPolicyValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "-"
+	case map[string]interface{}:
+		var parts []string
+		for _, field := range []string{"read", "read_write", "none"} {
+			if list, _ := stringList(t[field]); len(list) > 0 {
+				parts = append(parts, field+"="+strings.Join(list, ","))
+			}
+		}
+		if len(parts) == 0 {
+			return "-"
+		}
+		return strings.Join(parts, " ")
+	}
+	if list, ok := stringList(v); ok {
+		if len(list) == 0 {
+			return "[]"
+		}
+		return "[" + strings.Join(list, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", v)
+}
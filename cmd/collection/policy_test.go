@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const (
+	aliceID = "11111111-1111-1111-1111-111111111111"
+	bobID   = "22222222-2222-2222-2222-222222222222"
+	labID   = "33333333-3333-3333-3333-333333333333"
+)
+
+// stubLookup knows alice@example.org.
+func stubLookup(_ context.Context, username string) (string, error) {
+	if username == "alice@example.org" {
+		return aliceID, nil
+	}
+	return "", fmt.Errorf("no identity found for username %q", username)
+}
+
+const testPolicy = `
+roles:
+  - identity: alice@example.org
+    role: administrator
+  - group: ` + labID + `
+    role: access_manager
+sharing:
+  restrict_paths:
+    read_write: [/projects/]
+    none: [/projects/private/]
+  users_allow: [alice, bob]
+guest_collections:
+  allow: true
+`
+
+// TestParseCollectionPolicy checks validation of policy files.
+func TestParseCollectionPolicy(t *testing.T) {
+	policy, err := parseCollectionPolicy(strings.NewReader(testPolicy), "policy.yaml")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(*policy.Roles) != 2 || policy.Sharing.UsersDeny != nil || !*policy.GuestCollections.Allow {
+		t.Errorf("policy = %+v", policy)
+	}
+
+	for name, body := range map[string]string{
+		"two principals": "roles:\n  - identity: a@b.org\n    group: " + labID + "\n    role: administrator\n",
+		"group name":     "roles:\n  - group: Lab\n    role: administrator\n",
+		"unknown role":   "roles:\n  - identity: a@b.org\n    role: admin\n",
+		"relative path":  "sharing:\n  restrict_paths:\n    read: [projects]\n",
+		"unknown field":  "sharing:\n  users: [alice]\n",
+		"bare principal": "roles:\n  - principal: " + labID + "\n    role: administrator\n",
+	} {
+		if _, err := parseCollectionPolicy(strings.NewReader(body), "policy.yaml"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	upper := "roles:\n  - principal: URN:Globus:Groups:ID:" + labID + "\n    role: administrator\n"
+	if _, err := parseCollectionPolicy(strings.NewReader(upper), "policy.yaml"); err != nil {
+		t.Errorf("upper-case principal URN: %v", err)
+	}
+}
+
+// TestPlanCollectionPolicy checks role and field diffs against live state.
+func TestPlanCollectionPolicy(t *testing.T) {
+	policy, err := parseCollectionPolicy(strings.NewReader(testPolicy), "policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired, err := resolvePolicyRoles(context.Background(), stubLookup, *policy.Roles)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	live := map[string]interface{}{
+		"DATA_TYPE": "collection#1.7.0",
+		"sharing_restrict_paths": map[string]interface{}{
+			"DATA_TYPE":  "path_restrictions#1.0.0",
+			"read_write": []interface{}{"/projects/"},
+		},
+		"sharing_users_allow":     []interface{}{"bob", "alice"},
+		"allow_guest_collections": false,
+	}
+	liveRoles := []policyRole{
+		{ID: "r1", Principal: identityURNPrefix + aliceID, Role: "administrator"},
+		{ID: "r2", Principal: identityURNPrefix + bobID, Role: "administrator"},
+	}
+
+	plan := planCollectionPolicy(policy, desired, live, liveRoles)
+	var got []string
+	for _, c := range plan {
+		switch c.Action {
+		case "set":
+			got = append(got, "set "+c.Field)
+		default:
+			got = append(got, c.Action+" "+c.Role.Principal+" "+c.Role.Role)
+		}
+	}
+	want := []string{
+		"create " + groupURNPrefix + labID + " access_manager",
+		"set sharing_restrict_paths",
+		"set allow_guest_collections",
+		"delete " + identityURNPrefix + bobID + " administrator",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("plan:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	patch := policyPatch(plan, live)
+	if patch["DATA_TYPE"] != "collection#1.7.0" || patch["allow_guest_collections"] != true || len(patch) != 3 {
+		t.Errorf("patch = %v", patch)
+	}
+
+	// Applying the plan's result again changes nothing.
+	live["sharing_restrict_paths"] = patch["sharing_restrict_paths"]
+	live["allow_guest_collections"] = true
+	liveRoles = append(liveRoles[:1], policyRole{ID: "r3", Principal: groupURNPrefix + labID, Role: "access_manager"})
+	plan = planCollectionPolicy(policy, desired, live, liveRoles)
+	if data, _ := json.Marshal(plan); string(data) != "[]" {
+		t.Errorf("second plan = %s, want no changes", data)
+	}
+}
+
+// TestPlanCollectionPolicyClears checks that empty declarations clear live
+// settings and that undeclared sections are left alone.
+func TestPlanCollectionPolicyClears(t *testing.T) {
+	policy, err := parseCollectionPolicy(strings.NewReader("sharing:\n  restrict_paths: {}\n  users_deny: []\n"), "policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := map[string]interface{}{
+		"sharing_restrict_paths":  map[string]interface{}{"DATA_TYPE": "path_restrictions#1.0.0", "none": []interface{}{"/etc/"}},
+		"sharing_users_deny":      []interface{}{"mallory"},
+		"allow_guest_collections": true,
+	}
+	plan := planCollectionPolicy(policy, nil, live, []policyRole{{ID: "r1", Principal: identityURNPrefix + aliceID, Role: "owner"}})
+	if len(plan) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	patch := policyPatch(plan, live)
+	if rp, ok := patch["sharing_restrict_paths"]; !ok || rp.(map[string]interface{}) != nil {
+		t.Errorf("sharing_restrict_paths = %v, want null", rp)
+	}
+	if deny, _ := stringList(patch["sharing_users_deny"]); len(deny) != 0 {
+		t.Errorf("sharing_users_deny = %v, want []", patch["sharing_users_deny"])
+	}
+}
+
+// TestResolvePolicyRoles checks principal resolution.
+func TestResolvePolicyRoles(t *testing.T) {
+	roles, err := resolvePolicyRoles(context.Background(), stubLookup, []policyRoleSpec{
+		{Identity: strings.ToUpper(bobID), Role: "activity_monitor"},
+		{Principal: "URN:globus:groups:id:" + labID, Role: "owner"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles[0].Principal != identityURNPrefix+bobID || roles[1].Principal != groupURNPrefix+labID {
+		t.Errorf("roles = %+v", roles)
+	}
+	if _, err := resolvePolicyRoles(context.Background(), stubLookup, []policyRoleSpec{{Identity: "nobody@example.org", Role: "owner"}}); err == nil || !strings.Contains(err.Error(), "role 1") {
+		t.Errorf("err = %v", err)
+	}
+}
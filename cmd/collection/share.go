@@ -0,0 +1,565 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/config"
+	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
+	"github.com/scttfrdmn/globus-go-cli/pkg/ids"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/groups"
+)
+
+// shareOptions holds the collection share flags.
+type shareOptions struct {
+	With             []string
+	Permissions      string
+	Expires          string
+	DisplayName      string
+	UserCredentialID string
+	LocalUsername    string
+}
+
+// sharePrincipal is a resolved --with value.
+type sharePrincipal struct {
+	Type string `json:"principal_type"` // identity or group
+	ID   string `json:"principal"`
+	Name string `json:"name"`
+}
+
+// shareGrant is one access rule the share created, updated or found in place.
+type shareGrant struct {
+	sharePrincipal
+	Action      string `json:"action"` // create, update or keep
+	RuleID      string `json:"rule_id,omitempty"`
+	Permissions string `json:"permissions"`
+	Expiration  string `json:"expiration_date,omitempty"`
+
+	prevPermissions string
+	prevExpiration  string
+}
+
+// shareResult is what collection share reports.
+type shareResult struct {
+	CollectionID string       `json:"collection_id"`
+	DisplayName  string       `json:"display_name"`
+	BasePath     string       `json:"collection_base_path"`
+	Created      bool         `json:"created"`
+	URL          string       `json:"url"`
+	Grants       []shareGrant `json:"grants"`
+}
+
+// shareManager is the subset of the GCS CollectionClient collection share uses.
+type shareManager interface {
+	GetCollection(ctx context.Context, collectionID string, opts *gcs.GetCollectionOptions) (*gcs.Collection, error)
+	ListCollections(ctx context.Context, options *gcs.ListCollectionsOptions) (*gcs.CollectionListResponse, error)
+	GetUserCredentialList(ctx context.Context, options *gcs.UserCredentialListOptions) (*gcs.UserCredentialListResponse, error)
+	CreateCollection(ctx context.Context, doc *gcs.CollectionDocument) (*gcs.Collection, error)
+	DeleteCollection(ctx context.Context, collectionID string) error
+}
+
+// shareACLClient is the subset of the Transfer client collection share uses.
+type shareACLClient interface {
+	EndpointACLList(ctx context.Context, endpointID string) (map[string]interface{}, error)
+	AddEndpointACLRule(ctx context.Context, endpointID string, doc map[string]interface{}) (map[string]interface{}, error)
+	UpdateEndpointACLRule(ctx context.Context, endpointID, ruleID string, doc map[string]interface{}) (map[string]interface{}, error)
+	DeleteEndpointACLRule(ctx context.Context, endpointID, ruleID string) (map[string]interface{}, error)
+}
+
+// groupLookup returns the group ID for a group name.
+type groupLookup func(ctx context.Context, name string) (string, error)
+
+// collectionShareCmd returns the collection share command.
+func collectionShareCmd() *cobra.Command {
+	opts := &shareOptions{}
+	cmd := &cobra.Command{
+		Use:   "share ENDPOINT_ID MAPPED_COLLECTION_ID PATH",
+		Short: "Share a path with collaborators through a guest collection",
+		Long: `Create a guest collection rooted at PATH on a mapped collection, grant each
+--with principal access to it, and print its Globus web app URL.
+
+A guest collection you can see that is already rooted at PATH on the mapped
+collection is reused, and access rules the principals already hold on it are
+kept or updated rather than duplicated. --with takes a username
+(user@example.org), an identity ID, or group:NAME / group:ID; group names are
+matched against your own groups.
+
+New guest collections use --user-credential-id, or the one credential you hold
+on the mapped collection's storage gateway (--local-username picks among
+several).
+
+If any step fails, the rules created or changed so far are reverted and a
+guest collection created by this run is deleted.`,
+		Example: `  globus collection share EP_ID MAPPED_ID /projects/lab/results \
+    --with alice@example.org --with group:Lab --permissions rw --expires 2026-12-31`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCollectionShare(cmd, args[0], args[1], args[2], opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.With, "with", nil, "Username, identity ID, or group:NAME|ID to share with; may be given multiple times (required)")
+	cmd.Flags().StringVar(&opts.Permissions, "permissions", "r", "Permissions to grant: r or rw")
+	cmd.Flags().StringVar(&opts.Expires, "expires", "", "Expiration for the access rules (YYYY-MM-DD or an ISO 8601 timestamp)")
+	cmd.Flags().StringVar(&opts.DisplayName, "display-name", "", "Display name for a new guest collection (defaults to the last element of PATH)")
+	cmd.Flags().StringVar(&opts.UserCredentialID, "user-credential-id", "", "User credential for a new guest collection")
+	cmd.Flags().StringVar(&opts.LocalUsername, "local-username", "", "Pick the user credential with this local username")
+	_ = cmd.MarkFlagRequired("with")
+
+	return cmd
+}
+
+// runCollectionShare validates the flags, resolves the principals and shares
+// the path.
+func runCollectionShare(cmd *cobra.Command, endpointID, mappedID, sharePath string, opts *shareOptions) error {
+	if err := validateShareOptions(opts); err != nil {
+		return err
+	}
+	basePath, err := normalizeSharePath(sharePath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	principals, err := resolveSharePrincipals(ctx, newIdentityLookup(), newGroupLookup(), opts.With)
+	if err != nil {
+		return err
+	}
+
+	client, err := getManagerClient(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	tc, err := getTransferClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := shareCollection(ctx, client, tc, mappedID, basePath, principals, opts, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		return formatter.FormatOutput(result, nil)
+	}
+	printShareResult(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// validateShareOptions checks the flags that need no API call.
+func validateShareOptions(opts *shareOptions) error {
+	if opts.Permissions != "r" && opts.Permissions != "rw" {
+		return fmt.Errorf("--permissions must be r or rw, got %q", opts.Permissions)
+	}
+	if opts.Expires != "" {
+		t, ok := parseShareTime(opts.Expires)
+		if !ok {
+			return fmt.Errorf("invalid --expires %q (use YYYY-MM-DD or an ISO 8601 timestamp)", opts.Expires)
+		}
+		if !t.After(time.Now()) {
+			return fmt.Errorf("--expires %s is in the past", opts.Expires)
+		}
+	}
+	if opts.UserCredentialID != "" && opts.LocalUsername != "" {
+		return fmt.Errorf("--user-credential-id and --local-username are mutually exclusive")
+	}
+	return nil
+}
+
+// normalizeSharePath requires an absolute path and returns it cleaned, with a
+// trailing slash as GCS reports collection_base_path.
+func normalizeSharePath(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("PATH must be absolute, got %q", p)
+	}
+	p = gopath.Clean(p)
+	if p != "/" {
+		p += "/"
+	}
+	return p, nil
+}
+
+// parseShareTime parses an --expires value or a Transfer expiration_date.
+func parseShareTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05-07:00", "2006-01-02T15:04:05", "2006-01-02 15:04:05-07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sameShareTime reports whether two expirations denote the same instant (or
+// are both unset).
+func sameShareTime(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	ta, okA := parseShareTime(a)
+	tb, okB := parseShareTime(b)
+	if !okA || !okB {
+		return a == b
+	}
+	return ta.Equal(tb)
+}
+
+// newGroupLookup returns a lookup over the caller's groups, backed by the
+// current profile's Groups token and built on first use.
+func newGroupLookup() groupLookup {
+	var myGroups []groups.Group
+	loaded := false
+	return func(ctx context.Context, name string) (string, error) {
+		if !loaded {
+			clientCfg, err := config.LoadClientConfig()
+			if err != nil {
+				return "", fmt.Errorf("failed to load client configuration: %w", err)
+			}
+			cfg, err := globusauth.ClientConfig(ctx, viper.GetString("profile"), clientCfg.ClientID, clientCfg.ClientSecret, globusauth.ServiceGroups)
+			if err != nil {
+				return "", fmt.Errorf("not logged in: %w", err)
+			}
+			client, err := groups.NewClient(ctx, cfg)
+			if err != nil {
+				return "", fmt.Errorf("failed to create groups client: %w", err)
+			}
+			if myGroups, err = client.GetMyGroups(ctx, nil); err != nil {
+				return "", fmt.Errorf("failed to list groups: %w", err)
+			}
+			loaded = true
+		}
+
+		var groupIDs []string
+		for _, g := range myGroups {
+			if g.Name == name {
+				groupIDs = append(groupIDs, g.ID)
+			}
+		}
+		switch len(groupIDs) {
+		case 0:
+			return "", fmt.Errorf("no group named %q among your groups (use group:ID instead)", name)
+		case 1:
+			return groupIDs[0], nil
+		default:
+			return "", fmt.Errorf("group name %q is ambiguous (%s); use group:ID instead", name, strings.Join(groupIDs, ", "))
+		}
+	}
+}
+
+// resolveSharePrincipals resolves every --with value before anything is
+// changed. A principal named twice is an error.
+func resolveSharePrincipals(ctx context.Context, identities identityLookup, groupsByName groupLookup, with []string) ([]sharePrincipal, error) {
+	principals := make([]sharePrincipal, 0, len(with))
+	seen := map[string]string{}
+	for _, v := range with {
+		p := sharePrincipal{Type: "identity", Name: v}
+		name := v
+		if rest, ok := strings.CutPrefix(v, "group:"); ok {
+			p.Type, name = "group", rest
+		}
+		if name == "" {
+			return nil, fmt.Errorf("invalid --with %q", v)
+		}
+
+		var err error
+		switch {
+		case ids.IsUUID(name):
+			p.ID = strings.ToLower(name)
+		case p.Type == "group":
+			p.ID, err = groupsByName(ctx, name)
+		default:
+			p.ID, err = identities(ctx, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("--with %s: %w", v, err)
+		}
+		p.ID = strings.ToLower(p.ID)
+
+		key := p.Type + ":" + p.ID
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("--with %s and --with %s name the same %s", prev, v, p.Type)
+		}
+		seen[key] = v
+		principals = append(principals, p)
+	}
+	return principals, nil
+}
+
+// shareCollection finds or creates the guest collection and grants each
+// principal access to its root. On failure every change made so far is
+// undone, newest first; rollback progress is written to log.
+func shareCollection(ctx context.Context, client shareManager, acl shareACLClient, mappedID, basePath string, principals []sharePrincipal, opts *shareOptions, log io.Writer) (*shareResult, error) {
+	mapped, err := client.GetCollection(ctx, mappedID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapped collection: %w", err)
+	}
+	if mapped.CollectionType != "mapped" {
+		return nil, fmt.Errorf("collection %s is a %s collection; share needs a mapped collection", mappedID, mapped.CollectionType)
+	}
+
+	guest, err := findGuestCollection(ctx, client, mappedID, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &shareResult{BasePath: basePath}
+	var liveRules []map[string]interface{}
+	if guest != nil {
+		resp, err := acl.EndpointACLList(ctx, guest.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list access rules on guest collection %s: %w", guest.ID, err)
+		}
+		data, _ := resp["DATA"].([]interface{})
+		for _, item := range data {
+			if m, ok := item.(map[string]interface{}); ok {
+				liveRules = append(liveRules, m)
+			}
+		}
+	} else {
+		credentialID, err := selectUserCredential(ctx, client, mapped.StorageGatewayID, opts)
+		if err != nil {
+			return nil, err
+		}
+		displayName := opts.DisplayName
+		if displayName == "" {
+			displayName = gopath.Base(basePath)
+			if displayName == "/" {
+				displayName = mapped.DisplayName
+			}
+		}
+		guest, err = client.CreateCollection(ctx, &gcs.CollectionDocument{
+			CollectionType:     "guest",
+			DisplayName:        displayName,
+			MappedCollectionID: mappedID,
+			CollectionBasePath: basePath,
+			UserCredentialID:   credentialID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create guest collection: %w", err)
+		}
+		result.Created = true
+	}
+	result.CollectionID, result.DisplayName = guest.ID, guest.DisplayName
+	result.URL = shareURL(guest.ID)
+
+	for _, p := range principals {
+		grant := planShareGrant(p, liveRules, opts)
+		if err := applyShareGrant(ctx, acl, guest.ID, &grant); err != nil {
+			err = fmt.Errorf("failed to grant %s to %s: %w", opts.Permissions, p.Name, err)
+			return nil, rollbackShare(ctx, client, acl, result, err, log)
+		}
+		result.Grants = append(result.Grants, grant)
+	}
+	return result, nil
+}
+
+// findGuestCollection returns the guest collection on mappedID rooted at
+// basePath, or nil.
+func findGuestCollection(ctx context.Context, client shareManager, mappedID, basePath string) (*gcs.Collection, error) {
+	opts := &gcs.ListCollectionsOptions{MappedCollectionID: mappedID, Filter: []string{"guest_collections"}, PageSize: inventoryPageSize}
+	for {
+		resp, err := client.ListCollections(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list guest collections: %w", err)
+		}
+		for i, c := range resp.Data {
+			if c.CollectionType != "guest" || !strings.EqualFold(c.MappedCollectionID, mappedID) {
+				continue
+			}
+			if p, err := normalizeSharePath(c.CollectionBasePath); err == nil && p == basePath {
+				return &resp.Data[i], nil
+			}
+		}
+		if !resp.HasNextPage || resp.Marker == "" {
+			return nil, nil
+		}
+		opts.Marker = resp.Marker
+	}
+}
+
+// selectUserCredential picks the credential for a new guest collection: the
+// one given, the one with --local-username, or the caller's only credential on
+// the storage gateway. With no credential at all the GCS Manager decides.
+func selectUserCredential(ctx context.Context, client shareManager, storageGatewayID string, opts *shareOptions) (string, error) {
+	if opts.UserCredentialID != "" {
+		return opts.UserCredentialID, nil
+	}
+	resp, err := client.GetUserCredentialList(ctx, &gcs.UserCredentialListOptions{StorageGateway: storageGatewayID})
+	if err != nil {
+		return "", fmt.Errorf("failed to list user credentials: %w", err)
+	}
+
+	var matches []gcs.UserCredential
+	for _, c := range resp.Data {
+		if opts.LocalUsername == "" || c.Username == opts.LocalUsername {
+			matches = append(matches, c)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return matches[0].ID, nil
+	case len(matches) == 0 && opts.LocalUsername != "":
+		return "", fmt.Errorf("no user credential with local username %q on storage gateway %s", opts.LocalUsername, storageGatewayID)
+	case len(matches) == 0:
+		return "", nil
+	default:
+		names := make([]string, len(matches))
+		for i, c := range matches {
+			names[i] = c.Username
+		}
+		return "", fmt.Errorf("you hold %d user credentials on storage gateway %s (%s); pick one with --local-username or --user-credential-id",
+			len(matches), storageGatewayID, strings.Join(names, ", "))
+	}
+}
+
+// planShareGrant decides what to do for one principal given the rules
+// already on the guest collection's root.
+func planShareGrant(p sharePrincipal, liveRules []map[string]interface{}, opts *shareOptions) shareGrant {
+	grant := shareGrant{sharePrincipal: p, Action: "create", Permissions: opts.Permissions, Expiration: opts.Expires}
+	for _, r := range liveRules {
+		if docString(r, "principal_type") != p.Type || !strings.EqualFold(docString(r, "principal"), p.ID) || docString(r, "path") != "/" {
+			continue
+		}
+		grant.RuleID = docString(r, "id")
+		grant.prevPermissions = docString(r, "permissions")
+		grant.prevExpiration = docString(r, "expiration_date")
+		if grant.prevPermissions == opts.Permissions && sameShareTime(grant.prevExpiration, opts.Expires) {
+			grant.Action = "keep"
+		} else {
+			grant.Action = "update"
+		}
+		break
+	}
+	return grant
+}
+
+// applyShareGrant performs one grant, recording a created rule's ID.
+func applyShareGrant(ctx context.Context, acl shareACLClient, collectionID string, grant *shareGrant) error {
+	switch grant.Action {
+	case "create":
+		doc := map[string]interface{}{
+			"DATA_TYPE":      "access",
+			"principal_type": grant.Type,
+			"principal":      grant.ID,
+			"path":           "/",
+			"permissions":    grant.Permissions,
+		}
+		if grant.Expiration != "" {
+			doc["expiration_date"] = grant.Expiration
+		}
+		resp, err := acl.AddEndpointACLRule(ctx, collectionID, doc)
+		if err != nil {
+			return err
+		}
+		// Classic endpoints number their rules; collections use UUIDs.
+		if id, ok := resp["access_id"]; ok && id != nil {
+			grant.RuleID = fmt.Sprint(id)
+		}
+		return nil
+	case "update":
+		_, err := acl.UpdateEndpointACLRule(ctx, collectionID, grant.RuleID, shareRuleUpdate(grant.Permissions, grant.Expiration))
+		return err
+	default:
+		return nil
+	}
+}
+
+// shareRuleUpdate is the PUT body setting a rule's permissions and
+// expiration; an explicit null clears the expiration.
+func shareRuleUpdate(permissions, expiration string) map[string]interface{} {
+	doc := map[string]interface{}{"DATA_TYPE": "access", "permissions": permissions, "expiration_date": nil}
+	if expiration != "" {
+		doc["expiration_date"] = expiration
+	}
+	return doc
+}
+
+// rollbackShare undoes result's grants, newest first, then deletes the guest
+// collection if this run created it. It returns cause, annotated with any
+// step that could not be undone.
+func rollbackShare(ctx context.Context, client shareManager, acl shareACLClient, result *shareResult, cause error, log io.Writer) error {
+	fmt.Fprintf(log, "%v; rolling back\n", cause)
+	// Roll back even if the failure was the run's own deadline.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 60*time.Second)
+	defer cancel()
+
+	var failed []string
+	for i := len(result.Grants) - 1; i >= 0; i-- {
+		grant := result.Grants[i]
+		var err error
+		switch grant.Action {
+		case "create":
+			if result.Created {
+				// Deleting the collection removes its rules.
+				continue
+			}
+			_, err = acl.DeleteEndpointACLRule(ctx, result.CollectionID, grant.RuleID)
+			if err == nil {
+				fmt.Fprintf(log, "  deleted access rule %s for %s\n", grant.RuleID, grant.Name)
+			}
+		case "update":
+			_, err = acl.UpdateEndpointACLRule(ctx, result.CollectionID, grant.RuleID, shareRuleUpdate(grant.prevPermissions, grant.prevExpiration))
+			if err == nil {
+				fmt.Fprintf(log, "  restored access rule %s for %s to %s\n", grant.RuleID, grant.Name, grant.prevPermissions)
+			}
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("access rule %s for %s: %v", grant.RuleID, grant.Name, err))
+		}
+	}
+	if result.Created {
+		if err := client.DeleteCollection(ctx, result.CollectionID); err != nil {
+			failed = append(failed, fmt.Sprintf("guest collection %s: %v", result.CollectionID, err))
+		} else {
+			fmt.Fprintf(log, "  deleted guest collection %s\n", result.CollectionID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%w\nrollback incomplete; clean up by hand:\n  %s", cause, strings.Join(failed, "\n  "))
+	}
+	return cause
+}
+
+// shareURL is the Globus web app link to a collection's root.
+func shareURL(collectionID string) string {
+	return "https://app.globus.org/file-manager?origin_id=" + url.QueryEscape(collectionID) + "&origin_path=%2F"
+}
+
+// printShareResult prints the share in text form.
+func printShareResult(w io.Writer, result *shareResult) {
+	verb := "Reusing"
+	if result.Created {
+		verb = "Created"
+	}
+	fmt.Fprintf(w, "%s guest collection %s (%s) at %s\n", verb, result.CollectionID, result.DisplayName, result.BasePath)
+	for _, g := range result.Grants {
+		expires := ""
+		if g.Expiration != "" {
+			expires = " until " + g.Expiration
+		}
+		switch g.Action {
+		case "create":
+			fmt.Fprintf(w, "  + %s %s: %s%s\n", g.Type, g.Name, g.Permissions, expires)
+		case "update":
+			fmt.Fprintf(w, "  ~ %s %s: %s -> %s%s\n", g.Type, g.Name, g.prevPermissions, g.Permissions, expires)
+		default:
+			fmt.Fprintf(w, "  = %s %s: %s%s (unchanged)\n", g.Type, g.Name, g.Permissions, expires)
+		}
+	}
+	fmt.Fprintf(w, "URL: %s\n", result.URL)
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+)
+
+// fakeShareManager records the GCS Manager calls collection share makes.
+type fakeShareManager struct {
+	guests      []gcs.Collection
+	credentials []gcs.UserCredential
+	created     *gcs.CollectionDocument
+	deleted     []string
+}
+
+func (f *fakeShareManager) GetCollection(_ context.Context, id string, _ *gcs.GetCollectionOptions) (*gcs.Collection, error) {
+	return &gcs.Collection{ID: id, CollectionType: "mapped", DisplayName: "Lab storage", StorageGatewayID: "sg1"}, nil
+}
+
+func (f *fakeShareManager) ListCollections(context.Context, *gcs.ListCollectionsOptions) (*gcs.CollectionListResponse, error) {
+	return &gcs.CollectionListResponse{Data: f.guests}, nil
+}
+
+func (f *fakeShareManager) GetUserCredentialList(context.Context, *gcs.UserCredentialListOptions) (*gcs.UserCredentialListResponse, error) {
+	return &gcs.UserCredentialListResponse{Data: f.credentials}, nil
+}
+
+func (f *fakeShareManager) CreateCollection(_ context.Context, doc *gcs.CollectionDocument) (*gcs.Collection, error) {
+	f.created = doc
+	return &gcs.Collection{ID: "guest-new", DisplayName: doc.DisplayName}, nil
+}
+
+func (f *fakeShareManager) DeleteCollection(_ context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+// fakeACL is a Transfer access list that fails to grant failPrincipal.
+type fakeACL struct {
+	rules         []interface{}
+	failPrincipal string
+	calls         []string
+	next          int
+}
+
+func (f *fakeACL) EndpointACLList(context.Context, string) (map[string]interface{}, error) {
+	return map[string]interface{}{"DATA": f.rules}, nil
+}
+
+func (f *fakeACL) AddEndpointACLRule(_ context.Context, id string, doc map[string]interface{}) (map[string]interface{}, error) {
+	if doc["principal"] == f.failPrincipal {
+		return nil, errors.New("403 permission denied")
+	}
+	f.next++
+	f.calls = append(f.calls, fmt.Sprintf("add %s %s %s", id, doc["principal"], doc["permissions"]))
+	return map[string]interface{}{"access_id": fmt.Sprintf("rule-%d", f.next)}, nil
+}
+
+func (f *fakeACL) UpdateEndpointACLRule(_ context.Context, id, ruleID string, doc map[string]interface{}) (map[string]interface{}, error) {
+	f.calls = append(f.calls, fmt.Sprintf("update %s %s %s %v", id, ruleID, doc["permissions"], doc["expiration_date"]))
+	return map[string]interface{}{}, nil
+}
+
+func (f *fakeACL) DeleteEndpointACLRule(_ context.Context, id, ruleID string) (map[string]interface{}, error) {
+	f.calls = append(f.calls, fmt.Sprintf("delete %s %s", id, ruleID))
+	return map[string]interface{}{}, nil
+}
+
+var (
+	alicePrincipal = sharePrincipal{Type: "identity", ID: aliceID, Name: "alice@example.org"}
+	labPrincipal   = sharePrincipal{Type: "group", ID: labID, Name: "group:Lab"}
+)
+
+// TestShareCollectionCreates checks a new guest collection with fresh rules.
+func TestShareCollectionCreates(t *testing.T) {
+	mgr := &fakeShareManager{credentials: []gcs.UserCredential{{ID: "cred1", Username: "alice"}}}
+	acl := &fakeACL{}
+	opts := &shareOptions{Permissions: "rw"}
+
+	result, err := shareCollection(context.Background(), mgr, acl, "mapped1", "/projects/results/", []sharePrincipal{alicePrincipal, labPrincipal}, opts, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mgr.created == nil || mgr.created.CollectionType != "guest" || mgr.created.CollectionBasePath != "/projects/results/" ||
+		mgr.created.UserCredentialID != "cred1" || mgr.created.DisplayName != "results" {
+		t.Errorf("created = %+v", mgr.created)
+	}
+	if !result.Created || result.URL != "https://app.globus.org/file-manager?origin_id=guest-new&origin_path=%2F" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Grants) != 2 || result.Grants[1].RuleID != "rule-2" {
+		t.Errorf("grants = %+v", result.Grants)
+	}
+}
+
+// TestShareCollectionReuses checks that an existing guest collection and its
+// rules are reused.
+func TestShareCollectionReuses(t *testing.T) {
+	mgr := &fakeShareManager{guests: []gcs.Collection{
+		{ID: "other", CollectionType: "guest", MappedCollectionID: "mapped1", CollectionBasePath: "/projects/"},
+		{ID: "guest1", DisplayName: "results", CollectionType: "guest", MappedCollectionID: "mapped1", CollectionBasePath: "/projects/results"},
+	}}
+	acl := &fakeACL{rules: []interface{}{
+		map[string]interface{}{"id": "r1", "principal_type": "identity", "principal": aliceID, "path": "/", "permissions": "r"},
+		map[string]interface{}{"id": "r2", "principal_type": "group", "principal": labID, "path": "/", "permissions": "rw"},
+	}}
+	opts := &shareOptions{Permissions: "rw"}
+
+	result, err := shareCollection(context.Background(), mgr, acl, "mapped1", "/projects/results/", []sharePrincipal{alicePrincipal, labPrincipal}, opts, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created || result.CollectionID != "guest1" || mgr.created != nil {
+		t.Errorf("result = %+v", result)
+	}
+	if got := strings.Join(acl.calls, "\n"); got != "update guest1 r1 rw <nil>" {
+		t.Errorf("calls:\n%s", got)
+	}
+	if result.Grants[0].Action != "update" || result.Grants[1].Action != "keep" {
+		t.Errorf("grants = %+v", result.Grants)
+	}
+}
+
+// TestShareCollectionRollsBack checks that a failed grant undoes earlier
+// changes.
+func TestShareCollectionRollsBack(t *testing.T) {
+	opts := &shareOptions{Permissions: "rw"}
+
+	// A created collection is deleted, taking its rules with it.
+	mgr := &fakeShareManager{}
+	acl := &fakeACL{failPrincipal: labID}
+	var log bytes.Buffer
+	_, err := shareCollection(context.Background(), mgr, acl, "mapped1", "/data/", []sharePrincipal{alicePrincipal, labPrincipal}, opts, &log)
+	if err == nil || !strings.Contains(err.Error(), "failed to grant rw to group:Lab") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(mgr.deleted) != 1 || mgr.deleted[0] != "guest-new" || len(acl.calls) != 1 {
+		t.Errorf("deleted = %v, calls = %v", mgr.deleted, acl.calls)
+	}
+	if !strings.Contains(log.String(), "deleted guest collection guest-new") {
+		t.Errorf("log = %s", log.String())
+	}
+
+	// On a reused collection, created rules are deleted and updated ones
+	// restored.
+	mgr = &fakeShareManager{guests: []gcs.Collection{{ID: "guest1", CollectionType: "guest", MappedCollectionID: "mapped1", CollectionBasePath: "/data/"}}}
+	acl = &fakeACL{failPrincipal: labID, rules: []interface{}{
+		map[string]interface{}{"id": "r1", "principal_type": "identity", "principal": aliceID, "path": "/", "permissions": "r", "expiration_date": "2030-01-01T00:00:00+00:00"},
+	}}
+	bob := sharePrincipal{Type: "identity", ID: bobID, Name: "bob@example.org"}
+	_, err = shareCollection(context.Background(), mgr, acl, "mapped1", "/data/", []sharePrincipal{alicePrincipal, bob, labPrincipal}, opts, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := []string{
+		"update guest1 r1 rw <nil>",
+		"add guest1 " + bobID + " rw",
+		"delete guest1 rule-1",
+		"update guest1 r1 r 2030-01-01T00:00:00+00:00",
+	}
+	if got := strings.Join(acl.calls, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("calls:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if len(mgr.deleted) != 0 {
+		t.Errorf("reused collection deleted: %v", mgr.deleted)
+	}
+}
+
+// TestSelectUserCredential checks credential selection for new guest
+// collections.
+func TestSelectUserCredential(t *testing.T) {
+	mgr := &fakeShareManager{credentials: []gcs.UserCredential{{ID: "c1", Username: "alice"}, {ID: "c2", Username: "lab"}}}
+	if _, err := selectUserCredential(context.Background(), mgr, "sg1", &shareOptions{}); err == nil || !strings.Contains(err.Error(), "alice, lab") {
+		t.Errorf("err = %v", err)
+	}
+	if id, err := selectUserCredential(context.Background(), mgr, "sg1", &shareOptions{LocalUsername: "lab"}); err != nil || id != "c2" {
+		t.Errorf("id = %q, err = %v", id, err)
+	}
+	if _, err := selectUserCredential(context.Background(), mgr, "sg1", &shareOptions{LocalUsername: "bob"}); err == nil {
+		t.Error("expected an error for an unknown local username")
+	}
+	if id, err := selectUserCredential(context.Background(), &fakeShareManager{}, "sg1", &shareOptions{}); err != nil || id != "" {
+		t.Errorf("no credentials: id = %q, err = %v", id, err)
+	}
+}
+
+// TestResolveSharePrincipals checks --with parsing.
+func TestResolveSharePrincipals(t *testing.T) {
+	groupsByName := func(_ context.Context, name string) (string, error) {
+		if name == "Lab" {
+			return labID, nil
+		}
+		return "", fmt.Errorf("no group named %q", name)
+	}
+	got, err := resolveSharePrincipals(context.Background(), stubLookup, groupsByName,
+		[]string{"alice@example.org", "group:Lab", strings.ToUpper(bobID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].ID != aliceID || got[1].Type != "group" || got[1].ID != labID || got[2].ID != bobID {
+		t.Errorf("principals = %+v", got)
+	}
+
+	for _, with := range [][]string{{"group:"}, {"group:Nope"}, {"alice@example.org", aliceID}} {
+		if _, err := resolveSharePrincipals(context.Background(), stubLookup, groupsByName, with); err == nil {
+			t.Errorf("%v: expected an error", with)
+		}
+	}
+}
+
+// TestValidateShareOptions checks flag validation.
+func TestValidateShareOptions(t *testing.T) {
+	if err := validateShareOptions(&shareOptions{Permissions: "rw", Expires: "2999-01-01"}); err != nil {
+		t.Errorf("valid options: %v", err)
+	}
+	for _, opts := range []*shareOptions{
+		{Permissions: "w"},
+		{Permissions: "r", Expires: "next week"},
+		{Permissions: "r", Expires: "2001-01-01"},
+		{Permissions: "r", UserCredentialID: "c1", LocalUsername: "alice"},
+	} {
+		if err := validateShareOptions(opts); err == nil {
+			t.Errorf("%+v: expected an error", opts)
+		}
+	}
+	if _, err := normalizeSharePath("projects"); err == nil {
+		t.Error("relative path accepted")
+	}
+	if p, _ := normalizeSharePath("/projects//results/."); p != "/projects/results/" {
+		t.Errorf("normalized = %q", p)
+	}
+}
@@ -0,0 +1,791 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/ids"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+)
+
+// storageConnector is a GCS connector the storage-gateway flags know how to
+// build policies for.
+type storageConnector struct {
+	Name       string
+	ID         string
+	PolicyType string
+}
+
+// storageConnectors are the connectors accepted by name in --connector. Any
+// other connector is given by ID, with its policies in --document.
+var storageConnectors = []storageConnector{
+	{Name: "posix", ID: "145812c8-decc-41f1-83cf-bb2a85a2a70b", PolicyType: "posix_storage_policies"},
+	{Name: "s3", ID: "7643e831-5f6c-4b47-a07f-8ee90f401d23", PolicyType: "s3_storage_policies"},
+	{Name: "ceph", ID: "1b6374b0-f6a4-4cf7-a26f-f262d9c6ca72", PolicyType: "ceph_storage_policies"},
+	{Name: "google-cloud-storage", ID: "56366b96-ac98-11e9-abac-9cb6d0d9fd63", PolicyType: "google_cloud_storage_policies"},
+}
+
+// storageGatewayVersions are the storage_gateway document versions implied by
+// fields added after 1.0.0; the CLI sets DATA_TYPE to the highest one needed.
+var storageGatewayVersions = map[string]string{
+	"require_mfa":    "1.1.0",
+	"restrict_paths": "1.1.0",
+}
+
+// connectorByName looks up a connector by name or ID.
+func connectorByName(v string) (storageConnector, bool) {
+	for _, c := range storageConnectors {
+		if strings.EqualFold(c.Name, v) || strings.EqualFold(c.ID, v) {
+			return c, true
+		}
+	}
+	return storageConnector{}, false
+}
+
+// connectorNames lists the connector names for help and error text.
+func connectorNames() string {
+	names := make([]string, len(storageConnectors))
+	for i, c := range storageConnectors {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// storageGatewayFlags holds the options shared by storage-gateway create and
+// update.
+type storageGatewayFlags struct {
+	Document         string
+	Connector        string
+	DisplayName      string
+	Domains          []string
+	IdentityMappings []string
+	RestrictPaths    string
+	HighAssurance    bool
+	RequireMFA       bool
+	AuthTimeoutMins  int
+	UsersAllow       []string
+	UsersDeny        []string
+	GroupsAllow      []string
+	GroupsDeny       []string
+	S3Endpoint       string
+	S3Buckets        []string
+	S3CredRequired   bool
+	DryRun           bool
+}
+
+// addStorageGatewayFlags registers the shared storage-gateway options on cmd.
+func addStorageGatewayFlags(cmd *cobra.Command, f *storageGatewayFlags) {
+	cmd.Flags().StringVar(&f.Document, "document", "", "Read the storage gateway document from a JSON file ('-' for stdin); other flags are applied on top")
+	cmd.Flags().StringVar(&f.DisplayName, "display-name", "", "Name of the storage gateway")
+	cmd.Flags().StringArrayVar(&f.Domains, "domain", nil, "Identity domain allowed to access the storage gateway; may be given multiple times")
+	cmd.Flags().StringArrayVar(&f.IdentityMappings, "identity-mapping", nil, "Identity mapping as JSON, file:PATH or external:COMMAND; may be given multiple times")
+	cmd.Flags().StringVar(&f.RestrictPaths, "restrict-paths", "", "Path restrictions as JSON or file:PATH (read, read_write and none lists)")
+	cmd.Flags().BoolVar(&f.HighAssurance, "high-assurance", false, "Require high-assurance authentication for access")
+	cmd.Flags().BoolVar(&f.RequireMFA, "require-mfa", false, "Require multi-factor authentication (high-assurance gateways only)")
+	cmd.Flags().IntVar(&f.AuthTimeoutMins, "authentication-timeout-mins", 0, "Minutes a high-assurance login remains valid")
+	cmd.Flags().StringArrayVar(&f.UsersAllow, "user-allow", nil, "Local username allowed to access the storage gateway; may be given multiple times")
+	cmd.Flags().StringArrayVar(&f.UsersDeny, "user-deny", nil, "Local username denied access to the storage gateway; may be given multiple times")
+	cmd.Flags().StringArrayVar(&f.GroupsAllow, "posix-group-allow", nil, "POSIX: local group allowed access; may be given multiple times")
+	cmd.Flags().StringArrayVar(&f.GroupsDeny, "posix-group-deny", nil, "POSIX: local group denied access; may be given multiple times")
+	cmd.Flags().StringVar(&f.S3Endpoint, "s3-endpoint", "", "S3/Ceph: URL of the S3 API endpoint (e.g. https://s3.us-east-1.amazonaws.com)")
+	cmd.Flags().StringArrayVar(&f.S3Buckets, "s3-bucket", nil, "S3/Ceph: restrict access to this bucket; may be given multiple times")
+	cmd.Flags().BoolVar(&f.S3CredRequired, "s3-user-credential-required", false, "S3: require users to register S3 access keys")
+	cmd.Flags().BoolVar(&f.DryRun, "dry-run", false, "Validate and print the document without submitting it")
+}
+
+// storageGatewayCreateCmd returns the storage-gateway create command.
+func storageGatewayCreateCmd() *cobra.Command {
+	var f storageGatewayFlags
+
+	cmd := &cobra.Command{
+		Use:   "create ENDPOINT_ID",
+		Short: "Create a storage gateway",
+		Long: `Create a storage gateway on the given endpoint's GCS Manager.
+
+--connector names the connector (` + connectorNames() + `)
+or gives any connector's ID. POSIX gateways take --posix-group-allow and
+--posix-group-deny; S3 and Ceph gateways need --s3-endpoint and take
+--s3-bucket. For other connectors, or settings without a flag, write the
+document (including its policies) in a JSON file and pass --document; flags
+given alongside it override its fields.
+
+--identity-mapping takes an expression mapping document (JSON or file:PATH)
+or external:COMMAND for a mapping program. --restrict-paths takes a
+path_restrictions document with read, read_write and none path lists.
+
+The document is validated before it is sent; --dry-run prints it instead.
+
+Examples:
+  globus gcs storage-gateway create ENDPOINT_ID --connector posix \
+      --display-name "Lab POSIX" --domain example.org \
+      --restrict-paths '{"read_write": ["/data/lab"], "none": ["/data/lab/.ssh"]}'
+  globus gcs storage-gateway create ENDPOINT_ID --connector s3 \
+      --display-name "Lab S3" --domain example.org \
+      --s3-endpoint https://s3.us-east-1.amazonaws.com --s3-bucket lab-data
+  globus gcs storage-gateway create ENDPOINT_ID --document irods-gateway.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createStorageGateway(cmd, args[0], &f)
+		},
+	}
+
+	cmd.Flags().StringVar(&f.Connector, "connector", "", "Connector name ("+connectorNames()+") or ID")
+	addStorageGatewayFlags(cmd, &f)
+
+	return cmd
+}
+
+// storageGatewayUpdateCmd returns the storage-gateway update command.
+func storageGatewayUpdateCmd() *cobra.Command {
+	var f storageGatewayFlags
+
+	cmd := &cobra.Command{
+		Use:   "update ENDPOINT_ID STORAGE_GATEWAY_ID",
+		Short: "Update a storage gateway",
+		Long: `Update a storage gateway on the given endpoint's GCS Manager.
+
+Only the fields you supply are changed. Connector options (--posix-group-*,
+--s3-*) are merged into the gateway's current policies, so policy settings
+you do not name are kept. --document supplies a partial document; flags given
+alongside it override its fields.
+
+Examples:
+  globus gcs storage-gateway update ENDPOINT_ID GATEWAY_ID --domain example.org --domain partner.edu
+  globus gcs storage-gateway update ENDPOINT_ID GATEWAY_ID --s3-bucket lab-data --s3-bucket lab-archive`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateStorageGateway(cmd, args[0], args[1], &f)
+		},
+	}
+
+	addStorageGatewayFlags(cmd, &f)
+
+	return cmd
+}
+
+// storageGatewayDeleteCmd returns the storage-gateway delete command.
+func storageGatewayDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ENDPOINT_ID STORAGE_GATEWAY_ID",
+		Short: "Delete a storage gateway",
+		Long: `Delete a storage gateway from the given endpoint's GCS Manager.
+
+The GCS Manager refuses to delete a storage gateway that still has collections.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteStorageGateway(cmd, args[0], args[1])
+		},
+	}
+}
+
+// createStorageGateway builds, validates and submits a new storage gateway.
+func createStorageGateway(cmd *cobra.Command, endpointID string, f *storageGatewayFlags) error {
+	doc, err := f.baseDocument(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("connector") {
+		c, ok := connectorByName(f.Connector)
+		switch {
+		case ok:
+			doc["connector_id"] = c.ID
+			// The named connectors' policies start out empty, so a POSIX
+			// gateway needs no policy options at all.
+			if _, has := doc["policies"]; !has {
+				doc["policies"] = map[string]interface{}{"DATA_TYPE": c.PolicyType + "#1.0.0"}
+			}
+		case ids.IsUUID(f.Connector):
+			doc["connector_id"] = strings.ToLower(f.Connector)
+		default:
+			return fmt.Errorf("unknown connector %q (use %s, or a connector ID)", f.Connector, connectorNames())
+		}
+	}
+	if err := f.apply(cmd, doc, nil); err != nil {
+		return err
+	}
+	setStorageGatewayVersion(doc)
+	if err := validateStorageGatewayDoc(doc, true); err != nil {
+		return err
+	}
+	if f.DryRun {
+		return printDryRun(cmd, doc)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client, err := getManagerAPI(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	var resp managerResponse
+	if err := client.DoRequest(ctx, "POST", "/storage_gateways", nil, doc, &resp); err != nil {
+		return fmt.Errorf("failed to create storage gateway: %w", err)
+	}
+
+	gw := resp.first()
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		return formatter.FormatOutput(gw, nil)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Created storage gateway %s (%s)\n", docString(gw, "id"), docString(gw, "display_name"))
+	return nil
+}
+
+// updateStorageGateway builds, validates and submits a storage gateway patch.
+func updateStorageGateway(cmd *cobra.Command, endpointID, storageGatewayID string, f *storageGatewayFlags) error {
+	doc, err := f.baseDocument(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// Connector options are merged into the current policies, which also tell
+	// us the connector (and so the policy DATA_TYPE).
+	var (
+		client  *core.Client
+		current map[string]interface{}
+	)
+	if f.policyFlagsChanged(cmd) {
+		if client, err = getManagerAPI(ctx, endpointID); err != nil {
+			return err
+		}
+		if current, err = fetchStorageGateway(ctx, client, storageGatewayID, true); err != nil {
+			return err
+		}
+	}
+	if err := f.apply(cmd, doc, current); err != nil {
+		return err
+	}
+	if len(doc) == 0 {
+		return fmt.Errorf("nothing to update; give --document or at least one field flag")
+	}
+	setStorageGatewayVersion(doc)
+	if err := validateStorageGatewayDoc(doc, false); err != nil {
+		return err
+	}
+	if f.DryRun {
+		return printDryRun(cmd, doc)
+	}
+
+	if client == nil {
+		if client, err = getManagerAPI(ctx, endpointID); err != nil {
+			return err
+		}
+	}
+	var resp managerResponse
+	if err := client.DoRequest(ctx, "PATCH", "/storage_gateways/"+url.PathEscape(storageGatewayID), nil, doc, &resp); err != nil {
+		return fmt.Errorf("failed to update storage gateway: %w", err)
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		return formatter.FormatOutput(resp.first(), nil)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Updated storage gateway %s\n", storageGatewayID)
+	return nil
+}
+
+// deleteStorageGateway deletes a storage gateway.
+func deleteStorageGateway(cmd *cobra.Command, endpointID, storageGatewayID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client, err := getManagerClient(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteStorageGateway(ctx, storageGatewayID); err != nil {
+		return fmt.Errorf("failed to delete storage gateway: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted storage gateway %s\n", storageGatewayID)
+	return nil
+}
+
+// fetchStorageGateway gets a storage gateway document, including its private
+// policies when privatePolicies is set.
+func fetchStorageGateway(ctx context.Context, client *core.Client, storageGatewayID string, privatePolicies bool) (map[string]interface{}, error) {
+	var query url.Values
+	if privatePolicies {
+		query = url.Values{"include": {"private_policies"}}
+	}
+	var resp managerResponse
+	if err := client.DoRequest(ctx, "GET", "/storage_gateways/"+url.PathEscape(storageGatewayID), query, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get storage gateway: %w", err)
+	}
+	gw := resp.first()
+	if gw == nil {
+		return nil, fmt.Errorf("storage gateway %s: empty response", storageGatewayID)
+	}
+	return gw, nil
+}
+
+// baseDocument returns the --document contents, or an empty document.
+func (f *storageGatewayFlags) baseDocument(stdin io.Reader) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	if f.Document == "" {
+		return doc, nil
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if f.Document == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(f.Document)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --document: %w", err)
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("--document is not a JSON object: %w", err)
+	}
+	return doc, nil
+}
+
+// policyFlagsChanged reports whether any connector policy flag was given.
+func (f *storageGatewayFlags) policyFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"posix-group-allow", "posix-group-deny", "s3-endpoint", "s3-bucket", "s3-user-credential-required"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply sets the fields for the flags the user gave on doc. current is the
+// existing gateway on update (nil on create); its policies are the base the
+// connector flags are merged into.
+func (f *storageGatewayFlags) apply(cmd *cobra.Command, doc, current map[string]interface{}) error {
+	changed := cmd.Flags().Changed
+
+	if changed("display-name") {
+		doc["display_name"] = f.DisplayName
+	}
+	if changed("domain") {
+		doc["allowed_domains"] = f.Domains
+	}
+	if changed("user-allow") {
+		doc["users_allow"] = f.UsersAllow
+	}
+	if changed("user-deny") {
+		doc["users_deny"] = f.UsersDeny
+	}
+	if changed("high-assurance") {
+		doc["high_assurance"] = f.HighAssurance
+	}
+	if changed("require-mfa") {
+		doc["require_mfa"] = f.RequireMFA
+	}
+	if changed("authentication-timeout-mins") {
+		doc["authentication_timeout_mins"] = f.AuthTimeoutMins
+	}
+	if changed("identity-mapping") {
+		mappings := make([]interface{}, 0, len(f.IdentityMappings))
+		for _, v := range f.IdentityMappings {
+			m, err := parseIdentityMapping(v)
+			if err != nil {
+				return fmt.Errorf("--identity-mapping: %w", err)
+			}
+			mappings = append(mappings, m...)
+		}
+		doc["identity_mappings"] = mappings
+	}
+	if changed("restrict-paths") {
+		v, err := readJSONValue(f.RestrictPaths)
+		if err != nil {
+			return fmt.Errorf("--restrict-paths: %w", err)
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			if _, ok := m["DATA_TYPE"]; !ok {
+				m["DATA_TYPE"] = "path_restrictions#1.0.0"
+			}
+		}
+		doc["restrict_paths"] = v
+	}
+
+	if !f.policyFlagsChanged(cmd) {
+		return nil
+	}
+
+	connectorID := docString(doc, "connector_id")
+	if connectorID == "" {
+		connectorID = docString(current, "connector_id")
+	}
+	connector, ok := connectorByName(connectorID)
+	if !ok {
+		return fmt.Errorf("connector options need a %s gateway; use --document for other connectors", connectorNames())
+	}
+
+	// Merge into the document's policies, else the gateway's current ones.
+	policies := map[string]interface{}{}
+	base, _ := doc["policies"].(map[string]interface{})
+	if base == nil && current != nil {
+		base, _ = current["policies"].(map[string]interface{})
+	}
+	for k, v := range base {
+		policies[k] = v
+	}
+	if _, ok := policies["DATA_TYPE"]; !ok {
+		policies["DATA_TYPE"] = connector.PolicyType + "#1.0.0"
+	}
+
+	posix := connector.Name == "posix"
+	s3 := connector.Name == "s3" || connector.Name == "ceph"
+	for _, opt := range []struct {
+		flag  string
+		ok    bool
+		field string
+		value interface{}
+	}{
+		{"posix-group-allow", posix, "groups_allow", f.GroupsAllow},
+		{"posix-group-deny", posix, "groups_deny", f.GroupsDeny},
+		{"s3-endpoint", s3, "s3_endpoint", f.S3Endpoint},
+		{"s3-bucket", s3, "s3_buckets", f.S3Buckets},
+		{"s3-user-credential-required", connector.Name == "s3", "s3_user_credential_required", f.S3CredRequired},
+	} {
+		if !changed(opt.flag) {
+			continue
+		}
+		if !opt.ok {
+			return fmt.Errorf("--%s does not apply to %s storage gateways", opt.flag, connector.Name)
+		}
+		policies[opt.field] = opt.value
+	}
+	doc["policies"] = policies
+	return nil
+}
+
+// parseIdentityMapping parses an --identity-mapping value into one or more
+// identity mapping documents.
+func parseIdentityMapping(v string) ([]interface{}, error) {
+	if strings.HasPrefix(v, "external:") {
+		command := strings.Fields(strings.TrimPrefix(v, "external:"))
+		if len(command) == 0 {
+			return nil, fmt.Errorf("external: needs a command")
+		}
+		args := make([]interface{}, len(command))
+		for i, c := range command {
+			args[i] = c
+		}
+		return []interface{}{map[string]interface{}{
+			"DATA_TYPE": "external_identity_mapping#1.0.0",
+			"command":   args,
+		}}, nil
+	}
+
+	parsed, err := readJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := parsed.([]interface{}); ok {
+		return list, nil
+	}
+	return []interface{}{parsed}, nil
+}
+
+// readJSONValue decodes a JSON or file:PATH flag value.
+func readJSONValue(v string) (interface{}, error) {
+	data := []byte(v)
+	if strings.HasPrefix(v, "file:") {
+		var err error
+		if data, err = os.ReadFile(strings.TrimPrefix(v, "file:")); err != nil {
+			return nil, err
+		}
+	}
+	var parsed interface{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return parsed, nil
+}
+
+// setStorageGatewayVersion sets DATA_TYPE to the lowest storage_gateway
+// version that has every field in doc, unless the document already names one.
+func setStorageGatewayVersion(doc map[string]interface{}) {
+	if _, ok := doc["DATA_TYPE"]; ok {
+		return
+	}
+	version := "1.0.0"
+	for field, v := range storageGatewayVersions {
+		if _, ok := doc[field]; ok && v > version {
+			version = v
+		}
+	}
+	doc["DATA_TYPE"] = "storage_gateway#" + version
+}
+
+// validateStorageGatewayDoc checks a storage gateway document before it is
+// submitted, reporting every problem found.
+func validateStorageGatewayDoc(doc map[string]interface{}, create bool) error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if dt, ok := doc["DATA_TYPE"].(string); ok && !strings.HasPrefix(dt, "storage_gateway#") {
+		add("DATA_TYPE %q is not a storage_gateway document", dt)
+	}
+	if _, ok := doc["id"]; ok {
+		add("id is assigned by the GCS Manager and cannot be set")
+	}
+
+	connectorID, hasConnector := doc["connector_id"]
+	switch {
+	case create && !hasConnector:
+		add("connector_id is required (use --connector)")
+	case !create && hasConnector:
+		add("connector_id cannot be changed")
+	case hasConnector:
+		if s, ok := connectorID.(string); !ok || !ids.IsUUID(s) {
+			add("connector_id must be a connector ID")
+		}
+	}
+	if name, ok := doc["display_name"]; ok {
+		if s, ok := name.(string); !ok || strings.TrimSpace(s) == "" {
+			add("display_name must be a non-empty string")
+		}
+	} else if create {
+		add("display_name is required (use --display-name)")
+	}
+
+	if domains, ok := doc["allowed_domains"]; ok {
+		list, ok := stringList(domains)
+		switch {
+		case !ok:
+			add("allowed_domains must be a list of strings")
+		case len(list) == 0:
+			add("allowed_domains must not be empty")
+		}
+		for _, d := range list {
+			if d == "" || strings.ContainsAny(d, "@ /") {
+				add("allowed_domains: %q is not a domain name", d)
+			}
+		}
+	} else if create {
+		add("allowed_domains is required (use --domain)")
+	}
+	for _, field := range []string{"users_allow", "users_deny"} {
+		if v, ok := doc[field]; ok {
+			if _, ok := stringList(v); !ok {
+				add("%s must be a list of strings", field)
+			}
+		}
+	}
+	for _, field := range []string{"high_assurance", "require_mfa"} {
+		if v, ok := doc[field]; ok {
+			if _, ok := v.(bool); !ok {
+				add("%s must be true or false", field)
+			}
+		}
+	}
+	if v, ok := doc["authentication_timeout_mins"]; ok {
+		if n, ok := jsonInt(v); !ok || n < 0 {
+			add("authentication_timeout_mins must be a non-negative integer")
+		}
+	}
+
+	if v, ok := doc["identity_mappings"]; ok {
+		list, ok := v.([]interface{})
+		if !ok {
+			add("identity_mappings must be a list of identity mapping documents")
+		}
+		for i, item := range list {
+			for _, p := range identityMappingProblems(item) {
+				add("identity_mappings[%d]: %s", i, p)
+			}
+		}
+	}
+	if v, ok := doc["restrict_paths"]; ok {
+		for _, p := range restrictPathsProblems(v) {
+			add("restrict_paths: %s", p)
+		}
+	}
+	if v, ok := doc["policies"]; ok {
+		for _, p := range policiesProblems(v, docString(doc, "connector_id")) {
+			add("policies: %s", p)
+		}
+		if c, _ := connectorByName(docString(doc, "connector_id")); create && (c.Name == "s3" || c.Name == "ceph") {
+			if m, ok := v.(map[string]interface{}); ok && docString(m, "s3_endpoint") == "" {
+				add("policies: s3_endpoint is required for %s storage gateways (use --s3-endpoint)", c.Name)
+			}
+		}
+	} else if create {
+		add("policies is required (give them in --document)")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid storage gateway document:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+// identityMappingProblems checks one expression or external identity mapping.
+func identityMappingProblems(v interface{}) []string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{"must be an object"}
+	}
+	var problems []string
+	dt := docString(m, "DATA_TYPE")
+	switch {
+	case strings.HasPrefix(dt, "expression_identity_mapping#"):
+		rules, ok := m["mappings"].([]interface{})
+		if !ok || len(rules) == 0 {
+			problems = append(problems, "mappings must be a non-empty list")
+		}
+		for i, r := range rules {
+			rule, ok := r.(map[string]interface{})
+			if !ok {
+				problems = append(problems, fmt.Sprintf("mappings[%d] must be an object", i))
+				continue
+			}
+			for _, field := range []string{"source", "match", "output"} {
+				if docString(rule, field) == "" {
+					problems = append(problems, fmt.Sprintf("mappings[%d].%s is required", i, field))
+				}
+			}
+		}
+	case strings.HasPrefix(dt, "external_identity_mapping#"):
+		if list, ok := stringList(m["command"]); !ok || len(list) == 0 {
+			problems = append(problems, "command must be a non-empty list of strings")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("DATA_TYPE %q is not expression_identity_mapping or external_identity_mapping", dt))
+	}
+	return problems
+}
+
+// restrictPathsProblems checks a path_restrictions document.
+func restrictPathsProblems(v interface{}) []string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{"must be an object"}
+	}
+	var problems []string
+	if dt := docString(m, "DATA_TYPE"); !strings.HasPrefix(dt, "path_restrictions#") {
+		problems = append(problems, fmt.Sprintf("DATA_TYPE %q is not path_restrictions", dt))
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch k {
+		case "DATA_TYPE":
+		case "read", "read_write", "none":
+			paths, ok := stringList(m[k])
+			if !ok {
+				problems = append(problems, k+" must be a list of paths")
+			}
+			for _, p := range paths {
+				if !strings.HasPrefix(p, "/") {
+					problems = append(problems, fmt.Sprintf("%s: %q is not an absolute path", k, p))
+				}
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("unknown field %q (use read, read_write, none)", k))
+		}
+	}
+	return problems
+}
+
+// policiesProblems checks a connector policies document. The connector's
+// policy type is only known for the named connectors.
+func policiesProblems(v interface{}, connectorID string) []string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{"must be an object"}
+	}
+	var problems []string
+	dt := docString(m, "DATA_TYPE")
+	if dt == "" {
+		problems = append(problems, "DATA_TYPE is required")
+	}
+	if c, ok := connectorByName(connectorID); ok && dt != "" && !strings.HasPrefix(dt, c.PolicyType+"#") {
+		problems = append(problems, fmt.Sprintf("DATA_TYPE %q does not match the %s connector (%s)", dt, c.Name, c.PolicyType))
+	}
+	if endpoint, ok := m["s3_endpoint"]; ok {
+		s, _ := endpoint.(string)
+		if u, err := url.Parse(s); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("s3_endpoint %v is not an http(s) URL", endpoint))
+		}
+	}
+	for _, field := range []string{"s3_buckets", "groups_allow", "groups_deny"} {
+		if list, ok := m[field]; ok {
+			if _, ok := stringList(list); !ok {
+				problems = append(problems, field+" must be a list of strings")
+			}
+		}
+	}
+	return problems
+}
+
+// printDryRun prints a validated document instead of submitting it.
+func printDryRun(cmd *cobra.Command, doc map[string]interface{}) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	fmt.Fprintln(cmd.ErrOrStderr(), "Document is valid; not submitted (--dry-run).")
+	return nil
+}
+
+// stringList converts a decoded JSON list (or a []string from a flag) to
+// strings, reporting whether every element was a string.
+func stringList(v interface{}) ([]string, bool) {
+	switch t := v.(type) {
+	case []string:
+		return t, true
+	case []interface{}:
+		list := make([]string, 0, len(t))
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, s)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// jsonInt converts a decoded JSON number (or an int from a flag) to an int.
+func jsonInt(v interface{}) (int, bool) {
+	switch t := v.(type) {
+	case int:
+		return t, true
+	case float64:
+		if t == float64(int(t)) {
+			return int(t), true
+		}
+	}
+	return 0, false
+}
+
+// docString returns a string field of a GCS document, or "".
+func docString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runDryRun runs storage-gateway create --dry-run and decodes the printed
+// document.
+func runDryRun(t *testing.T, args ...string) (map[string]interface{}, error) {
+	t.Helper()
+	cmd := storageGatewayCreateCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"ENDPOINT", "--dry-run"}, args...))
+	if err := cmd.Execute(); err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("dry-run output is not JSON: %v\n%s", err, out.String())
+	}
+	return doc, nil
+}
+
+// TestStorageGatewayCreatePOSIX checks the POSIX document built from flags.
+func TestStorageGatewayCreatePOSIX(t *testing.T) {
+	doc, err := runDryRun(t,
+		"--connector", "POSIX", "--display-name", "Lab POSIX", "--domain", "example.org",
+		"--posix-group-allow", "lab",
+		"--identity-mapping", `{"DATA_TYPE":"expression_identity_mapping#1.0.0","mappings":[{"source":"{username}","match":"(.*)@example\\.org","output":"{0}"}]}`,
+		"--identity-mapping", "external:/usr/local/bin/map-user -v",
+		"--restrict-paths", `{"read_write":["/data/lab"],"none":["/data/lab/.ssh"]}`,
+	)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if doc["DATA_TYPE"] != "storage_gateway#1.1.0" || doc["connector_id"] != "145812c8-decc-41f1-83cf-bb2a85a2a70b" {
+		t.Errorf("DATA_TYPE/connector_id = %v/%v", doc["DATA_TYPE"], doc["connector_id"])
+	}
+	policies := doc["policies"].(map[string]interface{})
+	if policies["DATA_TYPE"] != "posix_storage_policies#1.0.0" || len(policies["groups_allow"].([]interface{})) != 1 {
+		t.Errorf("policies = %v", policies)
+	}
+	mappings := doc["identity_mappings"].([]interface{})
+	if len(mappings) != 2 || mappings[1].(map[string]interface{})["DATA_TYPE"] != "external_identity_mapping#1.0.0" {
+		t.Errorf("identity_mappings = %v", mappings)
+	}
+	if doc["restrict_paths"].(map[string]interface{})["DATA_TYPE"] != "path_restrictions#1.0.0" {
+		t.Errorf("restrict_paths = %v", doc["restrict_paths"])
+	}
+}
+
+// TestStorageGatewayCreateS3 checks the S3 policies, that connector options
+// are refused for the wrong connector and that required policies are checked.
+func TestStorageGatewayCreateS3(t *testing.T) {
+	doc, err := runDryRun(t,
+		"--connector", "s3", "--display-name", "Lab S3", "--domain", "example.org",
+		"--s3-endpoint", "https://s3.us-east-1.amazonaws.com", "--s3-bucket", "lab-data", "--s3-bucket", "lab-archive",
+	)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	policies := doc["policies"].(map[string]interface{})
+	if policies["DATA_TYPE"] != "s3_storage_policies#1.0.0" || len(policies["s3_buckets"].([]interface{})) != 2 {
+		t.Errorf("policies = %v", policies)
+	}
+	if doc["DATA_TYPE"] != "storage_gateway#1.0.0" {
+		t.Errorf("DATA_TYPE = %v", doc["DATA_TYPE"])
+	}
+
+	_, err = runDryRun(t, "--connector", "posix", "--display-name", "x", "--domain", "example.org", "--s3-bucket", "b")
+	if err == nil || !strings.Contains(err.Error(), "does not apply to posix") {
+		t.Errorf("err = %v, want a connector mismatch", err)
+	}
+	_, err = runDryRun(t, "--connector", "s3", "--display-name", "x", "--domain", "example.org", "--s3-endpoint", "s3.example.org")
+	if err == nil || !strings.Contains(err.Error(), "s3_endpoint") {
+		t.Errorf("err = %v, want an s3_endpoint error", err)
+	}
+	_, err = runDryRun(t, "--connector", "s3", "--display-name", "x", "--domain", "example.org", "--s3-bucket", "b")
+	if err == nil || !strings.Contains(err.Error(), "s3_endpoint is required") {
+		t.Errorf("err = %v, want a missing s3_endpoint error", err)
+	}
+	doc, err = runDryRun(t, "--connector", "posix", "--display-name", "x", "--domain", "example.org")
+	if err != nil || doc["policies"].(map[string]interface{})["DATA_TYPE"] != "posix_storage_policies#1.0.0" {
+		t.Errorf("posix without policy options: doc = %v, err = %v", doc, err)
+	}
+	_, err = runDryRun(t, "--connector", "e47b6920-ff57-11ea-8aaa-000c297ab3c2", "--display-name", "x", "--domain", "example.org")
+	if err == nil || !strings.Contains(err.Error(), "policies is required") {
+		t.Errorf("err = %v, want a missing policies error", err)
+	}
+}
+
+// TestStorageGatewayCreateDocument checks that --document is used as the base
+// and that flags override it.
+func TestStorageGatewayCreateDocument(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.json")
+	body := `{"DATA_TYPE":"storage_gateway#1.0.0","connector_id":"e47b6920-ff57-11ea-8aaa-000c297ab3c2","display_name":"iRODS","allowed_domains":["example.org"],"policies":{"DATA_TYPE":"irods_storage_policies#1.0.0","irods_environment_file":"/etc/irods.json"}}`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := runDryRun(t, "--document", path, "--display-name", "Lab iRODS")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if doc["display_name"] != "Lab iRODS" || doc["policies"].(map[string]interface{})["irods_environment_file"] != "/etc/irods.json" {
+		t.Errorf("doc = %v", doc)
+	}
+}
+
+// TestValidateStorageGatewayDoc checks that every problem is reported.
+func TestValidateStorageGatewayDoc(t *testing.T) {
+	err := validateStorageGatewayDoc(map[string]interface{}{
+		"id":                          "x",
+		"connector_id":                "posix",
+		"allowed_domains":             []interface{}{"alice@example.org"},
+		"authentication_timeout_mins": -5.0,
+		"identity_mappings":           []interface{}{map[string]interface{}{"DATA_TYPE": "expression_identity_mapping#1.0.0", "mappings": []interface{}{map[string]interface{}{"source": "{username}"}}}},
+		"restrict_paths":              map[string]interface{}{"DATA_TYPE": "path_restrictions#1.0.0", "read": []interface{}{"data"}, "write": []interface{}{}},
+		"policies":                    map[string]interface{}{"groups_allow": "lab"},
+	}, true)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"id is assigned", "connector_id must be a connector ID", "display_name is required",
+		`"alice@example.org" is not a domain`, "authentication_timeout_mins",
+		"mappings[0].match is required", "mappings[0].output is required",
+		`"data" is not an absolute path`, `unknown field "write"`,
+		"policies: DATA_TYPE is required", "groups_allow must be a list",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+
+	if err := validateStorageGatewayDoc(map[string]interface{}{"connector_id": "145812c8-decc-41f1-83cf-bb2a85a2a70b"}, false); err == nil {
+		t.Error("expected an error for changing connector_id on update")
+	}
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+)
+
+// gcsUserCredentialCmd returns the user-credential subgroup.
+func gcsUserCredentialCmd() *cobra.Command {
+	ucCmd := &cobra.Command{
+		Use:   "user-credential",
+		Short: "Commands for managing user credentials",
+		Long: `List, show, create, update, and delete the user credentials on the given
+endpoint's GCS Manager. A user credential binds a Globus identity to its
+account on a storage gateway: a local username for POSIX, or an access key
+pair for S3. Guest collections on S3 gateways need one (see 'collection
+create --user-credential-id').`,
+	}
+
+	ucCmd.AddCommand(
+		userCredentialListCmd(),
+		userCredentialShowCmd(),
+		userCredentialCreateCmd(),
+		userCredentialUpdateCmd(),
+		userCredentialDeleteCmd(),
+	)
+
+	return ucCmd
+}
+
+// userCredentialFlags holds the options shared by user-credential create and
+// update.
+type userCredentialFlags struct {
+	StorageGateway string
+	IdentityID     string
+	Username       string
+	DisplayName    string
+	S3KeyID        string
+	S3SecretFile   string
+	Policies       string
+}
+
+// addUserCredentialFlags registers the shared user-credential options on cmd.
+func addUserCredentialFlags(cmd *cobra.Command, f *userCredentialFlags) {
+	cmd.Flags().StringVar(&f.DisplayName, "display-name", "", "Name of the user credential")
+	cmd.Flags().StringVar(&f.Username, "username", "", "Local username the identity maps to (POSIX)")
+	cmd.Flags().StringVar(&f.S3KeyID, "s3-key-id", "", "S3: access key ID")
+	cmd.Flags().StringVar(&f.S3SecretFile, "s3-secret-key-file", "", "S3: read the secret access key from this file ('-' for stdin)")
+	cmd.Flags().StringVar(&f.Policies, "policies", "", "Connector policies as JSON or file:PATH, for connectors without options here")
+}
+
+// userCredentialListCmd returns the user-credential list command.
+func userCredentialListCmd() *cobra.Command {
+	var storageGateway string
+
+	cmd := &cobra.Command{
+		Use:   "list ENDPOINT_ID",
+		Short: "List user credentials",
+		Long: `List the user credentials on the given endpoint's GCS Manager. Administrators
+see every user's credentials; other users see their own.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerClient(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetUserCredentialList(ctx, &gcs.UserCredentialListOptions{StorageGateway: storageGateway})
+			if err != nil {
+				return fmt.Errorf("failed to list user credentials: %w", err)
+			}
+
+			format := viper.GetString("format")
+			formatter := output.NewFormatter(format, cmd.OutOrStdout())
+
+			if formatter.Format == output.FormatJSON {
+				return formatter.FormatOutput(resp, nil)
+			}
+
+			type credentialRow struct {
+				ID             string
+				DisplayName    string
+				Username       string
+				IdentityID     string
+				StorageGateway string
+				Invalid        bool
+			}
+			rows := make([]credentialRow, 0, len(resp.Data))
+			for _, c := range resp.Data {
+				rows = append(rows, credentialRow{
+					ID: c.ID, DisplayName: c.DisplayName, Username: c.Username,
+					IdentityID: c.IdentityID, StorageGateway: c.StorageGatewayID, Invalid: c.Invalid,
+				})
+			}
+			return formatter.FormatOutput(rows, []string{"ID", "DisplayName", "Username", "IdentityID", "StorageGateway", "Invalid"})
+		},
+	}
+
+	cmd.Flags().StringVar(&storageGateway, "storage-gateway", "", "Only list credentials for this storage gateway")
+
+	return cmd
+}
+
+// userCredentialShowCmd returns the user-credential show command.
+func userCredentialShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show ENDPOINT_ID USER_CREDENTIAL_ID",
+		Short: "Show user credential details",
+		Long: `Show detailed information about a user credential on the given endpoint's
+GCS Manager. Secrets are never returned by the GCS Manager.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerClient(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cred, err := client.GetUserCredential(ctx, args[1])
+			if err != nil {
+				return fmt.Errorf("failed to get user credential: %w", err)
+			}
+
+			format := viper.GetString("format")
+			formatter := output.NewFormatter(format, cmd.OutOrStdout())
+			if formatter.Format == output.FormatJSON || formatter.Format == output.FormatUnix {
+				return formatter.FormatOutput(cred, nil)
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, "User Credential Details:")
+			fmt.Fprintf(w, "  ID:               %s\n", cred.ID)
+			fmt.Fprintf(w, "  Display Name:     %s\n", cred.DisplayName)
+			fmt.Fprintf(w, "  Username:         %s\n", cred.Username)
+			fmt.Fprintf(w, "  Identity ID:      %s\n", cred.IdentityID)
+			fmt.Fprintf(w, "  Storage Gateway:  %s\n", cred.StorageGatewayID)
+			fmt.Fprintf(w, "  Connector ID:     %s\n", cred.ConnectorID)
+			fmt.Fprintf(w, "  Invalid:          %t\n", cred.Invalid)
+			var policies map[string]interface{}
+			if json.Unmarshal(cred.Policies, &policies) == nil {
+				if keyID := docString(policies, "s3_key_id"); keyID != "" {
+					fmt.Fprintf(w, "  S3 Key ID:        %s\n", keyID)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// userCredentialCreateCmd returns the user-credential create command.
+func userCredentialCreateCmd() *cobra.Command {
+	var f userCredentialFlags
+
+	cmd := &cobra.Command{
+		Use:   "create ENDPOINT_ID",
+		Short: "Create a user credential",
+		Long: `Create a user credential on the given endpoint's GCS Manager.
+
+For a POSIX storage gateway, give the local account with --username. For an
+S3 storage gateway, give the access key with --s3-key-id and the secret with
+--s3-secret-key-file, which reads a file or, with '-', stdin, so the secret
+never appears on the command line or in shell history. --policies supplies
+the policies document for other connectors.
+
+The credential belongs to the current identity unless --identity-id names
+another (administrators only).
+
+Examples:
+  globus gcs user-credential create ENDPOINT_ID --storage-gateway GATEWAY_ID --username alice
+  pass show s3/lab | globus gcs user-credential create ENDPOINT_ID \
+      --storage-gateway GATEWAY_ID --s3-key-id AKIAEXAMPLE --s3-secret-key-file -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc := &gcs.UserCredentialDocument{
+				DataType:         "user_credential#1.0.0",
+				StorageGatewayID: f.StorageGateway,
+				IdentityID:       f.IdentityID,
+			}
+			if err := f.apply(cmd, doc); err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerClient(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cred, err := client.CreateUserCredential(ctx, doc)
+			if err != nil {
+				return fmt.Errorf("failed to create user credential: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created user credential %s\n", cred.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&f.StorageGateway, "storage-gateway", "", "Storage gateway ID the credential is for (required)")
+	cmd.Flags().StringVar(&f.IdentityID, "identity-id", "", "Identity the credential belongs to (defaults to the current user)")
+	addUserCredentialFlags(cmd, &f)
+	_ = cmd.MarkFlagRequired("storage-gateway")
+
+	return cmd
+}
+
+// userCredentialUpdateCmd returns the user-credential update command.
+func userCredentialUpdateCmd() *cobra.Command {
+	var f userCredentialFlags
+
+	cmd := &cobra.Command{
+		Use:   "update ENDPOINT_ID USER_CREDENTIAL_ID",
+		Short: "Update a user credential",
+		Long: `Update a user credential on the given endpoint's GCS Manager.
+
+Only the fields you supply are changed. To rotate S3 keys, give both
+--s3-key-id and --s3-secret-key-file.
+
+Example:
+  globus gcs user-credential update ENDPOINT_ID CREDENTIAL_ID \
+      --s3-key-id AKIANEWKEY --s3-secret-key-file ~/.secrets/s3-lab`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc := &gcs.UserCredentialDocument{DataType: "user_credential#1.0.0"}
+			if err := f.apply(cmd, doc); err != nil {
+				return err
+			}
+			if doc.Username == "" && doc.DisplayName == "" && doc.Policies == nil {
+				return fmt.Errorf("nothing to update; give at least one field flag")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerClient(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.UpdateUserCredential(ctx, args[1], doc); err != nil {
+				return fmt.Errorf("failed to update user credential: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated user credential %s\n", args[1])
+			return nil
+		},
+	}
+
+	addUserCredentialFlags(cmd, &f)
+
+	return cmd
+}
+
+// userCredentialDeleteCmd returns the user-credential delete command.
+func userCredentialDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ENDPOINT_ID USER_CREDENTIAL_ID",
+		Short: "Delete a user credential",
+		Long:  `Delete a user credential from the given endpoint's GCS Manager.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			client, err := getManagerClient(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteUserCredential(ctx, args[1]); err != nil {
+				return fmt.Errorf("failed to delete user credential: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted user credential %s\n", args[1])
+			return nil
+		},
+	}
+}
+
+// apply sets the document fields for the flags the user gave, reading the S3
+// secret from its file or stdin.
+func (f *userCredentialFlags) apply(cmd *cobra.Command, doc *gcs.UserCredentialDocument) error {
+	changed := cmd.Flags().Changed
+
+	if changed("display-name") {
+		doc.DisplayName = f.DisplayName
+	}
+	if changed("username") {
+		doc.Username = f.Username
+	}
+
+	s3 := changed("s3-key-id") || changed("s3-secret-key-file")
+	switch {
+	case s3 && changed("policies"):
+		return fmt.Errorf("--policies cannot be combined with --s3-key-id or --s3-secret-key-file")
+	case s3:
+		if f.S3KeyID == "" || f.S3SecretFile == "" {
+			return fmt.Errorf("S3 credentials need both --s3-key-id and --s3-secret-key-file")
+		}
+		secret, err := readSecret(f.S3SecretFile, cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("--s3-secret-key-file: %w", err)
+		}
+		policies, err := json.Marshal(map[string]string{
+			"DATA_TYPE":     "s3_user_credential_policies#1.0.0",
+			"s3_key_id":     f.S3KeyID,
+			"s3_secret_key": secret,
+		})
+		if err != nil {
+			return err
+		}
+		doc.Policies = policies
+	case changed("policies"):
+		v, err := readJSONValue(f.Policies)
+		if err != nil {
+			return fmt.Errorf("--policies: %w", err)
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok || docString(m, "DATA_TYPE") == "" {
+			return fmt.Errorf("--policies must be a JSON object with a DATA_TYPE")
+		}
+		doc.Policies, _ = json.Marshal(m)
+	}
+	return nil
+}
+
+// readSecret reads a secret from file, or from stdin for "-", dropping the
+// trailing newline.
+func readSecret(file string, stdin io.Reader) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("secret is empty")
+	}
+	return secret, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/gcs"
+)
+
+// applyCredentialFlags parses args as user-credential flags and applies them
+// to a fresh document.
+func applyCredentialFlags(t *testing.T, stdin string, args ...string) (*gcs.UserCredentialDocument, error) {
+	t.Helper()
+	var f userCredentialFlags
+	cmd := &cobra.Command{}
+	addUserCredentialFlags(cmd, &f)
+	cmd.SetIn(strings.NewReader(stdin))
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	doc := &gcs.UserCredentialDocument{}
+	return doc, f.apply(cmd, doc)
+}
+
+// TestUserCredentialS3FromStdin checks that the S3 secret is read from stdin
+// into the policies document.
+func TestUserCredentialS3FromStdin(t *testing.T) {
+	doc, err := applyCredentialFlags(t, "s3cr3t\n", "--s3-key-id", "AKIAEXAMPLE", "--s3-secret-key-file", "-")
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	var policies map[string]string
+	if err := json.Unmarshal(doc.Policies, &policies); err != nil {
+		t.Fatal(err)
+	}
+	if policies["DATA_TYPE"] != "s3_user_credential_policies#1.0.0" || policies["s3_key_id"] != "AKIAEXAMPLE" || policies["s3_secret_key"] != "s3cr3t" {
+		t.Errorf("policies = %v", policies)
+	}
+}
+
+// TestUserCredentialFlags checks the POSIX binding, secret files and the
+// flag combinations that are refused.
+func TestUserCredentialFlags(t *testing.T) {
+	doc, err := applyCredentialFlags(t, "", "--username", "alice", "--display-name", "Alice on lab")
+	if err != nil || doc.Username != "alice" || doc.DisplayName != "Alice on lab" || doc.Policies != nil {
+		t.Errorf("doc = %+v, err = %v", doc, err)
+	}
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	doc, err = applyCredentialFlags(t, "", "--s3-key-id", "AKIA", "--s3-secret-key-file", secretFile)
+	if err != nil || !strings.Contains(string(doc.Policies), `"s3_secret_key":"from-file"`) {
+		t.Errorf("policies = %s, err = %v", doc.Policies, err)
+	}
+
+	for _, args := range [][]string{
+		{"--s3-key-id", "AKIA"},
+		{"--s3-secret-key-file", "-"},
+		{"--s3-key-id", "AKIA", "--s3-secret-key-file", "-", "--policies", `{"DATA_TYPE":"x#1.0.0"}`},
+		{"--policies", `{"s3_key_id":"AKIA"}`},
+	} {
+		if _, err := applyCredentialFlags(t, "secret", args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+	if _, err := applyCredentialFlags(t, "\n", "--s3-key-id", "AKIA", "--s3-secret-key-file", "-"); err == nil {
+		t.Error("expected an error for an empty secret")
+	}
+}
@@ -0,0 +1,378 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	gopath "path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// davPropfindBody asks only for the properties ls and stat report.
+const davPropfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:propfind xmlns:D="DAV:"><D:prop>
+<D:resourcetype/><D:getcontentlength/><D:getlastmodified/>
+</D:prop></D:propfind>`
+
+// davEntry is one file or directory from a PROPFIND response, named relative
+// to its parent. Self marks the entry for the requested path itself.
+type davEntry struct {
+	Name         string
+	Self         bool
+	Type         string // dir or file
+	Size         int64
+	LastModified time.Time
+
+	path string // unescaped href path
+}
+
+// davMultistatus is the subset of a WebDAV 207 Multi-Status body we read.
+type davMultistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"DAV: collection"`
+				} `xml:"DAV: resourcetype"`
+				ContentLength string `xml:"DAV: getcontentlength"`
+				LastModified  string `xml:"DAV: getlastmodified"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// httpsListOptions holds the collection ls and stat flags.
+type httpsListOptions struct {
+	HTTPSURL   string
+	Long       bool
+	ShowHidden bool
+}
+
+// collectionLsCmd returns the `collection ls` command.
+func collectionLsCmd() *cobra.Command {
+	opts := &httpsListOptions{}
+	cmd := &cobra.Command{
+		Use:   "ls ENDPOINT_ID COLLECTION_ID [PATH]",
+		Short: "List a directory on an HTTPS-enabled collection",
+		Long: `List a directory of a Globus Connect Server v5 collection over its HTTPS
+(WebDAV) data-plane interface, without going through the Transfer service.
+
+Output has the same columns and JSON shape as 'globus ls'. PATH defaults to /.
+The collection's data-access consent is escalated on first use, as for
+'collection cat'.
+
+--https-url skips looking up the collection's https_url, so no Transfer or
+GCS Manager call is made at all.
+
+Examples:
+  globus collection ls ENDPOINT_ID COLLECTION_ID /projects -l
+  globus collection ls ENDPOINT_ID COLLECTION_ID --https-url https://g-1a2b.data.globus.org`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := "/"
+			if len(args) == 3 {
+				p = args[2]
+			}
+			return listCollectionHTTPS(cmd, args[0], args[1], p, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.HTTPSURL, "https-url", "", "The collection's HTTPS base URL (skips the lookup)")
+	cmd.Flags().BoolVarP(&opts.Long, "long", "l", false, "List in long format with details")
+	cmd.Flags().BoolVarP(&opts.ShowHidden, "all", "a", false, "Show hidden files")
+
+	return cmd
+}
+
+// collectionStatCmd returns the `collection stat` command.
+func collectionStatCmd() *cobra.Command {
+	opts := &httpsListOptions{}
+	cmd := &cobra.Command{
+		Use:   "stat ENDPOINT_ID COLLECTION_ID PATH",
+		Short: "Show metadata for a path on an HTTPS-enabled collection",
+		Long: `Show the name, type, size and last modified time of a file or directory on
+a Globus Connect Server v5 collection over its HTTPS (WebDAV) data-plane
+interface, in the same shape as 'globus transfer stat'.
+
+--https-url skips looking up the collection's https_url.
+
+Examples:
+  globus collection stat ENDPOINT_ID COLLECTION_ID /projects/data.csv`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return statCollectionHTTPS(cmd, args[0], args[1], args[2], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.HTTPSURL, "https-url", "", "The collection's HTTPS base URL (skips the lookup)")
+
+	return cmd
+}
+
+// collectionHTTPSBase returns the collection's HTTPS base URL and a
+// data-access token for it.
+func collectionHTTPSBase(ctx context.Context, endpointID, collectionID, httpsURL string) (string, string, error) {
+	if httpsURL == "" {
+		managerClient, err := getManagerClient(ctx, endpointID)
+		if err != nil {
+			return "", "", err
+		}
+		coll, err := managerClient.GetCollection(ctx, collectionID, nil)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to look up collection %s: %w", collectionID, err)
+		}
+		if coll.HTTPSURL == "" {
+			return "", "", fmt.Errorf("collection %s does not have HTTPS enabled (no https_url)", collectionID)
+		}
+		httpsURL = coll.HTTPSURL
+	}
+	token, err := collectionHTTPSToken(ctx, collectionID)
+	if err != nil {
+		return "", "", err
+	}
+	return httpsURL, token, nil
+}
+
+// listCollectionHTTPS lists a directory over WebDAV.
+func listCollectionHTTPS(cmd *cobra.Command, endpointID, collectionID, p string, opts *httpsListOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	base, token, err := collectionHTTPSBase(ctx, endpointID, collectionID, opts.HTTPSURL)
+	if err != nil {
+		return err
+	}
+	entries, err := davPropfind(ctx, httpsDataClient, token, base, p, "1")
+	if err != nil {
+		return err
+	}
+	if len(entries) == 1 && entries[0].Self && entries[0].Type == "file" {
+		return fmt.Errorf("%s is not a directory", p)
+	}
+	return printHTTPSListing(cmd, collectionID, p, davChildren(entries, opts.ShowHidden), opts.Long)
+}
+
+// statCollectionHTTPS stats one path over WebDAV.
+func statCollectionHTTPS(cmd *cobra.Command, endpointID, collectionID, p string, opts *httpsListOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	base, token, err := collectionHTTPSBase(ctx, endpointID, collectionID, opts.HTTPSURL)
+	if err != nil {
+		return err
+	}
+	entries, err := davPropfind(ctx, httpsDataClient, token, base, p, "0")
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("failed to stat path: no properties returned for %s", p)
+	}
+	return printHTTPSStat(cmd, entries[0])
+}
+
+// davPropfind issues a PROPFIND of the given depth for a collection path.
+func davPropfind(ctx context.Context, client *http.Client, token, base, p, depth string) ([]davEntry, error) {
+	target := httpsPathURL(base, p)
+	req, err := http.NewRequestWithContext(ctx, "PROPFIND", target, strings.NewReader(davPropfindBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Depth", depth)
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	// Without this GCS answers unauthenticated requests with a login redirect
+	// instead of a status code.
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusMultiStatus:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("no such file or directory: %s", p)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("permission denied for %s (HTTP %d)", p, resp.StatusCode)
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, fmt.Errorf("the collection's HTTPS server does not support directory listing (PROPFIND returned HTTP %d)", resp.StatusCode)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("PROPFIND %s failed: HTTP %d: %s", target, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	entries, err := parseMultistatus(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	self := strings.TrimRight(req.URL.Path, "/")
+	for i := range entries {
+		entries[i].Self = strings.TrimRight(entries[i].path, "/") == self
+	}
+	return entries, nil
+}
+
+// parseMultistatus decodes a 207 body into entries, skipping responses with
+// no successful propstat.
+func parseMultistatus(r io.Reader) ([]davEntry, error) {
+	var ms davMultistatus
+	if err := xml.NewDecoder(r).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("failed to parse PROPFIND response: %w", err)
+	}
+
+	entries := make([]davEntry, 0, len(ms.Responses))
+	for _, resp := range ms.Responses {
+		href, err := url.Parse(resp.Href)
+		if err != nil {
+			return nil, fmt.Errorf("invalid href %q in PROPFIND response: %w", resp.Href, err)
+		}
+		for _, ps := range resp.Propstats {
+			if !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			entry := davEntry{path: href.Path, Type: "file"}
+			if ps.Prop.ResourceType.Collection != nil {
+				entry.Type = "dir"
+			}
+			entry.Name = gopath.Base(strings.TrimRight(href.Path, "/"))
+			if entry.Name == "." || entry.Name == "" {
+				entry.Name = "/"
+			}
+			if ps.Prop.ContentLength != "" {
+				entry.Size, _ = strconv.ParseInt(ps.Prop.ContentLength, 10, 64)
+			}
+			if ps.Prop.LastModified != "" {
+				entry.LastModified, _ = http.ParseTime(ps.Prop.LastModified)
+			}
+			entries = append(entries, entry)
+			break
+		}
+	}
+	return entries, nil
+}
+
+// davChildren drops the listed directory itself (and hidden entries unless
+// showHidden) and sorts the rest by name.
+func davChildren(entries []davEntry, showHidden bool) []davEntry {
+	children := make([]davEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Self {
+			continue
+		}
+		if !showHidden && strings.HasPrefix(e.Name, ".") {
+			continue
+		}
+		children = append(children, e)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
+// httpsPathURL joins a collection's HTTPS base URL and a collection path,
+// escaping each path segment.
+func httpsPathURL(base, p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.Join(segments, "/")
+}
+
+// printHTTPSListing prints entries with the columns and JSON shape of
+// 'globus ls'.
+func printHTTPSListing(cmd *cobra.Command, collectionID, dir string, entries []davEntry, long bool) error {
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+
+	headers := []string{"Type", "Name"}
+	if long {
+		headers = []string{"Type", "Permissions", "User", "Group", "Size", "LastModified", "Name"}
+	}
+
+	// WebDAV carries no owner or mode, so those long-format columns stay empty.
+	type fileEntry struct {
+		Type         string
+		Permissions  string
+		User         string
+		Group        string
+		Size         int64
+		LastModified string
+		Name         string
+	}
+	rows := make([]fileEntry, 0, len(entries))
+	for _, e := range entries {
+		row := fileEntry{Type: davFileType(e.Type), Name: e.Name}
+		if long {
+			row.Size = e.Size
+			if !e.LastModified.IsZero() {
+				row.LastModified = e.LastModified.Format("Jan 02 15:04")
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := formatter.FormatOutput(rows, headers); err != nil {
+		return fmt.Errorf("error formatting output: %w", err)
+	}
+	if formatter.Format == output.FormatText && formatter.JMESPath == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nDirectory: %s:%s\n", collectionID, dir)
+		fmt.Fprintf(cmd.OutOrStdout(), "Total: %d items\n", len(entries))
+	}
+	return nil
+}
+
+// printHTTPSStat prints one entry in the shape of 'globus transfer stat'.
+func printHTTPSStat(cmd *cobra.Command, e davEntry) error {
+	doc := map[string]interface{}{
+		"DATA_TYPE": "file",
+		"name":      e.Name,
+		"type":      e.Type,
+		"size":      e.Size,
+	}
+	if !e.LastModified.IsZero() {
+		doc["last_modified"] = e.LastModified.UTC().Format("2006-01-02 15:04:05-07:00")
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON || formatter.Format == output.FormatUnix {
+		return formatter.FormatOutput(doc, nil)
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "Path Details:")
+	fmt.Fprintf(w, "  Name:          %v\n", doc["name"])
+	fmt.Fprintf(w, "  Type:          %v\n", doc["type"])
+	fmt.Fprintf(w, "  Size:          %v\n", doc["size"])
+	if v, ok := doc["last_modified"]; ok {
+		fmt.Fprintf(w, "  Last Modified: %v\n", v)
+	}
+	return nil
+}
+
+// davFileType is the one-letter type column of 'globus ls'.
+func davFileType(t string) string {
+	switch t {
+	case "dir":
+		return "d"
+	case "file":
+		return "f"
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const testMultistatus = `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:">
+ <d:response><d:href>/projects/my%20data/</d:href>
+  <d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype>
+   <d:getlastmodified>Mon, 02 Mar 2026 10:00:00 GMT</d:getlastmodified></d:prop>
+  <d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+ <d:response><d:href>/projects/my%20data/results.csv</d:href>
+  <d:propstat><d:prop><d:resourcetype/><d:getcontentlength>2048</d:getcontentlength>
+   <d:getlastmodified>Tue, 03 Mar 2026 11:30:00 GMT</d:getlastmodified></d:prop>
+  <d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+ <d:response><d:href>/projects/my%20data/.hidden</d:href>
+  <d:propstat><d:prop><d:resourcetype/><d:getcontentlength>1</d:getcontentlength></d:prop>
+  <d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+ <d:response><d:href>/projects/my%20data/raw/</d:href>
+  <d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop>
+  <d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+  <d:propstat><d:prop><d:getcontentlength/></d:prop>
+  <d:status>HTTP/1.1 404 Not Found</d:status></d:propstat></d:response>
+</d:multistatus>`
+
+// newDAVServer serves testMultistatus for PROPFIND /projects/my data/ and 404
+// otherwise, recording the request headers.
+func newDAVServer(t *testing.T, got *http.Header) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = r.Header.Clone()
+		if r.Method != "PROPFIND" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if strings.TrimRight(r.URL.Path, "/") != "/projects/my data" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(testMultistatus))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestDAVPropfind checks the request and the decoded entries.
+func TestDAVPropfind(t *testing.T) {
+	var header http.Header
+	srv := newDAVServer(t, &header)
+
+	entries, err := davPropfind(context.Background(), srv.Client(), "tok", srv.URL, "/projects/my data", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("Authorization") != "Bearer tok" || header.Get("Depth") != "1" {
+		t.Errorf("headers = %v", header)
+	}
+	if len(entries) != 4 || !entries[0].Self || entries[1].Self {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if e := entries[1]; e.Name != "results.csv" || e.Type != "file" || e.Size != 2048 || e.LastModified.Day() != 3 {
+		t.Errorf("file entry = %+v", e)
+	}
+	if e := entries[3]; e.Name != "raw" || e.Type != "dir" {
+		t.Errorf("dir entry = %+v", e)
+	}
+
+	children := davChildren(entries, false)
+	if len(children) != 2 || children[0].Name != "raw" || children[1].Name != "results.csv" {
+		t.Errorf("children = %+v", children)
+	}
+	if len(davChildren(entries, true)) != 3 {
+		t.Error("--all should keep hidden entries")
+	}
+
+	if _, err := davPropfind(context.Background(), srv.Client(), "tok", srv.URL, "/missing", "0"); err == nil || !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("missing path err = %v", err)
+	}
+}
+
+// TestPrintHTTPSListing checks that ls output matches the transfer ls shape.
+func TestPrintHTTPSListing(t *testing.T) {
+	var header http.Header
+	srv := newDAVServer(t, &header)
+	entries, err := davPropfind(context.Background(), srv.Client(), "tok", srv.URL, "/projects/my data/", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origFormat := viper.GetString("format")
+	viper.Set("format", "json")
+	defer viper.Set("format", origFormat)
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	if err := printHTTPSListing(cmd, "coll", "/projects/my data/", davChildren(entries, false), true); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("json: %v\n%s", err, out.String())
+	}
+	if len(rows) != 2 || rows[1]["Type"] != "f" || rows[1]["Name"] != "results.csv" || rows[1]["Size"] != float64(2048) ||
+		rows[1]["LastModified"] != "Mar 03 11:30" {
+		t.Errorf("rows = %v", rows)
+	}
+
+	out.Reset()
+	if err := printHTTPSStat(cmd, entries[1]); err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["name"] != "results.csv" || doc["type"] != "file" || doc["last_modified"] != "2026-03-03 11:30:00+00:00" {
+		t.Errorf("stat = %v", doc)
+	}
+}
+
+// TestHTTPSPathURL checks path escaping.
+func TestHTTPSPathURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"/":                "https://g.example.org/",
+		"/a b/c#d":         "https://g.example.org/a%20b/c%23d",
+		"projects/x.csv":   "https://g.example.org/projects/x.csv",
+		"/projects/dir/":   "https://g.example.org/projects/dir",
+		"/projects/100%/x": "https://g.example.org/projects/100%25/x",
+	} {
+		if got := httpsPathURL("https://g.example.org/", in); got != want {
+			t.Errorf("httpsPathURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -44,6 +44,9 @@ Examples:
 	flowsCmd.AddCommand(flows.DeleteCmd)
 	flowsCmd.AddCommand(flows.StartCmd)
 	flowsCmd.AddCommand(flows.ValidateCmd)
+	flowsCmd.AddCommand(flows.RenderCmd)
+	flowsCmd.AddCommand(flows.SimulateCmd)
+	flowsCmd.AddCommand(flows.DeployCmd)
 	flowsCmd.AddCommand(flows.GetRunCmd())
 
 	return flowsCmd
@@ -10,6 +10,7 @@ import (
 
 	"github.com/scttfrdmn/globus-go-cli/pkg/config"
 	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
 	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/flows"
 )
 
@@ -36,3 +37,27 @@ func getClient(ctx context.Context) (*flows.Client, error) {
 	}
 	return client, nil
 }
+
+// getRawClient builds a raw Flows API client for the flow fields the SDK's
+// typed Flow does not carry (roles, keywords and the subscription). Paths are
+// relative to the Flows host.
+func getRawClient(ctx context.Context) (*core.Client, error) {
+	profile := viper.GetString("profile")
+
+	clientCfg, err := config.LoadClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client configuration: %w", err)
+	}
+
+	cfg, err := globusauth.ClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, globusauth.ServiceFlows)
+	if err != nil {
+		return nil, fmt.Errorf("not logged in: %w", err)
+	}
+	cfg.BaseURL = "https://flows.globus.org"
+
+	client, err := core.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flows client: %w", err)
+	}
+	return client, nil
+}
@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// flowCompiler turns a tree of JSON and YAML files into one JSON document.
+// Two include forms are resolved, both relative to the including file and
+// both optionally followed by a #/json/pointer selecting part of the target:
+//
+//   - a YAML scalar tagged !include, replaced by the file's content;
+//   - an object whose "$ref" names a file, replaced by the file's content
+//     with the object's other members laid over it.
+//
+// "$ref" values starting with "#" or naming a URL are left alone, so JSON
+// Schema references inside an input schema keep working.
+type flowCompiler struct {
+	// stack is the chain of files being loaded, for cycle detection.
+	stack []string
+}
+
+// load compiles the file at path. The line map covers path itself; members
+// that came from included files are left out of it.
+func (c *flowCompiler) load(path string) (interface{}, map[string]int, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, seen := range c.stack {
+		if seen == abs {
+			cycle := append(append([]string(nil), c.stack[i:]...), abs)
+			return nil, nil, fmt.Errorf("include cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	c.stack = append(c.stack, abs)
+	defer func() { c.stack = c.stack[:len(c.stack)-1] }()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := filepath.Dir(path)
+
+	var v interface{}
+	var lines map[string]int
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		lines = make(map[string]int)
+		if v, err = c.fromNode(&root, path, "", lines); err != nil {
+			return nil, nil, err
+		}
+	default:
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		// The document already parsed, so a failure here only costs line numbers.
+		lines, _ = jsonPointerLines(data)
+	}
+
+	v, err = c.resolveRefs(v, dir, path)
+	return v, lines, err
+}
+
+// fromNode converts a YAML node to the value encoding/json would produce,
+// resolving !include tags and recording member lines in lines (when non-nil).
+func (c *flowCompiler) fromNode(node *yaml.Node, path, pointer string, lines map[string]int) (interface{}, error) {
+	if lines != nil {
+		if _, ok := lines[pointer]; !ok {
+			lines[pointer] = node.Line
+		}
+	}
+	if node.Tag == "!include" {
+		if node.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s:%d: !include takes a file name", path, node.Line)
+		}
+		v, err := c.include(node.Value, filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, node.Line, err)
+		}
+		return v, nil
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.fromNode(node.Content[0], path, pointer, lines)
+	case yaml.AliasNode:
+		return c.fromNode(node.Alias, path, pointer, nil)
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+		for i, item := range node.Content {
+			v, err := c.fromNode(item, path, pointer+"/"+strconv.Itoa(i), lines)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(node.Content)/2)
+		var merged []map[string]interface{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				m, err := c.mergeValues(value, path)
+				if err != nil {
+					return nil, err
+				}
+				merged = append(merged, m...)
+				continue
+			}
+			child := pointer + "/" + escapePointer(key.Value)
+			if lines != nil {
+				lines[child] = key.Line
+			}
+			v, err := c.fromNode(value, path, child, lines)
+			if err != nil {
+				return nil, err
+			}
+			obj[key.Value] = v
+		}
+		// Explicit members win over merged ones, and earlier merges over later.
+		for _, m := range merged {
+			for k, v := range m {
+				if _, ok := obj[k]; !ok {
+					obj[k] = v
+				}
+			}
+		}
+		return obj, nil
+	}
+	return scalarValue(node, path)
+}
+
+// mergeValues returns the mappings a YAML merge key ("<<") pulls in.
+func (c *flowCompiler) mergeValues(node *yaml.Node, path string) ([]map[string]interface{}, error) {
+	items := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		items = node.Content
+	}
+	var maps []map[string]interface{}
+	for _, item := range items {
+		v, err := c.fromNode(item, path, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: << must merge a mapping", path, item.Line)
+		}
+		maps = append(maps, m)
+	}
+	return maps, nil
+}
+
+// scalarValue decodes a YAML scalar. Numbers become float64, as they would
+// from JSON; timestamps and other plain scalars stay strings.
+func scalarValue(node *yaml.Node, path string) (interface{}, error) {
+	var err error
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err = node.Decode(&b); err == nil {
+			return b, nil
+		}
+	case "!!int", "!!float":
+		var f float64
+		if err = node.Decode(&f); err == nil {
+			return f, nil
+		}
+	case "!!str", "!!timestamp", "!!binary":
+		return node.Value, nil
+	default:
+		return nil, fmt.Errorf("%s:%d: unsupported tag %s", path, node.Line, node.Tag)
+	}
+	return nil, fmt.Errorf("%s:%d: %w", path, node.Line, err)
+}
+
+// resolveRefs replaces objects whose "$ref" names a file with that file's
+// content, laying the object's other members over it.
+func (c *flowCompiler) resolveRefs(v interface{}, dir, path string) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			resolved, err := c.resolveRefs(item, dir, path)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = resolved
+		}
+		ref, ok := v["$ref"].(string)
+		if !ok || !isFileRef(ref) {
+			return v, nil
+		}
+		target, err := c.include(ref, dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: $ref %q: %w", path, ref, err)
+		}
+		if len(v) == 1 {
+			return target, nil
+		}
+		base, ok := target.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: $ref %q has sibling members but does not name an object", path, ref)
+		}
+		obj := make(map[string]interface{}, len(base)+len(v))
+		for k, item := range base {
+			obj[k] = item
+		}
+		for k, item := range v {
+			if k != "$ref" {
+				obj[k] = item
+			}
+		}
+		return obj, nil
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := c.resolveRefs(item, dir, path)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	}
+	return v, nil
+}
+
+// isFileRef reports whether a "$ref" value names a file rather than a
+// location in the same document or a URL.
+func isFileRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#") && !strings.Contains(ref, "://")
+}
+
+// include loads FILE or FILE#/pointer relative to dir.
+func (c *flowCompiler) include(ref, dir string) (interface{}, error) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if file == "" {
+		return nil, fmt.Errorf("include %q names no file", ref)
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	v, _, err := c.load(file)
+	if err != nil {
+		return nil, err
+	}
+	return resolvePointer(v, fragment)
+}
+
+// resolvePointer returns the value a JSON pointer selects in v.
+func resolvePointer(v interface{}, pointer string) (interface{}, error) {
+	if pointer == "" {
+		return v, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("JSON pointer %q: no member %q", pointer, token)
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("JSON pointer %q: no element %q", pointer, token)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("JSON pointer %q: cannot select %q from a %s", pointer, token, jsonType(v))
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes name -> content under a temp dir and returns the dir.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// TestLoadFlowDocumentYAML checks YAML compilation with both include forms.
+func TestLoadFlowDocumentYAML(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"flow.yaml": `# comment
+StartAt: Transfer
+States:
+  Transfer:
+    $ref: states/transfer.yaml
+    Next: Notify
+  Notify: !include shared.json#/States/Notify
+  Done: &done
+    Type: Pass
+    End: true
+  Also:
+    <<: *done
+    Comment: merged
+`,
+		"states/transfer.yaml": `Type: Action
+ActionUrl: https://transfer.actions.globus.org/transfer
+Parameters:
+  source_endpoint.$: $.source.id
+  verify_checksum: yes
+  deadline: 2026-01-01
+  retries: 3
+  schema: {$ref: "#/definitions/x"}
+`,
+		"shared.json": `{"States": {"Notify": {"Type": "Pass", "Next": "Done"}}}`,
+	})
+
+	doc, err := loadFlowDocument(filepath.Join(dir, "flow.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+  "StartAt": "Transfer",
+  "States": {
+    "Transfer": {
+      "Type": "Action",
+      "ActionUrl": "https://transfer.actions.globus.org/transfer",
+      "Parameters": {"source_endpoint.$": "$.source.id", "verify_checksum": "yes", "deadline": "2026-01-01", "retries": 3, "schema": {"$ref": "#/definitions/x"}},
+      "Next": "Notify"
+    },
+    "Notify": {"Type": "Pass", "Next": "Done"},
+    "Done": {"Type": "Pass", "End": true},
+    "Also": {"Type": "Pass", "End": true, "Comment": "merged"}
+  }
+}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(doc.Data, want) {
+		got, _ := json.MarshalIndent(doc.Data, "", "  ")
+		t.Errorf("compiled:\n%s", got)
+	}
+
+	if line := doc.lineOf("/States/Transfer/Next"); line != 6 {
+		t.Errorf("line of Next = %d, want 6", line)
+	}
+	// Members from an included file fall back to the include site.
+	if line := doc.lineOf("/States/Notify/Type"); line != 7 {
+		t.Errorf("line of included Type = %d, want 7", line)
+	}
+}
+
+// TestLoadFlowDocumentJSONRef checks "$ref" resolution in a JSON schema.
+func TestLoadFlowDocumentJSONRef(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schema.json":          `{"type": "object", "properties": {"source": {"$ref": "common/endpoint.yaml"}}}`,
+		"common/endpoint.yaml": "type: object\nrequired: [id]\n",
+	})
+
+	doc, err := loadFlowDocument(filepath.Join(dir, "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := doc.Data["properties"].(map[string]interface{})["source"]
+	if !reflect.DeepEqual(source, map[string]interface{}{"type": "object", "required": []interface{}{"id"}}) {
+		t.Errorf("source = %#v", source)
+	}
+}
+
+// TestLoadFlowDocumentErrors checks include cycles and bad includes.
+func TestLoadFlowDocumentErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":       "x: !include b.yaml\n",
+		"b.yaml":       "y: {$ref: a.yaml}\n",
+		"pointer.yaml": "x: !include list.yaml#/nope\n",
+		"list.yaml":    "- 1\n",
+		"tag.yaml":     "x: !custom 1\n",
+	})
+
+	for name, want := range map[string]string{
+		"a.yaml":       "include cycle",
+		"pointer.yaml": `no element "nope"`,
+		"list.yaml":    "expected an object",
+		"tag.yaml":     "unsupported tag !custom",
+		"missing.yaml": "no such file",
+	} {
+		_, err := loadFlowDocument(filepath.Join(dir, name))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", name, err, want)
+		}
+	}
+}
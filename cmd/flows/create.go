@@ -4,7 +4,6 @@ package flows
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -46,6 +45,11 @@ A flow definition is a JSON document that describes the workflow steps,
 actions, and logic. The input schema defines the required and optional
 parameters for running the flow.
 
+Both files may be written in YAML (.yaml or .yml) and may pull in shared
+fragments with !include FILE or {"$ref": "FILE"}, optionally followed by
+#/json/pointer; they are compiled to JSON before upload. Use 'globus flows
+render' to see the compiled result.
+
 Examples:
   # Create a flow from a definition file
   globus flows create --title "My Flow" --definition-file flow.json
@@ -67,8 +71,8 @@ func init() {
 	CreateCmd.Flags().StringVar(&createTitle, "title", "", "Flow title (required)")
 	CreateCmd.Flags().StringVar(&createDescription, "description", "", "Flow description")
 	CreateCmd.Flags().StringVar(&createSubtitle, "subtitle", "", "A concise summary of the flow's purpose")
-	CreateCmd.Flags().StringVar(&createDefinitionFile, "definition-file", "", "Path to flow definition JSON file (required)")
-	CreateCmd.Flags().StringVar(&createSchemaFile, "schema-file", "", "Path to input schema JSON file")
+	CreateCmd.Flags().StringVar(&createDefinitionFile, "definition-file", "", "Path to flow definition JSON or YAML file (required)")
+	CreateCmd.Flags().StringVar(&createSchemaFile, "schema-file", "", "Path to input schema JSON or YAML file")
 	CreateCmd.Flags().StringSliceVar(&createKeywords, "keywords", []string{}, "Comma-separated keywords")
 	CreateCmd.Flags().BoolVar(&createPublic, "public", false, "Make flow publicly visible")
 
@@ -91,28 +95,21 @@ func init() {
 }
 
 func runFlowsCreate(cmd *cobra.Command, args []string) error {
-	// Read definition file
-	definitionData, err := os.ReadFile(createDefinitionFile)
+	// Read definition file (JSON or YAML, with includes compiled in)
+	definitionDoc, err := loadFlowDocument(createDefinitionFile)
 	if err != nil {
 		return fmt.Errorf("failed to read definition file: %w", err)
 	}
-
-	var definition map[string]interface{}
-	if err := json.Unmarshal(definitionData, &definition); err != nil {
-		return fmt.Errorf("failed to parse definition JSON: %w", err)
-	}
+	definition := definitionDoc.Data
 
 	// Read schema file if provided
 	var inputSchema map[string]interface{}
 	if createSchemaFile != "" {
-		schemaData, err := os.ReadFile(createSchemaFile)
+		schemaDoc, err := loadFlowDocument(createSchemaFile)
 		if err != nil {
 			return fmt.Errorf("failed to read schema file: %w", err)
 		}
-
-		if err := json.Unmarshal(schemaData, &inputSchema); err != nil {
-			return fmt.Errorf("failed to parse schema JSON: %w", err)
-		}
+		inputSchema = schemaDoc.Data
 	}
 
 	// Create context with timeout
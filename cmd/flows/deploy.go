@@ -0,0 +1,615 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+)
+
+var (
+	deployFile     string
+	deployLockFile string
+	deployFlowID   string
+	deployDryRun   bool
+)
+
+// flowsAPI is the raw Flows call the deploy command makes.
+type flowsAPI interface {
+	DoRequest(ctx context.Context, method, endpoint string, query url.Values, body interface{}, result interface{}) error
+}
+
+// deployKeys maps the keys of a deploy file to the Flows API fields they set,
+// in the order changes are listed.
+var deployKeys = []struct{ key, field string }{
+	{"title", "title"},
+	{"subtitle", "subtitle"},
+	{"description", "description"},
+	{"keywords", "keywords"},
+	{"definition", "definition"},
+	{"input_schema", "input_schema"},
+	{"subscription_id", "subscription_id"},
+	{"administrators", "flow_administrators"},
+	{"starters", "flow_starters"},
+	{"viewers", "flow_viewers"},
+	{"run_managers", "run_managers"},
+	{"run_monitors", "run_monitors"},
+}
+
+// flowDeployment is a deploy file: the flow fields it declares, keyed by API
+// field. Fields left out are not managed.
+type flowDeployment struct {
+	Title      string
+	Fields     map[string]interface{}
+	Definition *flowDocument
+	Schema     *flowDocument
+}
+
+// deployChange is one difference between the deployed flow and the file. Path
+// is a JSON pointer into the flow document; list members added to or removed
+// from a role or keyword list are reported one per change.
+type deployChange struct {
+	Op   string      `json:"op"`
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// deployPlan is what flows deploy does: create the flow, update it, or
+// nothing when it already matches the file.
+type deployPlan struct {
+	Action   string         `json:"action"`
+	FlowID   string         `json:"flow_id,omitempty"`
+	Title    string         `json:"title"`
+	Changes  []deployChange `json:"changes"`
+	DryRun   bool           `json:"dry_run"`
+	LockFile string         `json:"lock_file,omitempty"`
+}
+
+// flowLock is the lock file flows deploy writes next to the deploy file.
+type flowLock struct {
+	FlowID    string `json:"flow_id"`
+	Title     string `json:"title"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// DeployCmd represents the flows deploy command
+var DeployCmd = &cobra.Command{
+	Use:   "deploy -f FILE",
+	Short: "Create or update a flow from a deploy file",
+	Long: `Create a flow, or bring an existing one up to date, from a deploy file.
+
+The deploy file (JSON or YAML, with the includes 'flows render' describes)
+declares the flow's metadata, definition and policy:
+
+  title: Transfer and index
+  subtitle: Nightly ingest
+  description: Copies new data and indexes it.
+  keywords: [transfer, search]
+  definition: definition.yaml
+  input_schema: !include schema.yaml
+  subscription_id: DEFAULT
+  administrators: [urn:globus:groups:id:0a2f6c40-6f58-11ee-b8d6-0242ac120002]
+  starters: [all_authenticated_users]
+  viewers: [public]
+  run_managers: []
+  run_monitors: []
+
+title and definition are required. definition and input_schema hold an
+object, or the name of a file relative to the deploy file. Fields left out
+are not managed; a role or keyword list, once given, is the complete list,
+so an empty one clears it.
+
+The flow to update is the one named by --flow-id, else the one recorded in
+the lock file, else the single flow you own with the same title. When there
+is none, the flow is created. The definition is checked as 'flows validate
+--offline' would before anything is sent.
+
+The differences are printed as JSON pointers into the flow document, then
+applied. The flow ID is written to the lock file (FILE with its extension
+replaced by .lock.json, unless --lock-file is given), so later deploys update
+the same flow even after a title change; commit it alongside the deploy file
+in CI. --dry-run prints the differences without applying them.
+
+Examples:
+  # Deploy a flow
+  globus flows deploy -f flow.yaml
+
+  # Show what would change
+  globus flows deploy -f flow.yaml --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runFlowsDeploy,
+}
+
+func init() {
+	DeployCmd.Flags().StringVarP(&deployFile, "file", "f", "", "JSON or YAML file declaring the flow")
+	DeployCmd.Flags().StringVar(&deployLockFile, "lock-file", "", "File recording the deployed flow ID (default: FILE with a .lock.json extension)")
+	DeployCmd.Flags().StringVar(&deployFlowID, "flow-id", "", "Update this flow, ignoring the lock file")
+	DeployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Print the differences without applying them")
+	_ = DeployCmd.MarkFlagRequired("file")
+}
+
+func runFlowsDeploy(cmd *cobra.Command, args []string) error {
+	doc, err := loadFlowDocument(deployFile)
+	if err != nil {
+		return fmt.Errorf("error reading deploy file: %w", err)
+	}
+	deployment, err := parseFlowDeployment(doc)
+	if err != nil {
+		return err
+	}
+
+	if findings := lintFlow(deployment.Definition, deployment.Schema); hasLintErrors(findings) {
+		for _, f := range findings {
+			if f.Severity == lintError {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d: %s: %s: %s\n", f.File, f.Line, f.Severity, f.Pointer, f.Message)
+			}
+		}
+		return fmt.Errorf("flow definition is invalid; see 'globus flows validate --offline'")
+	}
+
+	lockPath := deployLockFile
+	if lockPath == "" {
+		lockPath = defaultLockPath(deployFile)
+	}
+	lock, err := readFlowLock(lockPath)
+	if err != nil {
+		return err
+	}
+	lockID := ""
+	if lock != nil {
+		lockID = lock.FlowID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	api, err := getRawClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	current, err := findDeployTarget(ctx, api, deployFlowID, lockID, deployment.Title, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	plan := planFlowDeploy(deployment, current)
+	plan.DryRun = deployDryRun
+
+	if !deployDryRun {
+		plan.LockFile = lockPath
+		flow, err := applyFlowDeploy(ctx, api, deployment, plan, current)
+		if err != nil {
+			return err
+		}
+		plan.FlowID = stringField(flow, "id")
+		newLock := &flowLock{FlowID: plan.FlowID, Title: stringField(flow, "title"), UpdatedAt: stringField(flow, "updated_at")}
+		if lock == nil || *lock != *newLock {
+			if err := writeFlowLock(lockPath, newLock); err != nil {
+				return err
+			}
+		}
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format != output.FormatText {
+		return formatter.FormatOutput(plan, nil)
+	}
+	printDeployPlan(cmd.OutOrStdout(), plan)
+	return nil
+}
+
+// parseFlowDeployment reads the declared fields of a deploy file. A
+// definition or input schema given as a string is loaded from that file,
+// relative to the deploy file.
+func parseFlowDeployment(doc *flowDocument) (*flowDeployment, error) {
+	known := map[string]string{"schema": "input_schema"}
+	for _, k := range deployKeys {
+		known[k.key] = k.field
+	}
+
+	d := &flowDeployment{Fields: map[string]interface{}{}}
+	for _, key := range sortedKeys(doc.Data) {
+		value := doc.Data[key]
+		field, ok := known[key]
+		where := fmt.Sprintf("%s:%d", doc.Path, doc.lineOf("/"+escapePointer(key)))
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown key %q", where, key)
+		}
+		if _, dup := d.Fields[field]; dup {
+			return nil, fmt.Errorf("%s: %s is declared twice (schema and input_schema)", where, field)
+		}
+
+		switch field {
+		case "title", "subtitle", "description", "subscription_id":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s must be a string, not %s", where, key, jsonType(value))
+			}
+			d.Fields[field] = s
+		case "definition", "input_schema":
+			sub, err := deploySubDocument(doc, key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", where, key, err)
+			}
+			d.Fields[field] = sub.Data
+			if field == "definition" {
+				d.Definition = sub
+			} else {
+				d.Schema = sub
+			}
+		default:
+			list, ok := deployStringList(value)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s must be a list of strings", where, key)
+			}
+			d.Fields[field] = list
+		}
+	}
+
+	d.Title, _ = d.Fields["title"].(string)
+	if strings.TrimSpace(d.Title) == "" {
+		return nil, fmt.Errorf("%s: title is required", doc.Path)
+	}
+	if d.Definition == nil {
+		return nil, fmt.Errorf("%s: definition is required", doc.Path)
+	}
+	return d, nil
+}
+
+// deploySubDocument returns the object at key of doc as a document of its
+// own, keeping doc's line numbers, or loads it from the file key names.
+func deploySubDocument(doc *flowDocument, key string) (*flowDocument, error) {
+	switch v := doc.Data[key].(type) {
+	case string:
+		path := v
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(doc.Path), path)
+		}
+		return loadFlowDocument(path)
+	case map[string]interface{}:
+		prefix := "/" + escapePointer(key)
+		lines := map[string]int{}
+		for pointer, line := range doc.lines {
+			if pointer == prefix || strings.HasPrefix(pointer, prefix+"/") {
+				lines[strings.TrimPrefix(pointer, prefix)] = line
+			}
+		}
+		return &flowDocument{Path: doc.Path, Data: v, lines: lines}, nil
+	default:
+		return nil, fmt.Errorf("expected an object or a file name, not %s", jsonType(v))
+	}
+}
+
+// deployStringList converts a decoded list of strings.
+func deployStringList(v interface{}) ([]string, bool) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, v == nil
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, s)
+	}
+	return list, true
+}
+
+// defaultLockPath is the deploy file's name with its extension replaced by
+// .lock.json.
+func defaultLockPath(file string) string {
+	return strings.TrimSuffix(file, filepath.Ext(file)) + ".lock.json"
+}
+
+// readFlowLock reads a lock file, returning nil when there is none.
+func readFlowLock(path string) (*flowLock, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	var lock flowLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("invalid lock file %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// writeFlowLock writes a lock file.
+func writeFlowLock(path string, lock *flowLock) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// findDeployTarget returns the flow a deploy updates, or nil when it must be
+// created. An explicit flow ID must exist; a locked one that no longer does
+// (or is no longer visible) falls back to the title, with a warning.
+func findDeployTarget(ctx context.Context, api flowsAPI, flowID, lockID, title string, warn io.Writer) (map[string]interface{}, error) {
+	if flowID != "" {
+		flow, err := getFlowDoc(ctx, api, flowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get flow %s: %w", flowID, err)
+		}
+		return flow, nil
+	}
+
+	if lockID != "" {
+		flow, err := getFlowDoc(ctx, api, lockID)
+		if err == nil {
+			return flow, nil
+		}
+		var apiErr *core.APIError
+		if !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusNotFound && apiErr.StatusCode != http.StatusForbidden) {
+			return nil, fmt.Errorf("failed to get flow %s: %w", lockID, err)
+		}
+		fmt.Fprintf(warn, "Warning: flow %s in the lock file was not found; looking it up by title\n", lockID)
+	}
+
+	var matches []map[string]interface{}
+	query := url.Values{"filter_roles": {"flow_owner"}, "filter_fulltext": {title}}
+	for {
+		var page struct {
+			Flows       []map[string]interface{} `json:"flows"`
+			Marker      string                   `json:"marker"`
+			HasNextPage bool                     `json:"has_next_page"`
+		}
+		if err := api.DoRequest(ctx, http.MethodGet, "/flows", query, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to list flows: %w", err)
+		}
+		for _, flow := range page.Flows {
+			if stringField(flow, "title") == title {
+				matches = append(matches, flow)
+			}
+		}
+		if !page.HasNextPage || page.Marker == "" {
+			break
+		}
+		query.Set("marker", page.Marker)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		// List entries may be abbreviated; diff against the full document.
+		id := stringField(matches[0], "id")
+		flow, err := getFlowDoc(ctx, api, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get flow %s: %w", id, err)
+		}
+		return flow, nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, flow := range matches {
+		ids = append(ids, stringField(flow, "id"))
+	}
+	return nil, fmt.Errorf("you own %d flows titled %q (%s); choose one with --flow-id", len(matches), title, strings.Join(ids, ", "))
+}
+
+// getFlowDoc fetches a flow document.
+func getFlowDoc(ctx context.Context, api flowsAPI, flowID string) (map[string]interface{}, error) {
+	var flow map[string]interface{}
+	if err := api.DoRequest(ctx, http.MethodGet, "/flows/"+url.PathEscape(flowID), nil, nil, &flow); err != nil {
+		return nil, err
+	}
+	return flow, nil
+}
+
+// planFlowDeploy compares the deployment with the deployed flow (nil when it
+// does not exist yet).
+func planFlowDeploy(d *flowDeployment, current map[string]interface{}) *deployPlan {
+	plan := &deployPlan{Action: "create", Title: d.Title, Changes: []deployChange{}}
+	if current == nil {
+		for _, k := range deployKeys {
+			if v, ok := d.Fields[k.field]; ok {
+				plan.Changes = append(plan.Changes, deployChange{Op: "add", Path: "/" + k.field, New: v})
+			}
+		}
+		return plan
+	}
+
+	plan.FlowID = stringField(current, "id")
+	for _, k := range deployKeys {
+		desired, ok := d.Fields[k.field]
+		if !ok {
+			continue
+		}
+		path := "/" + k.field
+		switch k.field {
+		case "definition", "input_schema":
+			diffJSON(path, current[k.field], desired, &plan.Changes)
+		case "title", "subtitle", "description", "subscription_id":
+			old := stringField(current, k.field)
+			// DEFAULT asks Flows to pick the caller's subscription; any
+			// subscription already set satisfies it.
+			if old == desired || (k.field == "subscription_id" && desired == "DEFAULT" && old != "") {
+				continue
+			}
+			plan.Changes = append(plan.Changes, deployChange{Op: "change", Path: path, Old: current[k.field], New: desired})
+		default:
+			old, _ := deployStringList(current[k.field])
+			diffStringSet(path, old, desired.([]string), &plan.Changes)
+		}
+	}
+	if len(plan.Changes) == 0 {
+		plan.Action = "none"
+	} else {
+		plan.Action = "update"
+	}
+	return plan
+}
+
+// diffJSON appends the differences between two JSON values. Objects are
+// compared member by member and lists of equal length element by element;
+// anything else that differs is one change.
+func diffJSON(path string, old, new interface{}, changes *[]deployChange) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+	oldObj, oldIsObj := old.(map[string]interface{})
+	newObj, newIsObj := new.(map[string]interface{})
+	if oldIsObj && newIsObj {
+		keys := sortedKeys(oldObj)
+		for k := range newObj {
+			if _, ok := oldObj[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := path + "/" + escapePointer(k)
+			ov, inOld := oldObj[k]
+			nv, inNew := newObj[k]
+			switch {
+			case !inOld:
+				*changes = append(*changes, deployChange{Op: "add", Path: child, New: nv})
+			case !inNew:
+				*changes = append(*changes, deployChange{Op: "remove", Path: child, Old: ov})
+			default:
+				diffJSON(child, ov, nv, changes)
+			}
+		}
+		return
+	}
+	oldList, oldIsList := old.([]interface{})
+	newList, newIsList := new.([]interface{})
+	if oldIsList && newIsList && len(oldList) == len(newList) {
+		for i := range oldList {
+			diffJSON(fmt.Sprintf("%s/%d", path, i), oldList[i], newList[i], changes)
+		}
+		return
+	}
+	if old == nil {
+		*changes = append(*changes, deployChange{Op: "add", Path: path, New: new})
+		return
+	}
+	*changes = append(*changes, deployChange{Op: "change", Path: path, Old: old, New: new})
+}
+
+// diffStringSet appends the members added to and removed from a list whose
+// order does not matter.
+func diffStringSet(path string, old, new []string, changes *[]deployChange) {
+	have := map[string]bool{}
+	for _, s := range old {
+		have[s] = true
+	}
+	want := map[string]bool{}
+	for _, s := range new {
+		want[s] = true
+		if !have[s] {
+			*changes = append(*changes, deployChange{Op: "add", Path: path + "/-", New: s})
+			have[s] = true
+		}
+	}
+	for _, s := range old {
+		if !want[s] {
+			*changes = append(*changes, deployChange{Op: "remove", Path: path + "/-", Old: s})
+			want[s] = true
+		}
+	}
+}
+
+// applyFlowDeploy creates or updates the flow and returns the flow document
+// Flows sends back, or current when there is nothing to do. An update sends
+// every top-level field that changed.
+func applyFlowDeploy(ctx context.Context, api flowsAPI, d *flowDeployment, plan *deployPlan, current map[string]interface{}) (map[string]interface{}, error) {
+	var flow map[string]interface{}
+	switch plan.Action {
+	case "create":
+		body := map[string]interface{}{"input_schema": map[string]interface{}{}}
+		for field, v := range d.Fields {
+			body[field] = v
+		}
+		if err := api.DoRequest(ctx, http.MethodPost, "/flows", nil, body, &flow); err != nil {
+			return nil, fmt.Errorf("error creating flow: %w", err)
+		}
+	case "update":
+		body := map[string]interface{}{}
+		for _, c := range plan.Changes {
+			field := strings.SplitN(c.Path[1:], "/", 2)[0]
+			body[field] = d.Fields[field]
+		}
+		if err := api.DoRequest(ctx, http.MethodPut, "/flows/"+url.PathEscape(plan.FlowID), nil, body, &flow); err != nil {
+			return nil, fmt.Errorf("error updating flow: %w", err)
+		}
+	default:
+		return current, nil
+	}
+	return flow, nil
+}
+
+// printDeployPlan prints a plan in text form, one line per change.
+func printDeployPlan(w io.Writer, plan *deployPlan) {
+	switch {
+	case plan.Action == "none":
+		fmt.Fprintf(w, "Flow %q (%s) already matches the deploy file; no changes.\n", plan.Title, plan.FlowID)
+	case plan.Action == "create" && plan.DryRun:
+		fmt.Fprintf(w, "Flow %q does not exist and would be created:\n\n", plan.Title)
+	case plan.Action == "create":
+		fmt.Fprintf(w, "Created flow %q (%s):\n\n", plan.Title, plan.FlowID)
+	case plan.DryRun:
+		fmt.Fprintf(w, "Flow %q (%s) would change:\n\n", plan.Title, plan.FlowID)
+	default:
+		fmt.Fprintf(w, "Updated flow %q (%s):\n\n", plan.Title, plan.FlowID)
+	}
+
+	for _, c := range plan.Changes {
+		switch c.Op {
+		case "add":
+			fmt.Fprintf(w, "  + %s: %s\n", c.Path, formatDeployValue(c.New))
+		case "remove":
+			fmt.Fprintf(w, "  - %s: %s\n", c.Path, formatDeployValue(c.Old))
+		default:
+			fmt.Fprintf(w, "  ~ %s: %s -> %s\n", c.Path, formatDeployValue(c.Old), formatDeployValue(c.New))
+		}
+	}
+
+	if plan.LockFile != "" {
+		fmt.Fprintf(w, "\nLock file: %s\n", plan.LockFile)
+	}
+}
+
+// formatDeployValue renders a value as compact JSON, shortened for plan
+// output.
+func formatDeployValue(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	s := strings.TrimSuffix(buf.String(), "\n")
+	if runes := []rune(s); len(runes) > 72 {
+		s = string(runes[:69]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+)
+
+// fakeFlows serves flow documents by ID and records writes.
+type fakeFlows struct {
+	flows  map[string]map[string]interface{}
+	writes []string
+}
+
+func (f *fakeFlows) DoRequest(_ context.Context, method, path string, query url.Values, body, result interface{}) error {
+	var resp interface{}
+	switch {
+	case method == http.MethodGet && path == "/flows":
+		var list []map[string]interface{}
+		for _, flow := range f.flows {
+			if strings.Contains(flow["title"].(string), query.Get("filter_fulltext")) {
+				list = append(list, flow)
+			}
+		}
+		resp = map[string]interface{}{"flows": list, "has_next_page": false}
+	case method == http.MethodGet:
+		flow, ok := f.flows[strings.TrimPrefix(path, "/flows/")]
+		if !ok {
+			return &core.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+		}
+		resp = flow
+	default:
+		f.writes = append(f.writes, method+" "+path)
+		resp = map[string]interface{}{"id": "new-id", "title": body.(map[string]interface{})["title"]}
+	}
+	raw, _ := json.Marshal(resp)
+	return json.Unmarshal(raw, result)
+}
+
+const deployDefinition = `
+StartAt: Hello
+States:
+  Hello:
+    Type: Pass
+    End: true
+`
+
+// TestParseFlowDeployment checks deploy file fields, file references and
+// errors.
+func TestParseFlowDeployment(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"flow.yaml":       "title: Hello\ndefinition: definition.yaml\nschema:\n  type: object\nviewers: [public]\nrun_monitors: []\n",
+		"definition.yaml": deployDefinition,
+	})
+	doc, err := loadFlowDocument(filepath.Join(dir, "flow.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := parseFlowDeployment(doc)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if d.Title != "Hello" || d.Definition.Data["StartAt"] != "Hello" || d.Schema.Data["type"] != "object" {
+		t.Errorf("deployment = %+v", d)
+	}
+	if !reflect.DeepEqual(d.Fields["flow_viewers"], []string{"public"}) || len(d.Fields["run_monitors"].([]string)) != 0 {
+		t.Errorf("fields = %v", d.Fields)
+	}
+	if d.Schema.lineOf("/type") != 4 {
+		t.Errorf("inline schema line = %d, want 4", d.Schema.lineOf("/type"))
+	}
+
+	for name, body := range map[string]string{
+		"unknown key":   "title: Hello\ndefinition: {}\nowner: me\n",
+		"no title":      "definition: {}\n",
+		"no definition": "title: Hello\n",
+		"bad list":      "title: Hello\ndefinition: {}\nviewers: public\n",
+		"two schemas":   "title: Hello\ndefinition: {}\nschema: {}\ninput_schema: {}\n",
+	} {
+		if _, err := parseFlowDeployment(writeDoc(t, "flow.yaml", body)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestPlanFlowDeploy checks the structured diff against a deployed flow.
+func TestPlanFlowDeploy(t *testing.T) {
+	d := &flowDeployment{Title: "Hello", Fields: map[string]interface{}{
+		"title":           "Hello",
+		"definition":      decodeJSON(t, `{"StartAt": "A", "States": {"A": {"Type": "Pass", "Next": "B"}, "B": {"Type": "Pass", "End": true}}}`),
+		"subscription_id": "DEFAULT",
+		"flow_viewers":    []string{"public", "urn:globus:groups:id:lab"},
+		"flow_starters":   []string{},
+	}}
+
+	current := decodeJSON(t, `{
+		"id": "flow-1", "title": "Hello", "subscription_id": "sub-1", "description": "kept",
+		"definition": {"StartAt": "A", "States": {"A": {"Type": "Pass", "End": true}, "Old": {"Type": "Pass", "End": true}}},
+		"flow_viewers": ["public"], "flow_starters": ["all_authenticated_users"]
+	}`)
+	plan := planFlowDeploy(d, current)
+	want := []deployChange{
+		{Op: "remove", Path: "/definition/States/A/End", Old: true},
+		{Op: "add", Path: "/definition/States/A/Next", New: "B"},
+		{Op: "add", Path: "/definition/States/B", New: map[string]interface{}{"Type": "Pass", "End": true}},
+		{Op: "remove", Path: "/definition/States/Old", Old: map[string]interface{}{"Type": "Pass", "End": true}},
+		{Op: "remove", Path: "/flow_starters/-", Old: "all_authenticated_users"},
+		{Op: "add", Path: "/flow_viewers/-", New: "urn:globus:groups:id:lab"},
+	}
+	if plan.Action != "update" || plan.FlowID != "flow-1" || !reflect.DeepEqual(plan.Changes, want) {
+		t.Errorf("plan = %+v", plan)
+	}
+
+	current["definition"] = d.Fields["definition"]
+	current["flow_viewers"] = []interface{}{"urn:globus:groups:id:lab", "public"}
+	current["flow_starters"] = nil
+	if plan := planFlowDeploy(d, current); plan.Action != "none" || len(plan.Changes) != 0 {
+		t.Errorf("matching flow: plan = %+v", plan)
+	}
+
+	if plan := planFlowDeploy(d, nil); plan.Action != "create" || len(plan.Changes) != len(d.Fields) {
+		t.Errorf("new flow: plan = %+v", plan)
+	}
+}
+
+// TestFindDeployTarget checks lookup by flow ID, lock file and title.
+func TestFindDeployTarget(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeFlows{flows: map[string]map[string]interface{}{
+		"flow-1": {"id": "flow-1", "title": "Hello"},
+		"flow-2": {"id": "flow-2", "title": "Hello again"},
+	}}
+	var warn bytes.Buffer
+
+	for _, tc := range []struct{ flowID, lockID, title, want string }{
+		{"flow-2", "flow-1", "Hello", "flow-2"},
+		{"", "flow-2", "Hello", "flow-2"},
+		{"", "", "Hello", "flow-1"},
+		{"", "gone", "Hello", "flow-1"},
+		{"", "", "Goodbye", ""},
+	} {
+		flow, err := findDeployTarget(ctx, fake, tc.flowID, tc.lockID, tc.title, &warn)
+		if err != nil || stringField(flow, "id") != tc.want {
+			t.Errorf("%+v: flow = %v, err = %v", tc, flow, err)
+		}
+	}
+	if !strings.Contains(warn.String(), "flow gone in the lock file") {
+		t.Errorf("warning = %q", warn.String())
+	}
+
+	if _, err := findDeployTarget(ctx, fake, "gone", "", "Hello", &warn); err == nil {
+		t.Error("missing --flow-id: expected an error")
+	}
+	fake.flows["flow-3"] = map[string]interface{}{"id": "flow-3", "title": "Hello"}
+	if _, err := findDeployTarget(ctx, fake, "", "", "Hello", &warn); err == nil || !strings.Contains(err.Error(), "--flow-id") {
+		t.Errorf("two flows titled Hello: err = %v", err)
+	}
+}
+
+// TestApplyFlowDeploy checks that an update sends only the changed fields
+// and the lock file round-trips.
+func TestApplyFlowDeploy(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeFlows{flows: map[string]map[string]interface{}{}}
+	d := &flowDeployment{Title: "Hello", Fields: map[string]interface{}{"title": "Hello", "definition": map[string]interface{}{}}}
+
+	plan := planFlowDeploy(d, nil)
+	flow, err := applyFlowDeploy(ctx, fake, d, plan, nil)
+	if err != nil || stringField(flow, "id") != "new-id" {
+		t.Fatalf("create: flow = %v, err = %v", flow, err)
+	}
+
+	plan = &deployPlan{Action: "update", FlowID: "flow-1", Changes: []deployChange{{Op: "change", Path: "/title"}}}
+	if _, err := applyFlowDeploy(ctx, fake, d, plan, nil); err != nil {
+		t.Fatal(err)
+	}
+	plan = &deployPlan{Action: "none", FlowID: "flow-1"}
+	if _, err := applyFlowDeploy(ctx, fake, d, plan, map[string]interface{}{"id": "flow-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"POST /flows", "PUT /flows/flow-1"}; !reflect.DeepEqual(fake.writes, want) {
+		t.Errorf("writes = %v, want %v", fake.writes, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "flow.lock.json")
+	if lock, err := readFlowLock(path); lock != nil || err != nil {
+		t.Errorf("missing lock: lock = %v, err = %v", lock, err)
+	}
+	want := &flowLock{FlowID: "flow-1", Title: "Hello", UpdatedAt: "2026-01-02T03:04:05Z"}
+	if err := writeFlowLock(path, want); err != nil {
+		t.Fatal(err)
+	}
+	if lock, err := readFlowLock(path); err != nil || *lock != *want {
+		t.Errorf("lock = %v, err = %v", lock, err)
+	}
+	if got := defaultLockPath("deploy/flow.yaml"); got != "deploy/flow.lock.json" {
+		t.Errorf("defaultLockPath = %q", got)
+	}
+}
+
+// TestFormatDeployValue checks that long values are shortened on character
+// boundaries.
+func TestFormatDeployValue(t *testing.T) {
+	if got := formatDeployValue(map[string]interface{}{"a": "<b>"}); got != `{"a":"<b>"}` {
+		t.Errorf("short value = %s", got)
+	}
+	got := formatDeployValue(strings.Repeat("é", 100))
+	if !utf8.ValidString(got) || utf8.RuneCountInString(got) != 72 || !strings.HasSuffix(got, "...") {
+		t.Errorf("long value = %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// flowDocument is a flow definition or input schema read from a file, with
+// the line each JSON pointer starts on so findings can point at the source.
+type flowDocument struct {
+	Path  string
+	Data  map[string]interface{}
+	lines map[string]int
+}
+
+// loadFlowDocument reads a flow definition or input schema. JSON and YAML
+// (.yaml, .yml) files are accepted; see flowCompiler for the include forms
+// they may use. The result is the single JSON document Globus Flows expects.
+func loadFlowDocument(path string) (*flowDocument, error) {
+	c := &flowCompiler{}
+	v, lines, err := c.load(path)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: expected an object, not %s", path, jsonType(v))
+	}
+	return &flowDocument{Path: path, Data: data, lines: lines}, nil
+}
+
+// lineOf returns the line pointer starts on, falling back to its nearest
+// ancestor for members that are missing from the document. It returns 0 when
+// lines are unknown.
+func (d *flowDocument) lineOf(pointer string) int {
+	if d == nil {
+		return 0
+	}
+	for {
+		if line, ok := d.lines[pointer]; ok {
+			return line
+		}
+		i := strings.LastIndexByte(pointer, '/')
+		if i < 0 {
+			return 0
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// jsonPointerLines maps the JSON pointer of every value in data to the line it
+// starts on. A member's line is that of its key.
+func jsonPointerLines(data []byte) (map[string]int, error) {
+	lines := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	line, seen := 1, 0
+	lineAt := func(offset int64) int {
+		line += bytes.Count(data[seen:offset], []byte{'\n'})
+		seen = int(offset)
+		return line
+	}
+
+	var walk func(pointer string) error
+	walk = func(pointer string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := lines[pointer]; !ok {
+			lines[pointer] = lineAt(dec.InputOffset())
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child := pointer + "/" + escapePointer(key.(string))
+				lines[child] = lineAt(dec.InputOffset())
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(pointer + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	return lines, walk("")
+}
+
+// escapePointer escapes a member name for use in a JSON pointer (RFC 6901).
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
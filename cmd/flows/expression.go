@@ -0,0 +1,847 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// evalExpression evaluates a Flows ".=" expression against the run state,
+// whose top-level members are available as names. Flows evaluates these as a
+// restricted Python subset; the simulator supports literals (numbers,
+// strings, True, False, None, lists, dicts), names, .attr and [index]
+// access, arithmetic, comparisons, in, and/or/not, x if c else y, and the
+// built-ins len, str, int, float, bool, abs, min, max, round and sum.
+func evalExpression(expr string, state map[string]interface{}) (interface{}, error) {
+	toks, err := lexExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", expr, err)
+	}
+	p := &exprParser{toks: toks, names: state}
+	v, err := p.ternary()
+	if err == nil && p.peek().kind != tokEOF {
+		err = fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", expr, err)
+	}
+	return v, nil
+}
+
+// Expression token kinds.
+const (
+	tokEOF = iota
+	tokNumber
+	tokString
+	tokName
+	tokOp
+)
+
+type exprToken struct {
+	kind int
+	text string
+	num  float64
+}
+
+// exprOperators are the operator tokens, longest first.
+var exprOperators = []string{"**", "//", "==", "!=", "<=", ">=", "+", "-", "*", "/", "%", "<", ">", "(", ")", "[", "]", "{", "}", ",", ".", ":"}
+
+func lexExpression(s string) ([]exprToken, error) {
+	var toks []exprToken
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c >= '0' && c <= '9' || (c == '.' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9'):
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.' || s[j] == '_' ||
+				s[j] == 'e' || s[j] == 'E' || ((s[j] == '+' || s[j] == '-') && (s[j-1] == 'e' || s[j-1] == 'E'))) {
+				j++
+			}
+			n, err := strconv.ParseFloat(strings.ReplaceAll(s[i:j], "_", ""), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", s[i:j])
+			}
+			toks = append(toks, exprToken{kind: tokNumber, text: s[i:j], num: n})
+			i = j
+		case c == '\'' || c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && rune(s[j]) != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+					switch s[j] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(s[j])
+					}
+					continue
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			toks = append(toks, exprToken{kind: tokString, text: b.String()})
+			i = j + 1
+		case c == '_' || unicode.IsLetter(c):
+			j := i
+			for j < len(s) && (s[j] == '_' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			toks = append(toks, exprToken{kind: tokName, text: s[i:j]})
+			i = j
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(s[i:], op) {
+					toks = append(toks, exprToken{kind: tokOp, text: op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q", c)
+			}
+		}
+	}
+	return append(toks, exprToken{kind: tokEOF}), nil
+}
+
+// exprParser evaluates while it parses; expressions are small and run once.
+// Branches whose value is not needed (the untaken side of a conditional, the
+// right of a short-circuited and/or) are parsed with dead set, so a guard
+// such as "x['k'] if 'k' in x else None" does not fail on the missing key.
+type exprParser struct {
+	toks  []exprToken
+	pos   int
+	dead  int
+	names map[string]interface{}
+}
+
+// fail reports an evaluation error, or carries on with None in a dead branch.
+func (p *exprParser) fail(err error) (interface{}, error) {
+	if p.dead > 0 {
+		return nil, nil
+	}
+	return nil, err
+}
+
+func (p *exprParser) peek() exprToken { return p.toks[p.pos] }
+
+func (p *exprParser) next() exprToken {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the operator or keyword text.
+func (p *exprParser) accept(text string) bool {
+	if t := p.peek(); (t.kind == tokOp || t.kind == tokName) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) expect(text string) error {
+	if !p.accept(text) {
+		return fmt.Errorf("expected %q, found %q", text, p.peek().text)
+	}
+	return nil
+}
+
+// ternary: or_expr ["if" or_expr "else" ternary]. As in Python, the
+// condition is evaluated first and only the chosen branch is evaluated; the
+// other is skipped over as a dead branch.
+func (p *exprParser) ternary() (interface{}, error) {
+	start := p.pos
+	if err := p.skip(p.or); err != nil {
+		return nil, err
+	}
+	if !p.accept("if") {
+		p.pos = start
+		return p.or()
+	}
+	cond, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("else"); err != nil {
+		return nil, err
+	}
+	if !truthy(cond) {
+		return p.ternary()
+	}
+	if err := p.skip(p.ternary); err != nil {
+		return nil, err
+	}
+	end := p.pos
+	p.pos = start
+	v, err := p.or()
+	p.pos = end
+	return v, err
+}
+
+// skip parses one production as a dead branch.
+func (p *exprParser) skip(parse func() (interface{}, error)) error {
+	p.dead++
+	defer func() { p.dead-- }()
+	_, err := parse()
+	return err
+}
+
+func (p *exprParser) or() (interface{}, error) {
+	v, err := p.and()
+	for err == nil && p.accept("or") {
+		if truthy(v) {
+			err = p.skip(p.and)
+		} else {
+			v, err = p.and()
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) and() (interface{}, error) {
+	v, err := p.not()
+	for err == nil && p.accept("and") {
+		if !truthy(v) {
+			err = p.skip(p.not)
+		} else {
+			v, err = p.not()
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) not() (interface{}, error) {
+	if p.accept("not") {
+		v, err := p.not()
+		return !truthy(v), err
+	}
+	return p.comparison()
+}
+
+// comparison handles chains such as a < b <= c.
+func (p *exprParser) comparison() (interface{}, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	var result interface{} = left
+	chained := false
+	for {
+		op := ""
+		switch t := p.peek(); {
+		case t.kind == tokOp && (t.text == "==" || t.text == "!=" || t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+			op = t.text
+			p.pos++
+		case p.accept("in"):
+			op = "in"
+		case t.kind == tokName && t.text == "not" && p.toks[p.pos+1].text == "in":
+			p.pos += 2
+			op = "not in"
+		}
+		if op == "" {
+			return result, nil
+		}
+		right, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := compareValues(op, left, right)
+		if err != nil {
+			return p.fail(err)
+		}
+		if !chained || result == true {
+			result = ok
+		}
+		chained = true
+		left = right
+	}
+}
+
+func (p *exprParser) sum() (interface{}, error) {
+	v, err := p.product()
+	for err == nil {
+		t := p.peek()
+		if t.kind != tokOp || (t.text != "+" && t.text != "-") {
+			break
+		}
+		p.pos++
+		var r interface{}
+		if r, err = p.product(); err == nil {
+			v, err = p.arithmetic(t.text, v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) product() (interface{}, error) {
+	v, err := p.unary()
+	for err == nil {
+		t := p.peek()
+		if t.kind != tokOp || (t.text != "*" && t.text != "/" && t.text != "//" && t.text != "%") {
+			break
+		}
+		p.pos++
+		var r interface{}
+		if r, err = p.unary(); err == nil {
+			v, err = p.arithmetic(t.text, v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) unary() (interface{}, error) {
+	if t := p.peek(); t.kind == tokOp && (t.text == "-" || t.text == "+") {
+		p.pos++
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		n, ok := v.(float64)
+		if !ok {
+			return p.fail(fmt.Errorf("bad operand for unary %s: %s", t.text, jsonType(v)))
+		}
+		if t.text == "-" {
+			n = -n
+		}
+		return n, nil
+	}
+	return p.power()
+}
+
+func (p *exprParser) power() (interface{}, error) {
+	v, err := p.postfix()
+	if err != nil || !p.accept("**") {
+		return v, err
+	}
+	r, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return p.arithmetic("**", v, r)
+}
+
+// arithmetic applies a binary operator, failing softly in dead branches.
+func (p *exprParser) arithmetic(op string, a, b interface{}) (interface{}, error) {
+	v, err := arithmetic(op, a, b)
+	if err != nil {
+		return p.fail(err)
+	}
+	return v, nil
+}
+
+// postfix handles calls, .attr and [index] after an atom.
+func (p *exprParser) postfix() (interface{}, error) {
+	t := p.peek()
+	if t.kind == tokName && p.toks[p.pos+1].text == "(" {
+		if _, ok := exprBuiltins[t.text]; ok {
+			p.pos += 2
+			args, err := p.list(")")
+			if err != nil {
+				return nil, err
+			}
+			v, err := exprBuiltins[t.text](args)
+			if err != nil {
+				if v, err = p.fail(fmt.Errorf("%s(): %w", t.text, err)); err != nil {
+					return nil, err
+				}
+			}
+			return p.accessors(v)
+		}
+		return nil, fmt.Errorf("unsupported function %q (supported: %s)", t.text, strings.Join(sortedExprBuiltins(), ", "))
+	}
+	v, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	return p.accessors(v)
+}
+
+func (p *exprParser) accessors(v interface{}) (interface{}, error) {
+	for {
+		switch {
+		case p.accept("."):
+			name := p.next()
+			if name.kind != tokName {
+				return nil, fmt.Errorf("expected a name after \".\"")
+			}
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				if _, err := p.fail(fmt.Errorf("cannot read .%s of a %s", name.text, jsonType(v))); err != nil {
+					return nil, err
+				}
+				v = nil
+				continue
+			}
+			if v, ok = obj[name.text]; !ok {
+				if _, err := p.fail(fmt.Errorf("no key %q", name.text)); err != nil {
+					return nil, err
+				}
+			}
+		case p.accept("["):
+			idx, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			if v, err = index(v, idx); err != nil {
+				if v, err = p.fail(err); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *exprParser) atom() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return t.num, nil
+	case tokString:
+		s := t.text
+		// Adjacent string literals concatenate, as in Python.
+		for p.peek().kind == tokString {
+			s += p.next().text
+		}
+		return s, nil
+	case tokName:
+		switch t.text {
+		case "True":
+			return true, nil
+		case "False":
+			return false, nil
+		case "None":
+			return nil, nil
+		}
+		v, ok := p.names[t.text]
+		if !ok {
+			return p.fail(fmt.Errorf("name %q is not defined", t.text))
+		}
+		return v, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			v, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			return v, p.expect(")")
+		case "[":
+			return p.list("]")
+		case "{":
+			obj := make(map[string]interface{})
+			for !p.accept("}") {
+				k, err := p.ternary()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := k.(string)
+				if !ok {
+					if _, err := p.fail(fmt.Errorf("dict keys must be strings")); err != nil {
+						return nil, err
+					}
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				if obj[key], err = p.ternary(); err != nil {
+					return nil, err
+				}
+				if !p.accept(",") {
+					if err := p.expect("}"); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+			return obj, nil
+		}
+	}
+	if t.kind == tokEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q", t.text)
+}
+
+// list parses comma-separated expressions up to the closing token.
+func (p *exprParser) list(closing string) ([]interface{}, error) {
+	items := []interface{}{}
+	for !p.accept(closing) {
+		v, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+		if !p.accept(",") {
+			if err := p.expect(closing); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return items, nil
+}
+
+// truthy applies Python truthiness.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func arithmetic(op string, a, b interface{}) (interface{}, error) {
+	x, xok := a.(float64)
+	y, yok := b.(float64)
+	if !xok || !yok {
+		switch {
+		case op == "+":
+			if s, ok := a.(string); ok {
+				if t, ok := b.(string); ok {
+					return s + t, nil
+				}
+			}
+			if s, ok := a.([]interface{}); ok {
+				if t, ok := b.([]interface{}); ok {
+					return append(append([]interface{}{}, s...), t...), nil
+				}
+			}
+		case op == "*" && xok:
+			if s, ok := b.(string); ok {
+				return strings.Repeat(s, int(math.Max(x, 0))), nil
+			}
+		case op == "*" && yok:
+			if s, ok := a.(string); ok {
+				return strings.Repeat(s, int(math.Max(y, 0))), nil
+			}
+		}
+		return nil, fmt.Errorf("unsupported operand types for %s: %s and %s", op, jsonType(a), jsonType(b))
+	}
+	switch op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "**":
+		return math.Pow(x, y), nil
+	}
+	if y == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	switch op {
+	case "/":
+		return x / y, nil
+	case "//":
+		return math.Floor(x / y), nil
+	}
+	// Python's % takes the sign of the divisor.
+	return x - y*math.Floor(x/y), nil
+}
+
+func compareValues(op string, a, b interface{}) (bool, error) {
+	switch op {
+	case "==":
+		return reflect.DeepEqual(a, b), nil
+	case "!=":
+		return !reflect.DeepEqual(a, b), nil
+	case "in", "not in":
+		var found bool
+		switch c := b.(type) {
+		case string:
+			s, ok := a.(string)
+			if !ok {
+				return false, fmt.Errorf("'in <string>' requires a string, not %s", jsonType(a))
+			}
+			found = strings.Contains(c, s)
+		case []interface{}:
+			for _, item := range c {
+				if reflect.DeepEqual(item, a) {
+					found = true
+					break
+				}
+			}
+		case map[string]interface{}:
+			s, _ := a.(string)
+			_, found = c[s]
+		default:
+			return false, fmt.Errorf("argument of type %s is not iterable", jsonType(b))
+		}
+		return found == (op == "in"), nil
+	}
+
+	var cmp int
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return false, fmt.Errorf("cannot compare number and %s", jsonType(b))
+		}
+		cmp = compareOrdered(x, y)
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot compare string and %s", jsonType(b))
+		}
+		cmp = strings.Compare(x, y)
+	default:
+		return false, fmt.Errorf("cannot order %s values", jsonType(a))
+	}
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func compareOrdered(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// index applies [idx] to a list, string or dict. Negative list and string
+// indexes count from the end.
+func index(v, idx interface{}) (interface{}, error) {
+	switch c := v.(type) {
+	case map[string]interface{}:
+		key, _ := idx.(string)
+		item, ok := c[key]
+		if !ok {
+			return nil, fmt.Errorf("no key %v", idx)
+		}
+		return item, nil
+	case []interface{}, string:
+		n, ok := idx.(float64)
+		if !ok || n != math.Trunc(n) {
+			return nil, fmt.Errorf("indexes must be integers")
+		}
+		i := int(n)
+		if s, ok := c.(string); ok {
+			if i < 0 {
+				i += len(s)
+			}
+			if i < 0 || i >= len(s) {
+				return nil, fmt.Errorf("string index out of range")
+			}
+			return s[i : i+1], nil
+		}
+		list := c.([]interface{})
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return nil, fmt.Errorf("list index out of range")
+		}
+		return list[i], nil
+	}
+	return nil, fmt.Errorf("%s is not subscriptable", jsonType(v))
+}
+
+// exprBuiltins are the functions expressions may call.
+var exprBuiltins = map[string]func(args []interface{}) (interface{}, error){
+	"len": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument")
+		}
+		switch v := args[0].(type) {
+		case string:
+			return float64(len([]rune(v))), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		}
+		return nil, fmt.Errorf("%s has no len", jsonType(args[0]))
+	},
+	"str": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument")
+		}
+		return exprString(args[0]), nil
+	},
+	"int": func(args []interface{}) (interface{}, error) {
+		n, err := toNumber(args)
+		return math.Trunc(n), err
+	},
+	"float": func(args []interface{}) (interface{}, error) {
+		return toNumber(args)
+	},
+	"bool": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument")
+		}
+		return truthy(args[0]), nil
+	},
+	"abs": func(args []interface{}) (interface{}, error) {
+		n, err := toNumber(args)
+		return math.Abs(n), err
+	},
+	"round": func(args []interface{}) (interface{}, error) {
+		n, err := toNumber(args)
+		// Python rounds halves to even.
+		return math.RoundToEven(n), err
+	},
+	"min": func(args []interface{}) (interface{}, error) { return extreme(args, "<") },
+	"max": func(args []interface{}) (interface{}, error) { return extreme(args, ">") },
+	"sum": func(args []interface{}) (interface{}, error) {
+		list, ok := singleList(args)
+		if !ok {
+			return nil, fmt.Errorf("takes one list")
+		}
+		total := 0.0
+		for _, item := range list {
+			n, ok := item.(float64)
+			if !ok {
+				return nil, fmt.Errorf("cannot add %s", jsonType(item))
+			}
+			total += n
+		}
+		return total, nil
+	},
+}
+
+func toNumber(args []interface{}) (float64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("takes one argument")
+	}
+	switch v := args[0].(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("cannot convert %s to a number", jsonType(args[0]))
+}
+
+func singleList(args []interface{}) ([]interface{}, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	list, ok := args[0].([]interface{})
+	return list, ok
+}
+
+// extreme implements min and max over arguments or a single list.
+func extreme(args []interface{}, op string) (interface{}, error) {
+	if list, ok := singleList(args); ok {
+		args = list
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty sequence")
+	}
+	best := args[0]
+	for _, v := range args[1:] {
+		better, err := compareValues(op, v, best)
+		if err != nil {
+			return nil, err
+		}
+		if better {
+			best = v
+		}
+	}
+	return best, nil
+}
+
+// exprString renders a value the way Python's str() would.
+func exprString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatFloat(v, 'f', 0, 64)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = exprRepr(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]interface{}:
+		keys := sortedKeys(v)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = exprRepr(k) + ": " + exprRepr(v[k])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+// exprRepr is exprString with strings quoted, for items inside containers.
+func exprRepr(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return "'" + strings.ReplaceAll(s, "'", "\\'") + "'"
+	}
+	return exprString(v)
+}
+
+// sortedExprBuiltins lists the built-in names, for help and error text.
+func sortedExprBuiltins() []string {
+	names := make([]string, 0, len(exprBuiltins))
+	for name := range exprBuiltins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
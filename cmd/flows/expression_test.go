@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEvalExpression checks the supported expression forms.
+func TestEvalExpression(t *testing.T) {
+	state := map[string]interface{}{
+		"n":     float64(7),
+		"name":  "run",
+		"files": []interface{}{"a", "b"},
+		"meta":  map[string]interface{}{"size": float64(10)},
+	}
+	tests := map[string]interface{}{
+		"n + 1":                               float64(8),
+		"2 ** 3 * 2":                          float64(16),
+		"-7 // 2":                             float64(-4),
+		"-7 % 3":                              float64(2),
+		"name + '-' + str(n)":                 "run-7",
+		"len(files)":                          float64(2),
+		"files[-1]":                           "b",
+		"meta.size > 5 and meta['size'] < 20": true,
+		"1 < n <= 7":                          true,
+		"'a' in files and 'z' not in files":   true,
+		"'big' if n > 5 else 'small'":         "big",
+		"meta['missing'] if 'missing' in meta else None": nil,
+		"None or 'default'":      "default",
+		"max(files)":             "b",
+		"[n, {'k': True}]":       []interface{}{float64(7), map[string]interface{}{"k": true}},
+		"int('42') + round(2.5)": float64(44),
+	}
+	for expr, want := range tests {
+		got, err := evalExpression(expr, state)
+		if err != nil {
+			t.Errorf("%s: %v", expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %#v, want %#v", expr, got, want)
+		}
+	}
+
+	for expr, want := range map[string]string{
+		"missing":      `name "missing" is not defined`,
+		"n +":          "unexpected end",
+		"name - 1":     "unsupported operand",
+		"open('x')":    `unsupported function "open"`,
+		"meta['nope']": "no key",
+		"n / 0":        "division by zero",
+		"(n":           `expected ")"`,
+	} {
+		if _, err := evalExpression(expr, state); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", expr, err, want)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// schemaViolation is one way run input fails the flow's input schema. Path
+// is a JSONPath to the offending value.
+type schemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// inputValidator checks run input against a flow's input schema. It covers
+// the JSON Schema keywords input schemas use: type, enum, const, the object,
+// array, string and number constraints, allOf/anyOf/oneOf/not, if/then/else
+// and "#/..." references. Formats and references it cannot resolve are not
+// checked; Flows still validates the input when the run starts.
+type inputValidator struct {
+	root       interface{}
+	violations []schemaViolation
+	// depth bounds $ref expansion, for schemas that refer to themselves.
+	depth int
+}
+
+// validateInput returns the ways input fails schema, in document order.
+func validateInput(schema map[string]interface{}, input interface{}) []schemaViolation {
+	v := &inputValidator{root: schema}
+	v.validate("$", schema, input)
+	return v.violations
+}
+
+func (v *inputValidator) report(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, schemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value satisfies schema, without recording why not.
+func (v *inputValidator) matches(schema, value interface{}) bool {
+	sub := &inputValidator{root: v.root, depth: v.depth}
+	sub.validate("$", schema, value)
+	return len(sub.violations) == 0
+}
+
+// resolve follows a schema's local "$ref", returning nil when it cannot.
+func (v *inputValidator) resolve(schema map[string]interface{}) map[string]interface{} {
+	for {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		if !strings.HasPrefix(ref, "#") || v.depth > 32 {
+			return nil
+		}
+		target, err := resolvePointer(v.root, ref[1:])
+		if err != nil {
+			return nil
+		}
+		next, ok := target.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		schema = next
+		v.depth++
+	}
+}
+
+func (v *inputValidator) validate(path string, raw, value interface{}) {
+	var schema map[string]interface{}
+	switch s := raw.(type) {
+	case bool:
+		if !s {
+			v.report(path, "no value is allowed here")
+		}
+		return
+	case map[string]interface{}:
+		depth := v.depth
+		defer func() { v.depth = depth }()
+		if schema = v.resolve(s); schema == nil {
+			return
+		}
+	default:
+		return
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.report(path, "expected %s, got %s", describeTypes(t), valueType(value))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		v.report(path, "must be one of %s", formatChoices(enum))
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		v.report(path, "must be %s", compactJSON(c))
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(path, schema, value)
+	case []interface{}:
+		v.validateArray(path, schema, value)
+	case string:
+		v.validateString(path, schema, value)
+	case float64:
+		v.validateNumber(path, schema, value)
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.validate(path, sub, value)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.matches(sub, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.report(path, "does not match any of the allowed forms (anyOf)")
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		n := 0
+		for _, sub := range oneOf {
+			if v.matches(sub, value) {
+				n++
+			}
+		}
+		if n != 1 {
+			v.report(path, "matches %d of the oneOf forms, not exactly one", n)
+		}
+	}
+	if not, ok := schema["not"]; ok && v.matches(not, value) {
+		v.report(path, "matches a form that is not allowed (not)")
+	}
+	if cond, ok := schema["if"]; ok {
+		branch := "else"
+		if v.matches(cond, value) {
+			branch = "then"
+		}
+		if sub, ok := schema[branch]; ok {
+			v.validate(path, sub, value)
+		}
+	}
+}
+
+func (v *inputValidator) validateObject(path string, schema, obj map[string]interface{}) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, item := range required {
+			if name, ok := item.(string); ok {
+				if _, present := obj[name]; !present {
+					v.report(childPath(path, name), "required property is missing")
+				}
+			}
+		}
+	}
+	if n, ok := schema["minProperties"].(float64); ok && float64(len(obj)) < n {
+		v.report(path, "must have at least %v properties", n)
+	}
+	if n, ok := schema["maxProperties"].(float64); ok && float64(len(obj)) > n {
+		v.report(path, "must have at most %v properties", n)
+	}
+
+	props, _ := schema["properties"].(map[string]interface{})
+	patterns, _ := schema["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+	for _, name := range sortedKeys(obj) {
+		child := childPath(path, name)
+		matched := false
+		if sub, ok := props[name]; ok {
+			matched = true
+			v.validate(child, sub, obj[name])
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				matched = true
+				v.validate(child, patterns[pattern], obj[name])
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.report(child, "property is not allowed")
+		} else {
+			v.validate(child, additional, obj[name])
+		}
+	}
+}
+
+func (v *inputValidator) validateArray(path string, schema map[string]interface{}, list []interface{}) {
+	if n, ok := schema["minItems"].(float64); ok && float64(len(list)) < n {
+		v.report(path, "must have at least %v items", n)
+	}
+	if n, ok := schema["maxItems"].(float64); ok && float64(len(list)) > n {
+		v.report(path, "must have at most %v items", n)
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range list {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(list[i], list[j]) {
+					v.report(fmt.Sprintf("%s[%d]", path, i), "duplicates item %d", j)
+				}
+			}
+		}
+	}
+	switch items := schema["items"].(type) {
+	case []interface{}:
+		for i, item := range list {
+			if i < len(items) {
+				v.validate(fmt.Sprintf("%s[%d]", path, i), items[i], item)
+			} else if extra, ok := schema["additionalItems"]; ok {
+				v.validate(fmt.Sprintf("%s[%d]", path, i), extra, item)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range list {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), items, item)
+		}
+	}
+}
+
+func (v *inputValidator) validateString(path string, schema map[string]interface{}, s string) {
+	n := float64(utf8.RuneCountInString(s))
+	if min, ok := schema["minLength"].(float64); ok && n < min {
+		v.report(path, "must be at least %v characters long", min)
+	}
+	if max, ok := schema["maxLength"].(float64); ok && n > max {
+		v.report(path, "must be at most %v characters long", max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(s) {
+			v.report(path, "does not match pattern %q", pattern)
+		}
+	}
+}
+
+func (v *inputValidator) validateNumber(path string, schema map[string]interface{}, n float64) {
+	if min, ok := schema["minimum"].(float64); ok && n < min {
+		v.report(path, "must be at least %v", min)
+	}
+	if max, ok := schema["maximum"].(float64); ok && n > max {
+		v.report(path, "must be at most %v", max)
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && n <= min {
+		v.report(path, "must be greater than %v", min)
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && n >= max {
+		v.report(path, "must be less than %v", max)
+	}
+	if m, ok := schema["multipleOf"].(float64); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.report(path, "must be a multiple of %v", m)
+		}
+	}
+}
+
+// matchesType reports whether value has the type (or one of the types) t
+// names. A whole number is an integer.
+func matchesType(t, value interface{}) bool {
+	var names []interface{}
+	switch t := t.(type) {
+	case string:
+		names = []interface{}{t}
+	case []interface{}:
+		names = t
+	default:
+		return true
+	}
+	for _, name := range names {
+		switch name {
+		case valueType(value):
+			return true
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valueType names value's JSON Schema type, telling integers from numbers.
+func valueType(value interface{}) string {
+	if n, ok := value.(float64); ok && n == math.Trunc(n) && !math.IsInf(n, 0) {
+		return "integer"
+	}
+	return jsonType(value)
+}
+
+// describeTypes renders a schema's type keyword for messages.
+func describeTypes(t interface{}) string {
+	if list, ok := t.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, item := range list {
+			names = append(names, fmt.Sprint(item))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// containsValue reports whether list holds value.
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatChoices renders enum values for messages.
+func formatChoices(list []interface{}) string {
+	parts := make([]string, 0, len(list))
+	for _, item := range list {
+		parts = append(parts, compactJSON(item))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compactJSON renders a value as compact JSON, in full: unlike plan output,
+// a shortened choice or default would be ambiguous.
+func compactJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// plainName matches member names that need no brackets in a JSONPath.
+var plainName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// childPath appends a member name to a JSONPath.
+func childPath(path, name string) string {
+	if plainName.MatchString(name) {
+		return path + "." + name
+	}
+	return path + "['" + strings.ReplaceAll(name, "'", `\'`) + "']"
+}
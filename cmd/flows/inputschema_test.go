@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testInputSchema = `{
+	"type": "object",
+	"required": ["source", "mode"],
+	"additionalProperties": false,
+	"properties": {
+		"source": {"$ref": "#/definitions/location"},
+		"destinations": {"type": "array", "items": {"$ref": "#/definitions/location"}, "minItems": 1},
+		"mode": {"enum": ["copy", "move"]},
+		"retries": {"type": "integer", "minimum": 0, "maximum": 5},
+		"label": {"type": ["string", "null"], "maxLength": 8},
+		"notify": {"anyOf": [{"type": "boolean"}, {"type": "string", "pattern": "@"}]},
+		"odd name": {"type": "string"}
+	},
+	"definitions": {
+		"location": {
+			"type": "object",
+			"required": ["collection", "path"],
+			"properties": {
+				"collection": {"type": "string", "pattern": "^[0-9a-f-]{36}$"},
+				"path": {"type": "string", "minLength": 1}
+			}
+		}
+	}
+}`
+
+// TestValidateInput checks violations and their JSONPaths.
+func TestValidateInput(t *testing.T) {
+	schema := decodeJSON(t, testInputSchema)
+
+	valid := decodeJSON(t, `{
+		"source": {"collection": "6c54cade-bde5-45c1-bdea-f4bd71dba2cc", "path": "/data"},
+		"mode": "copy", "retries": 3, "label": null, "notify": "me@example.org"
+	}`)
+	if got := validateInput(schema, valid); len(got) != 0 {
+		t.Errorf("valid input: violations = %v", got)
+	}
+
+	invalid := decodeJSON(t, `{
+		"source": {"collection": "lab", "path": ""},
+		"destinations": [],
+		"mode": "sync",
+		"retries": 2.5,
+		"label": "much too long",
+		"notify": 3,
+		"odd name": 1,
+		"extra": true
+	}`)
+	want := []schemaViolation{
+		{Path: "$.destinations", Message: "must have at least 1 items"},
+		{Path: "$.extra", Message: "property is not allowed"},
+		{Path: "$.label", Message: "must be at most 8 characters long"},
+		{Path: "$.mode", Message: `must be one of "copy", "move"`},
+		{Path: "$.notify", Message: "does not match any of the allowed forms (anyOf)"},
+		{Path: "$['odd name']", Message: "expected string, got integer"},
+		{Path: "$.retries", Message: "expected integer, got number"},
+		{Path: "$.source.collection", Message: `does not match pattern "^[0-9a-f-]{36}$"`},
+		{Path: "$.source.path", Message: "must be at least 1 characters long"},
+	}
+	if got := validateInput(schema, invalid); !reflect.DeepEqual(got, want) {
+		t.Errorf("violations =\n%v\nwant\n%v", got, want)
+	}
+
+	missing := validateInput(schema, decodeJSON(t, `{"source": {"path": "/"}}`))
+	if len(missing) != 2 || missing[0].Path != "$.mode" || missing[1].Path != "$.source.collection" {
+		t.Errorf("missing properties: violations = %v", missing)
+	}
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathStep is one step of a parsed JSONPath expression. Exactly one of
+// Name, Index (with IsIndex), Wildcard or Filter describes it; Descend marks
+// a ".." step that matches at any depth.
+type jsonPathStep struct {
+	Name     string
+	Index    int
+	IsIndex  bool
+	Wildcard bool
+	Filter   string
+	Descend  bool
+}
+
+// parseJSONPath parses the JSONPath subset flow definitions use: "$" followed
+// by .name, ['name'], [N], [*], .* and ..name steps. Filter expressions
+// ([?(...)]) are accepted but kept as opaque text.
+func parseJSONPath(expr string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath %q must start with \"$\"", expr)
+	}
+	var steps []jsonPathStep
+	i := 1
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			step := jsonPathStep{}
+			i++
+			if i < len(expr) && expr[i] == '.' {
+				step.Descend = true
+				i++
+			}
+			if i < len(expr) && expr[i] == '[' {
+				if !step.Descend {
+					return nil, fmt.Errorf("JSONPath %q: unexpected \"[\" after \".\" at offset %d", expr, i)
+				}
+				continue
+			}
+			end := i
+			for end < len(expr) && expr[end] != '.' && expr[end] != '[' {
+				end++
+			}
+			name := expr[i:end]
+			switch {
+			case name == "":
+				return nil, fmt.Errorf("JSONPath %q: empty name at offset %d", expr, i)
+			case name == "*":
+				step.Wildcard = true
+			default:
+				step.Name = name
+			}
+			steps = append(steps, step)
+			i = end
+		case '[':
+			step, next, err := parseJSONPathBracket(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			if i >= 2 && expr[i-2:i] == ".." {
+				step.Descend = true
+			}
+			steps = append(steps, step)
+			i = next
+		default:
+			return nil, fmt.Errorf("JSONPath %q: unexpected %q at offset %d", expr, expr[i], i)
+		}
+	}
+	return steps, nil
+}
+
+// parseJSONPathBracket parses the bracket step starting at expr[start] and
+// returns it with the offset just past its closing bracket.
+func parseJSONPathBracket(expr string, start int) (jsonPathStep, int, error) {
+	i := start + 1
+	if i >= len(expr) {
+		return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: unterminated \"[\"", expr)
+	}
+	switch c := expr[i]; {
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(expr[i+1:], c)
+		if end < 0 {
+			return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: unterminated quoted name", expr)
+		}
+		closing := i + 1 + end + 1
+		if closing >= len(expr) || expr[closing] != ']' {
+			return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: expected \"]\" at offset %d", expr, closing)
+		}
+		return jsonPathStep{Name: expr[i+1 : i+1+end]}, closing + 1, nil
+	case c == '?':
+		depth := 0
+		for j := i; j < len(expr); j++ {
+			switch expr[j] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case ']':
+				if depth == 0 {
+					return jsonPathStep{Filter: expr[i:j]}, j + 1, nil
+				}
+			}
+		}
+		return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: unterminated filter expression", expr)
+	}
+
+	end := strings.IndexByte(expr[i:], ']')
+	if end < 0 {
+		return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: unterminated \"[\"", expr)
+	}
+	inner := strings.TrimSpace(expr[i : i+end])
+	if inner == "*" {
+		return jsonPathStep{Wildcard: true}, i + end + 1, nil
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return jsonPathStep{}, 0, fmt.Errorf("JSONPath %q: invalid index %q", expr, inner)
+	}
+	return jsonPathStep{Index: n, IsIndex: true}, i + end + 1, nil
+}
+
+// lookupJSONPath evaluates expr against doc. Paths of names and indexes
+// select one value, and found is false when it is missing; paths with
+// wildcards or ".." return the list of matches. Filters are not evaluated.
+func lookupJSONPath(expr string, doc interface{}) (v interface{}, found bool, err error) {
+	steps, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, false, err
+	}
+	multi := false
+	for _, step := range steps {
+		if step.Filter != "" {
+			return nil, false, fmt.Errorf("JSONPath %q: filter expressions are not supported here", expr)
+		}
+		multi = multi || step.Wildcard || step.Descend
+	}
+
+	matches := []interface{}{doc}
+	for _, step := range steps {
+		var next []interface{}
+		for _, m := range matches {
+			next = append(next, applyJSONPathStep(step, m)...)
+		}
+		matches = next
+	}
+	if multi {
+		if matches == nil {
+			matches = []interface{}{}
+		}
+		return matches, true, nil
+	}
+	if len(matches) == 0 {
+		return nil, false, nil
+	}
+	return matches[0], true, nil
+}
+
+// applyJSONPathStep returns the values one step selects from v.
+func applyJSONPathStep(step jsonPathStep, v interface{}) []interface{} {
+	var out []interface{}
+	switch node := v.(type) {
+	case map[string]interface{}:
+		switch {
+		case step.Wildcard:
+			for _, k := range sortedKeys(node) {
+				out = append(out, node[k])
+			}
+		case step.Name != "":
+			if item, ok := node[step.Name]; ok {
+				out = append(out, item)
+			}
+		}
+	case []interface{}:
+		switch {
+		case step.Wildcard:
+			out = append(out, node...)
+		case step.IsIndex:
+			i := step.Index
+			if i < 0 {
+				i += len(node)
+			}
+			if i >= 0 && i < len(node) {
+				out = append(out, node[i])
+			}
+		}
+	}
+	if step.Descend {
+		children := []interface{}{}
+		switch node := v.(type) {
+		case map[string]interface{}:
+			for _, k := range sortedKeys(node) {
+				children = append(children, node[k])
+			}
+		case []interface{}:
+			children = node
+		}
+		for _, child := range children {
+			out = append(out, applyJSONPathStep(step, child)...)
+		}
+	}
+	return out
+}
+
+// setJSONPath stores value at expr in doc and returns the updated document.
+// "$" replaces the document, which then must be an object. Other paths may
+// only use names and existing array indexes; missing objects are created.
+func setJSONPath(doc map[string]interface{}, expr string, value interface{}) (map[string]interface{}, error) {
+	steps, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("ResultPath %q needs an object result, not %s", expr, jsonType(value))
+		}
+		return obj, nil
+	}
+
+	var cur interface{} = doc
+	for i, step := range steps {
+		last := i == len(steps)-1
+		switch {
+		case step.Name != "" && !step.Descend:
+			obj, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("JSONPath %q: cannot set %q inside a %s", expr, step.Name, jsonType(cur))
+			}
+			if last {
+				obj[step.Name] = value
+				break
+			}
+			if _, ok := obj[step.Name].(map[string]interface{}); !ok {
+				if _, isList := obj[step.Name].([]interface{}); !isList || !steps[i+1].IsIndex {
+					obj[step.Name] = map[string]interface{}{}
+				}
+			}
+			cur = obj[step.Name]
+		case step.IsIndex && !step.Descend:
+			list, ok := cur.([]interface{})
+			idx := step.Index
+			if ok && idx < 0 {
+				idx += len(list)
+			}
+			if !ok || idx < 0 || idx >= len(list) {
+				return nil, fmt.Errorf("JSONPath %q: index %d is out of range", expr, step.Index)
+			}
+			if last {
+				list[idx] = value
+				break
+			}
+			if _, ok := list[idx].(map[string]interface{}); !ok {
+				list[idx] = map[string]interface{}{}
+			}
+			cur = list[idx]
+		default:
+			return nil, fmt.Errorf("JSONPath %q: only names and indexes can be assigned to", expr)
+		}
+	}
+	return doc, nil
+}
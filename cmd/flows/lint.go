@@ -0,0 +1,728 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Lint finding severities.
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+// lintFinding is one problem found by the offline linter. Pointer is a JSON
+// pointer into File; Line is 0 when it is unknown.
+type lintFinding struct {
+	File     string `json:"file"`
+	Pointer  string `json:"pointer"`
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// flowStateTypes are the state types Globus Flows accepts.
+var flowStateTypes = map[string]bool{
+	"Action": true, "Choice": true, "ExpressionEval": true, "Fail": true, "Pass": true, "Wait": true,
+}
+
+// choiceOperandTypes maps each Choice comparison operator to the JSON type of
+// its operand; "path" operands are JSONPath strings.
+var choiceOperandTypes = map[string]string{
+	"StringEquals": "string", "StringLessThan": "string", "StringGreaterThan": "string",
+	"StringLessThanEquals": "string", "StringGreaterThanEquals": "string", "StringMatches": "string",
+
+	"NumericEquals": "number", "NumericLessThan": "number", "NumericGreaterThan": "number",
+	"NumericLessThanEquals": "number", "NumericGreaterThanEquals": "number",
+
+	"BooleanEquals": "boolean",
+
+	"TimestampEquals": "string", "TimestampLessThan": "string", "TimestampGreaterThan": "string",
+	"TimestampLessThanEquals": "string", "TimestampGreaterThanEquals": "string",
+
+	"StringEqualsPath": "path", "StringLessThanPath": "path", "StringGreaterThanPath": "path",
+	"StringLessThanEqualsPath": "path", "StringGreaterThanEqualsPath": "path",
+	"NumericEqualsPath": "path", "NumericLessThanPath": "path", "NumericGreaterThanPath": "path",
+	"NumericLessThanEqualsPath": "path", "NumericGreaterThanEqualsPath": "path",
+	"TimestampEqualsPath": "path", "TimestampLessThanPath": "path", "TimestampGreaterThanPath": "path",
+	"TimestampLessThanEqualsPath": "path", "TimestampGreaterThanEqualsPath": "path",
+	"BooleanEqualsPath": "path",
+
+	"IsNull": "boolean", "IsPresent": "boolean", "IsNumeric": "boolean", "IsString": "boolean",
+	"IsBoolean": "boolean", "IsTimestamp": "boolean",
+}
+
+// knownActionProviders are the action URLs of the Globus-operated action
+// providers, without trailing slashes.
+var knownActionProviders = []string{
+	"https://actions.globus.org/hello_world",
+	"https://actions.globus.org/expression_eval",
+	"https://actions.globus.org/notification/notify",
+	"https://actions.globus.org/search/ingest",
+	"https://actions.globus.org/search/delete",
+	"https://actions.globus.org/datacite/mint/basic_auth",
+	"https://actions.globus.org/transfer/transfer",
+	"https://actions.globus.org/transfer/delete",
+	"https://actions.globus.org/transfer/ls",
+	"https://actions.globus.org/transfer/mkdir",
+	"https://actions.globus.org/transfer/set_permission",
+	"https://transfer.actions.globus.org/transfer",
+	"https://transfer.actions.globus.org/delete",
+	"https://transfer.actions.globus.org/ls",
+	"https://transfer.actions.globus.org/mkdir",
+	"https://transfer.actions.globus.org/set_permission",
+	"https://compute.actions.globus.org",
+	"https://compute.actions.globus.org/v3",
+}
+
+// flowLinter collects findings for one definition and optional input schema.
+type flowLinter struct {
+	def      *flowDocument
+	schema   *flowDocument
+	findings []lintFinding
+}
+
+// lintFlow checks a flow definition (and, when given, its input schema)
+// without contacting Globus Flows. Findings are sorted by file and line.
+func lintFlow(def, schema *flowDocument) []lintFinding {
+	l := &flowLinter{def: def, schema: schema}
+	states := l.lintStates()
+	if states != nil {
+		l.lintGraph(states)
+	}
+	if schema != nil {
+		l.lintSchema("", schema.Data, true)
+		if states != nil {
+			l.lintInputReferences(states)
+		}
+	}
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return l.findings
+}
+
+// report records a finding against doc at pointer.
+func (l *flowLinter) report(doc *flowDocument, severity, pointer, format string, args ...interface{}) {
+	l.findings = append(l.findings, lintFinding{
+		File:     doc.Path,
+		Pointer:  pointer,
+		Line:     doc.lineOf(pointer),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// lintStates checks StartAt and each state on its own, returning the States
+// object (nil when it is missing or malformed).
+func (l *flowLinter) lintStates() map[string]interface{} {
+	d := l.def.Data
+	states, ok := d["States"].(map[string]interface{})
+	if !ok || len(states) == 0 {
+		l.report(l.def, lintError, "/States", "definition must have a non-empty States object")
+		return nil
+	}
+
+	switch startAt, ok := d["StartAt"].(string); {
+	case !ok:
+		l.report(l.def, lintError, "/StartAt", "definition must have a StartAt state name")
+	case states[startAt] == nil:
+		l.report(l.def, lintError, "/StartAt", "StartAt names state %q, which does not exist", startAt)
+	}
+
+	for _, name := range sortedKeys(states) {
+		ptr := "/States/" + escapePointer(name)
+		state, ok := states[name].(map[string]interface{})
+		if !ok {
+			l.report(l.def, lintError, ptr, "state %q must be an object", name)
+			continue
+		}
+		l.lintState(name, ptr, state, states)
+	}
+	return states
+}
+
+// lintState checks one state's type, transitions and JSONPath fields.
+func (l *flowLinter) lintState(name, ptr string, state, states map[string]interface{}) {
+	typ, _ := state["Type"].(string)
+	if !flowStateTypes[typ] {
+		if typ == "" {
+			l.report(l.def, lintError, ptr+"/Type", "state %q has no Type", name)
+		} else {
+			l.report(l.def, lintError, ptr+"/Type", "state %q has unknown Type %q", name, typ)
+		}
+		return
+	}
+
+	next, hasNext := state["Next"]
+	end, _ := state["End"].(bool)
+	switch typ {
+	case "Choice":
+		l.lintChoice(ptr, state, states)
+		if hasNext || state["End"] != nil {
+			l.report(l.def, lintError, ptr, "Choice state %q cannot have Next or End; use Choices and Default", name)
+		}
+	case "Fail":
+		if hasNext || state["End"] != nil {
+			l.report(l.def, lintError, ptr, "Fail state %q cannot have Next or End", name)
+		}
+	default:
+		switch {
+		case hasNext && end:
+			l.report(l.def, lintError, ptr, "state %q has both Next and End", name)
+		case hasNext:
+			l.lintTransition(ptr+"/Next", next, states)
+		case !end:
+			l.report(l.def, lintError, ptr, "state %q must have Next or \"End\": true", name)
+		}
+	}
+
+	if typ == "Action" {
+		l.lintActionURL(ptr, state)
+		if catches, ok := state["Catch"].([]interface{}); ok {
+			for i, c := range catches {
+				cptr := fmt.Sprintf("%s/Catch/%d", ptr, i)
+				catch, ok := c.(map[string]interface{})
+				if !ok {
+					l.report(l.def, lintError, cptr, "Catch entries must be objects")
+					continue
+				}
+				if _, ok := catch["ErrorEquals"].([]interface{}); !ok {
+					l.report(l.def, lintError, cptr+"/ErrorEquals", "Catch entry must list ErrorEquals")
+				}
+				l.lintTransition(cptr+"/Next", catch["Next"], states)
+				l.lintPathField(cptr, catch, "ResultPath")
+			}
+		} else if state["Catch"] != nil {
+			l.report(l.def, lintError, ptr+"/Catch", "Catch must be an array")
+		}
+	}
+
+	for _, field := range []string{"ResultPath", "InputPath", "SecondsPath", "TimestampPath"} {
+		l.lintPathField(ptr, state, field)
+	}
+	if params, ok := state["Parameters"]; ok {
+		l.lintParameters(ptr+"/Parameters", params)
+	}
+}
+
+// lintTransition checks that a Next-style value names an existing state.
+func (l *flowLinter) lintTransition(ptr string, next interface{}, states map[string]interface{}) {
+	name, ok := next.(string)
+	switch {
+	case !ok:
+		l.report(l.def, lintError, ptr, "must be a state name")
+	case states[name] == nil:
+		l.report(l.def, lintError, ptr, "state %q does not exist", name)
+	}
+}
+
+// lintActionURL checks that an Action state has an ActionUrl and warns when it
+// is not a Globus-operated action provider.
+func (l *flowLinter) lintActionURL(ptr string, state map[string]interface{}) {
+	raw, ok := state["ActionUrl"].(string)
+	if !ok || raw == "" {
+		l.report(l.def, lintError, ptr+"/ActionUrl", "Action state must have an ActionUrl")
+		return
+	}
+	if !isKnownActionProvider(raw) {
+		l.report(l.def, lintWarning, ptr+"/ActionUrl", "ActionUrl %q is not a known Globus action provider", raw)
+	}
+}
+
+// isKnownActionProvider reports whether raw is a Globus-operated action
+// provider. Flows run as actions (https://flows.globus.org/flows/ID and
+// https://ID.flows.globus.org) count as known.
+func isKnownActionProvider(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Host)
+	path := strings.TrimSuffix(u.Path, "/")
+	if strings.HasSuffix(host, ".flows.globus.org") || (host == "flows.globus.org" && strings.HasPrefix(path, "/flows/")) {
+		return true
+	}
+	normalized := "https://" + host + path
+	for _, known := range knownActionProviders {
+		if normalized == known {
+			return true
+		}
+	}
+	return false
+}
+
+// lintChoice checks a Choice state's rules and Default.
+func (l *flowLinter) lintChoice(ptr string, state, states map[string]interface{}) {
+	choices, ok := state["Choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		l.report(l.def, lintError, ptr+"/Choices", "Choice state must have a non-empty Choices array")
+	}
+	for i, rule := range choices {
+		rptr := fmt.Sprintf("%s/Choices/%d", ptr, i)
+		l.lintChoiceRule(rptr, rule, true)
+		if r, ok := rule.(map[string]interface{}); ok {
+			l.lintTransition(rptr+"/Next", r["Next"], states)
+		}
+	}
+	if def, ok := state["Default"]; ok {
+		l.lintTransition(ptr+"/Default", def, states)
+	} else {
+		l.report(l.def, lintWarning, ptr, "Choice state has no Default; a run fails when no rule matches")
+	}
+}
+
+// lintChoiceRule checks one Choice rule. Top-level rules carry Next; rules
+// nested in And, Or and Not must not.
+func (l *flowLinter) lintChoiceRule(ptr string, raw interface{}, top bool) {
+	rule, ok := raw.(map[string]interface{})
+	if !ok {
+		l.report(l.def, lintError, ptr, "choice rule must be an object")
+		return
+	}
+	if _, ok := rule["Next"]; ok && !top {
+		l.report(l.def, lintError, ptr+"/Next", "nested choice rules cannot have Next")
+	}
+
+	var ops []string
+	for _, key := range sortedKeys(rule) {
+		switch key {
+		case "Next", "Variable", "Comment":
+		case "And", "Or", "Not":
+			ops = append(ops, key)
+		default:
+			if _, ok := choiceOperandTypes[key]; !ok {
+				l.report(l.def, lintError, ptr+"/"+escapePointer(key), "unknown choice rule field %q", key)
+				continue
+			}
+			ops = append(ops, key)
+		}
+	}
+	if len(ops) != 1 {
+		if len(ops) == 0 {
+			l.report(l.def, lintError, ptr, "choice rule has no comparison operator")
+		} else {
+			l.report(l.def, lintError, ptr, "choice rule has more than one operator (%s)", strings.Join(ops, ", "))
+		}
+		return
+	}
+
+	op := ops[0]
+	optr := ptr + "/" + op
+	switch op {
+	case "And", "Or":
+		list, ok := rule[op].([]interface{})
+		if !ok || len(list) == 0 {
+			l.report(l.def, lintError, optr, "%s must be a non-empty array of rules", op)
+			return
+		}
+		for i, sub := range list {
+			l.lintChoiceRule(fmt.Sprintf("%s/%d", optr, i), sub, false)
+		}
+		if _, ok := rule["Variable"]; ok {
+			l.report(l.def, lintError, ptr+"/Variable", "%s rules cannot have a Variable", op)
+		}
+		return
+	case "Not":
+		l.lintChoiceRule(optr, rule[op], false)
+		if _, ok := rule["Variable"]; ok {
+			l.report(l.def, lintError, ptr+"/Variable", "Not rules cannot have a Variable")
+		}
+		return
+	}
+
+	if _, ok := rule["Variable"]; !ok {
+		l.report(l.def, lintError, ptr, "choice rule with %s must have a Variable", op)
+	} else {
+		l.lintPathField(ptr, rule, "Variable")
+	}
+	switch want := choiceOperandTypes[op]; want {
+	case "path":
+		l.lintPathField(ptr, rule, op)
+	default:
+		if got := jsonType(rule[op]); got != want {
+			l.report(l.def, lintError, optr, "%s takes a %s, not a %s", op, want, got)
+		}
+	}
+}
+
+// lintPathField checks that obj[field], when present, is a valid JSONPath.
+func (l *flowLinter) lintPathField(ptr string, obj map[string]interface{}, field string) {
+	raw, ok := obj[field]
+	if !ok {
+		return
+	}
+	fptr := ptr + "/" + escapePointer(field)
+	expr, ok := raw.(string)
+	if !ok {
+		l.report(l.def, lintError, fptr, "%s must be a JSONPath string", field)
+		return
+	}
+	if _, err := parseJSONPath(expr); err != nil {
+		l.report(l.def, lintError, fptr, "%v", err)
+	}
+}
+
+// lintParameters checks the JSONPath of every "key.$" member in a Parameters
+// tree. "key.=" members are expressions and are not parsed.
+func (l *flowLinter) lintParameters(ptr string, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			if strings.HasSuffix(key, ".$") {
+				l.lintPathField(ptr, v, key)
+				continue
+			}
+			l.lintParameters(ptr+"/"+escapePointer(key), v[key])
+		}
+	case []interface{}:
+		for i, item := range v {
+			l.lintParameters(fmt.Sprintf("%s/%d", ptr, i), item)
+		}
+	}
+}
+
+// stateSuccessors returns the states a state can move to.
+func stateSuccessors(state map[string]interface{}) []string {
+	var next []string
+	add := func(v interface{}) {
+		if name, ok := v.(string); ok {
+			next = append(next, name)
+		}
+	}
+	add(state["Next"])
+	add(state["Default"])
+	for _, key := range []string{"Choices", "Catch"} {
+		list, _ := state[key].([]interface{})
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok {
+				add(m["Next"])
+			}
+		}
+	}
+	return next
+}
+
+// isTerminalState reports whether a run ends in state.
+func isTerminalState(state map[string]interface{}) bool {
+	end, _ := state["End"].(bool)
+	return end || state["Type"] == "Fail"
+}
+
+// lintGraph reports states StartAt cannot reach and reachable states from
+// which no path leads to an End or Fail state.
+func (l *flowLinter) lintGraph(states map[string]interface{}) {
+	startAt, _ := l.def.Data["StartAt"].(string)
+	if states[startAt] == nil {
+		return
+	}
+
+	succ := make(map[string][]string, len(states))
+	pred := make(map[string][]string, len(states))
+	var terminal []string
+	for name, raw := range states {
+		state, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, next := range stateSuccessors(state) {
+			if states[next] != nil {
+				succ[name] = append(succ[name], next)
+				pred[next] = append(pred[next], name)
+			}
+		}
+		if isTerminalState(state) {
+			terminal = append(terminal, name)
+		}
+	}
+
+	reachable := walkStates([]string{startAt}, succ)
+	canEnd := walkStates(terminal, pred)
+	for _, name := range sortedKeys(states) {
+		ptr := "/States/" + escapePointer(name)
+		switch {
+		case !reachable[name]:
+			l.report(l.def, lintError, ptr, "state %q is unreachable from StartAt", name)
+		case !canEnd[name]:
+			l.report(l.def, lintError, ptr, "no path from state %q reaches an End or Fail state", name)
+		}
+	}
+}
+
+// walkStates returns every state reachable from start along edges.
+func walkStates(start []string, edges map[string][]string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		queue = append(queue, edges[name]...)
+	}
+	return seen
+}
+
+// jsonSchemaTypes are the type names JSON Schema defines.
+var jsonSchemaTypes = map[string]bool{
+	"array": true, "boolean": true, "integer": true, "null": true, "number": true, "object": true, "string": true,
+}
+
+// lintSchema checks the structure of an input schema: the keywords the Flows
+// web app and the local validator rely on must have the right shapes.
+func (l *flowLinter) lintSchema(ptr string, raw interface{}, root bool) {
+	schema, ok := raw.(map[string]interface{})
+	if !ok {
+		if _, isBool := raw.(bool); !isBool {
+			l.report(l.schema, lintError, ptr, "schema must be an object")
+		}
+		return
+	}
+
+	switch t := schema["type"].(type) {
+	case nil:
+	case string:
+		if !jsonSchemaTypes[t] {
+			l.report(l.schema, lintError, ptr+"/type", "unknown type %q", t)
+		} else if root && t != "object" {
+			l.report(l.schema, lintError, ptr+"/type", "flow input must be an object, not %s", t)
+		}
+	case []interface{}:
+		for i, item := range t {
+			if name, _ := item.(string); !jsonSchemaTypes[name] {
+				l.report(l.schema, lintError, fmt.Sprintf("%s/type/%d", ptr, i), "unknown type %v", item)
+			}
+		}
+	default:
+		l.report(l.schema, lintError, ptr+"/type", "type must be a string or an array of strings")
+	}
+
+	props, hasProps := schema["properties"].(map[string]interface{})
+	if schema["properties"] != nil && !hasProps {
+		l.report(l.schema, lintError, ptr+"/properties", "properties must be an object")
+	}
+	for _, name := range sortedKeys(props) {
+		l.lintSchema(ptr+"/properties/"+escapePointer(name), props[name], false)
+	}
+
+	if req, ok := schema["required"]; ok {
+		list, ok := req.([]interface{})
+		if !ok {
+			l.report(l.schema, lintError, ptr+"/required", "required must be an array of property names")
+		}
+		for i, item := range list {
+			name, ok := item.(string)
+			switch {
+			case !ok:
+				l.report(l.schema, lintError, fmt.Sprintf("%s/required/%d", ptr, i), "required entries must be strings")
+			case hasProps && props[name] == nil:
+				l.report(l.schema, lintWarning, fmt.Sprintf("%s/required/%d", ptr, i), "required property %q is not defined in properties", name)
+			}
+		}
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		if list, ok := enum.([]interface{}); !ok || len(list) == 0 {
+			l.report(l.schema, lintError, ptr+"/enum", "enum must be a non-empty array")
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		if list, ok := items.([]interface{}); ok {
+			for i, item := range list {
+				l.lintSchema(fmt.Sprintf("%s/items/%d", ptr, i), item, false)
+			}
+		} else {
+			l.lintSchema(ptr+"/items", items, false)
+		}
+	}
+	if extra, ok := schema["additionalProperties"]; ok {
+		l.lintSchema(ptr+"/additionalProperties", extra, false)
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if sub, ok := schema[key]; ok {
+			list, ok := sub.([]interface{})
+			if !ok || len(list) == 0 {
+				l.report(l.schema, lintError, ptr+"/"+key, "%s must be a non-empty array of schemas", key)
+				continue
+			}
+			for i, item := range list {
+				l.lintSchema(fmt.Sprintf("%s/%s/%d", ptr, key, i), item, false)
+			}
+		}
+	}
+	if not, ok := schema["not"]; ok {
+		l.lintSchema(ptr+"/not", not, false)
+	}
+}
+
+// lintInputReferences warns about JSONPath references to top-level names that
+// neither the input schema defines nor any state writes with ResultPath. The
+// check is skipped when the schema lists no properties or a state replaces the
+// whole run state, since then any name may be present.
+func (l *flowLinter) lintInputReferences(states map[string]interface{}) {
+	props, ok := l.schema.Data["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	known := make(map[string]bool, len(props))
+	for name := range props {
+		known[name] = true
+	}
+
+	var refs []inputReference
+	for _, name := range sortedKeys(states) {
+		state, ok := states[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ptr := "/States/" + escapePointer(name)
+		written := []string{stringField(state, "ResultPath")}
+		catches, _ := state["Catch"].([]interface{})
+		for _, c := range catches {
+			if catch, ok := c.(map[string]interface{}); ok {
+				written = append(written, stringField(catch, "ResultPath"))
+			}
+		}
+		for _, path := range written {
+			if path == "" {
+				continue
+			}
+			top, whole := topLevelName(path)
+			if whole {
+				return
+			}
+			known[top] = true
+		}
+		refs = append(refs, stateInputReferences(ptr, state)...)
+	}
+
+	for _, ref := range refs {
+		if top, whole := topLevelName(ref.path); !whole && top != "" && !known[top] {
+			l.report(l.def, lintWarning, ref.pointer, "%s reads %q, which the input schema does not define and no state sets", ref.path, top)
+		}
+	}
+}
+
+// inputReference is a JSONPath in a definition that reads the run state.
+type inputReference struct {
+	pointer string
+	path    string
+}
+
+// stateInputReferences lists the JSONPaths a state reads.
+func stateInputReferences(ptr string, state map[string]interface{}) []inputReference {
+	var refs []inputReference
+	for _, field := range []string{"InputPath", "SecondsPath", "TimestampPath"} {
+		if path := stringField(state, field); path != "" {
+			refs = append(refs, inputReference{ptr + "/" + field, path})
+		}
+	}
+	var walkParams func(p string, v interface{})
+	walkParams = func(p string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, val := range v {
+				kptr := p + "/" + escapePointer(key)
+				if s, ok := val.(string); ok && strings.HasSuffix(key, ".$") {
+					refs = append(refs, inputReference{kptr, s})
+					continue
+				}
+				walkParams(kptr, val)
+			}
+		case []interface{}:
+			for i, item := range v {
+				walkParams(fmt.Sprintf("%s/%d", p, i), item)
+			}
+		}
+	}
+	walkParams(ptr+"/Parameters", state["Parameters"])
+
+	var walkRule func(p string, v interface{})
+	walkRule = func(p string, v interface{}) {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, val := range rule {
+			switch {
+			case key == "Variable" || (strings.HasSuffix(key, "Path") && choiceOperandTypes[key] == "path"):
+				if s, ok := val.(string); ok {
+					refs = append(refs, inputReference{p + "/" + key, s})
+				}
+			case key == "And" || key == "Or":
+				list, _ := val.([]interface{})
+				for i, sub := range list {
+					walkRule(fmt.Sprintf("%s/%s/%d", p, key, i), sub)
+				}
+			case key == "Not":
+				walkRule(p+"/Not", val)
+			}
+		}
+	}
+	choices, _ := state["Choices"].([]interface{})
+	for i, rule := range choices {
+		walkRule(fmt.Sprintf("%s/Choices/%d", ptr, i), rule)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].pointer < refs[j].pointer })
+	return refs
+}
+
+// topLevelName returns the first member name a JSONPath selects. whole is
+// true for "$" itself; the name is empty when the first step is not a name.
+func topLevelName(path string) (name string, whole bool) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return "", false
+	}
+	if len(steps) == 0 {
+		return "", true
+	}
+	if steps[0].Descend {
+		return "", false
+	}
+	return steps[0].Name, false
+}
+
+// stringField returns obj[key] when it is a string.
+func stringField(obj map[string]interface{}, key string) string {
+	s, _ := obj[key].(string)
+	return s
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// sortedKeys returns m's keys in order, so findings are deterministic.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDoc writes content to a temp file and loads it as a flow document.
+func writeDoc(t *testing.T, name, content string) *flowDocument {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := loadFlowDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// findingAt returns the finding with the given pointer, or nil.
+func findingAt(findings []lintFinding, pointer string) *lintFinding {
+	for i := range findings {
+		if findings[i].Pointer == pointer {
+			return &findings[i]
+		}
+	}
+	return nil
+}
+
+// TestLintFlowValid checks that a well-formed flow produces no findings.
+func TestLintFlowValid(t *testing.T) {
+	def := writeDoc(t, "flow.json", `{
+  "StartAt": "Transfer",
+  "States": {
+    "Transfer": {
+      "Type": "Action",
+      "ActionUrl": "https://transfer.actions.globus.org/transfer/",
+      "Parameters": {"source_endpoint.$": "$.source.id", "DATA": [{"path.$": "$.source['path']"}]},
+      "ResultPath": "$.TransferResult",
+      "Catch": [{"ErrorEquals": ["States.ALL"], "Next": "Failed", "ResultPath": "$.Error"}],
+      "Next": "Check"
+    },
+    "Check": {
+      "Type": "Choice",
+      "Choices": [{
+        "And": [
+          {"Variable": "$.TransferResult.status", "StringEquals": "SUCCEEDED"},
+          {"Not": {"Variable": "$.TransferResult.details", "IsNull": true}}
+        ],
+        "Next": "Done"
+      }],
+      "Default": "Failed"
+    },
+    "Done": {"Type": "Pass", "End": true},
+    "Failed": {"Type": "Fail", "Cause": "transfer failed"}
+  }
+}`)
+	schema := writeDoc(t, "schema.json", `{"type": "object", "properties": {"source": {"type": "object"}}, "required": ["source"]}`)
+
+	if findings := lintFlow(def, schema); len(findings) != 0 {
+		t.Errorf("findings = %+v", findings)
+	}
+}
+
+// TestLintFlowFindings checks each class of problem and its line number.
+func TestLintFlowFindings(t *testing.T) {
+	def := writeDoc(t, "flow.json", `{
+  "StartAt": "A",
+  "States": {
+    "A": {"Type": "Action", "ActionUrl": "https://example.org/ap", "Parameters": {"x.$": "source"}, "Next": "Pick"},
+    "Pick": {"Type": "Choice", "Choices": [
+      {"Variable": "$.n", "NumericEquals": "1", "StringEquals": "1", "Next": "B"},
+      {"Not": {"Variable": "$.n", "IsPresent": true, "Next": "B"}, "Next": "Spin"}
+    ], "Default": "Nowhere"},
+    "Spin": {"Type": "Pass", "Next": "Spin"},
+    "B": {"Type": "Pass", "ResultPath": "result", "End": true},
+    "Lost": {"Type": "Wait", "Seconds": 5, "End": true},
+    "C": {"Type": "Bogus"}
+  }
+}`)
+
+	findings := lintFlow(def, nil)
+	want := map[string]struct {
+		severity string
+		line     int
+		contains string
+	}{
+		"/States/A/ActionUrl":             {lintWarning, 4, "not a known Globus action provider"},
+		"/States/A/Parameters/x.$":        {lintError, 4, "must start with"},
+		"/States/Pick/Choices/0":          {lintError, 6, "more than one operator"},
+		"/States/Pick/Choices/1/Not/Next": {lintError, 7, "nested choice rules"},
+		"/States/Pick/Default":            {lintError, 8, `"Nowhere" does not exist`},
+		"/States/Spin":                    {lintError, 9, "no path"},
+		"/States/B/ResultPath":            {lintError, 10, "must start with"},
+		"/States/Lost":                    {lintError, 11, "unreachable"},
+		"/States/C/Type":                  {lintError, 12, `unknown Type "Bogus"`},
+		"/States/C":                       {lintError, 12, "unreachable"},
+	}
+	for pointer, w := range want {
+		f := findingAt(findings, pointer)
+		if f == nil {
+			t.Errorf("no finding at %s in %+v", pointer, findings)
+			continue
+		}
+		if f.Severity != w.severity || f.Line != w.line || !strings.Contains(f.Message, w.contains) {
+			t.Errorf("finding at %s = %+v, want %s on line %d containing %q", pointer, *f, w.severity, w.line, w.contains)
+		}
+	}
+	if len(findings) != len(want) {
+		t.Errorf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+}
+
+// TestLintFlowSchema checks input-schema structure and undefined input names.
+func TestLintFlowSchema(t *testing.T) {
+	def := writeDoc(t, "flow.json", `{
+  "StartAt": "A",
+  "States": {
+    "A": {"Type": "Pass", "Parameters": {"a.$": "$.known", "b.$": "$.unknown", "c.$": "$.Saved.x"}, "ResultPath": "$.Saved", "End": true}
+  }
+}`)
+	schema := writeDoc(t, "schema.json", `{
+  "type": "object",
+  "properties": {"known": {"type": "strin"}},
+  "required": ["known", "other"]
+}`)
+
+	findings := lintFlow(def, schema)
+	if f := findingAt(findings, "/States/A/Parameters/b.$"); f == nil || f.Severity != lintWarning {
+		t.Errorf("undefined input name: %+v", findings)
+	}
+	if f := findingAt(findings, "/properties/known/type"); f == nil || f.Severity != lintError || f.Line != 3 {
+		t.Errorf("bad type: %+v", findings)
+	}
+	if f := findingAt(findings, "/required/1"); f == nil || f.Severity != lintWarning {
+		t.Errorf("undefined required: %+v", findings)
+	}
+	if len(findings) != 3 {
+		t.Errorf("findings = %+v", findings)
+	}
+}
+
+// TestParseJSONPath checks accepted and rejected JSONPath expressions.
+func TestParseJSONPath(t *testing.T) {
+	steps, err := parseJSONPath(`$.a['b.c'][2][*].*..d[?(@.x == ']')]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 7 || steps[0].Name != "a" || steps[1].Name != "b.c" || steps[2].Index != 2 ||
+		!steps[3].Wildcard || !steps[4].Wildcard || !steps[5].Descend || steps[5].Name != "d" || steps[6].Filter == "" {
+		t.Errorf("steps = %+v", steps)
+	}
+	if steps, err := parseJSONPath("$"); err != nil || len(steps) != 0 {
+		t.Errorf("$: steps = %+v, err = %v", steps, err)
+	}
+	for _, bad := range []string{"", "a.b", "$.", "$..", "$.a[", "$.a[x]", "$.a['b'", "$a"} {
+		if _, err := parseJSONPath(bad); err == nil {
+			t.Errorf("parseJSONPath(%q) succeeded", bad)
+		}
+	}
+}
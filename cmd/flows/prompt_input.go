@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// inputPrompter builds run input by asking for each required property of an
+// input schema, descending into required objects.
+type inputPrompter struct {
+	in     *bufio.Reader
+	out    io.Writer
+	schema map[string]interface{}
+}
+
+// promptInput fills in the required properties input lacks, reading answers
+// from in and writing questions to out. Each answer is checked against its
+// property's schema and asked again until it passes.
+func promptInput(in io.Reader, out io.Writer, schema, input map[string]interface{}) (map[string]interface{}, error) {
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+	p := &inputPrompter{in: bufio.NewReader(in), out: out, schema: schema}
+	if err := p.fill("$", schema, input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// fill asks for the required properties of obj that schema declares.
+func (p *inputPrompter) fill(path string, schema, obj map[string]interface{}) error {
+	v := &inputValidator{root: p.schema}
+	if schema = v.resolve(schema); schema == nil {
+		return nil
+	}
+	required, _ := schema["required"].([]interface{})
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, item := range required {
+		name, ok := item.(string)
+		if !ok {
+			continue
+		}
+		child := childPath(path, name)
+		sub, _ := props[name].(map[string]interface{})
+		if sub != nil {
+			sub = v.resolve(sub)
+		}
+
+		if promptsNested(sub) {
+			existing, isObj := obj[name].(map[string]interface{})
+			if _, present := obj[name]; present && !isObj {
+				continue
+			}
+			if !isObj {
+				existing = map[string]interface{}{}
+				p.describe(child, sub, "object")
+			}
+			if err := p.fill(child, sub, existing); err != nil {
+				return err
+			}
+			obj[name] = existing
+			continue
+		}
+		if _, present := obj[name]; present {
+			continue
+		}
+		value, err := p.ask(child, sub)
+		if err != nil {
+			return err
+		}
+		obj[name] = value
+	}
+	return nil
+}
+
+// promptsNested reports whether a property is an object whose own
+// properties are asked for one by one rather than as a JSON value.
+func promptsNested(schema map[string]interface{}) bool {
+	if schema == nil || schema["enum"] != nil {
+		return false
+	}
+	props, _ := schema["properties"].(map[string]interface{})
+	return schemaType(schema) == "object" && len(props) > 0
+}
+
+// ask reads one property's value until it satisfies the property's schema.
+func (p *inputPrompter) ask(path string, schema map[string]interface{}) (interface{}, error) {
+	t := schemaType(schema)
+	p.describe(path, schema, t)
+	enum, _ := schema["enum"].([]interface{})
+	def, hasDefault := schema["default"]
+
+	for {
+		fmt.Fprint(p.out, "> ")
+		line, err := p.in.ReadString('\n')
+		if err != nil && line == "" {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("input ended before %s was given", path)
+			}
+			return nil, err
+		}
+		answer := strings.TrimSpace(line)
+
+		var value interface{}
+		switch {
+		case answer == "" && hasDefault:
+			value = def
+		case answer == "":
+			fmt.Fprintln(p.out, "  A value is required.")
+			continue
+		default:
+			if i, err := strconv.Atoi(answer); err == nil && len(enum) > 0 && i >= 1 && i <= len(enum) {
+				value = enum[i-1]
+				break
+			}
+			if value, err = parseAnswer(answer, t, schema); err != nil {
+				fmt.Fprintf(p.out, "  %v\n", err)
+				continue
+			}
+		}
+
+		v := &inputValidator{root: p.schema}
+		v.validate(path, schema, value)
+		if len(v.violations) == 0 {
+			return value, nil
+		}
+		for _, violation := range v.violations {
+			fmt.Fprintf(p.out, "  %s: %s\n", violation.Path, violation.Message)
+		}
+	}
+}
+
+// describe prints a property's path, type, title, description, choices and
+// default ahead of its question.
+func (p *inputPrompter) describe(path string, schema map[string]interface{}, t string) {
+	if t == "" {
+		t = "any"
+	}
+	fmt.Fprintf(p.out, "\n%s (%s)\n", path, t)
+	for _, key := range []string{"title", "description"} {
+		if s := stringField(schema, key); s != "" {
+			fmt.Fprintf(p.out, "  %s\n", s)
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		for i, item := range enum {
+			fmt.Fprintf(p.out, "  %d) %s\n", i+1, compactJSON(item))
+		}
+	}
+	if def, ok := schema["default"]; ok {
+		fmt.Fprintf(p.out, "  default: %s\n", compactJSON(def))
+	}
+}
+
+// schemaType returns the type a schema asks for: its type keyword (the first
+// non-null one of a list), else the type of its enum or const values.
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if name, _ := item.(string); name != "" && name != "null" {
+				return name
+			}
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return jsonType(enum[0])
+	}
+	if c, ok := schema["const"]; ok {
+		return jsonType(c)
+	}
+	return ""
+}
+
+// parseAnswer converts a typed answer to a value of type t. Arrays may be
+// given as JSON or, for lists of strings, comma-separated; objects and
+// untyped values as JSON, falling back to a string for untyped ones.
+func parseAnswer(answer, t string, schema map[string]interface{}) (interface{}, error) {
+	switch t {
+	case "string":
+		return answer, nil
+	case "integer", "number":
+		n, err := strconv.ParseFloat(answer, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", answer)
+		}
+		return n, nil
+	case "boolean":
+		switch strings.ToLower(answer) {
+		case "y", "yes", "true":
+			return true, nil
+		case "n", "no", "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("answer yes or no")
+	case "array":
+		if !strings.HasPrefix(answer, "[") {
+			items, _ := schema["items"].(map[string]interface{})
+			if items == nil || schemaType(items) != "string" {
+				return nil, fmt.Errorf("enter a JSON array")
+			}
+			list := []interface{}{}
+			for _, item := range strings.Split(answer, ",") {
+				list = append(list, strings.TrimSpace(item))
+			}
+			return list, nil
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(answer), &value); err != nil {
+		if t == "" {
+			return answer, nil
+		}
+		return nil, fmt.Errorf("enter a JSON %s", t)
+	}
+	return value, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPromptInput checks questions, retries on bad answers, defaults and
+// enum choices.
+func TestPromptInput(t *testing.T) {
+	schema := decodeJSON(t, `{
+		"type": "object",
+		"required": ["source", "mode", "count", "tags", "dry_run"],
+		"properties": {
+			"source": {
+				"type": "object",
+				"title": "Where to read from",
+				"required": ["collection", "path"],
+				"properties": {
+					"collection": {"type": "string"},
+					"path": {"type": "string", "default": "/~/"}
+				}
+			},
+			"mode": {"enum": ["copy", "move", "sync-with-checksum-verification-and-deletion-of-extra-destination-files"]},
+			"count": {"type": "integer", "minimum": 1},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"dry_run": {"type": "boolean"}
+		}
+	}`)
+	answers := strings.Join([]string{
+		"",           // source.collection: required, asked again
+		"lab",        // source.collection
+		"",           // source.path: default
+		"2",          // mode: second choice
+		"lots",       // count: not a number
+		"0",          // count: below minimum
+		"3",          // count
+		"raw, daily", // tags
+		"y",          // dry_run
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	input, err := promptInput(strings.NewReader(answers), &out, schema, map[string]interface{}{"dry_run": false})
+	if err != nil {
+		t.Fatalf("prompt: %v\n%s", err, out.String())
+	}
+	want := decodeJSON(t, `{
+		"source": {"collection": "lab", "path": "/~/"},
+		"mode": "move", "count": 3, "tags": ["raw", "daily"], "dry_run": false
+	}`)
+	if !reflect.DeepEqual(input, want) {
+		t.Errorf("input = %v, want %v", input, want)
+	}
+	for _, text := range []string{"$.source (object)", "Where to read from", "$.mode (string)", "2) \"move\"", "3) \"sync-with-checksum-verification-and-deletion-of-extra-destination-files\"\n", "default: \"/~/\"", "A value is required.", "\"lots\" is not a number", "$.count: must be at least 1"} {
+		if !strings.Contains(out.String(), text) {
+			t.Errorf("prompts lack %q:\n%s", text, out.String())
+		}
+	}
+
+	if _, err := promptInput(strings.NewReader("lab\n"), &out, schema, nil); err == nil || !strings.Contains(err.Error(), "$.source.path") {
+		t.Errorf("short input: err = %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// RenderCmd represents the flows render command
+var RenderCmd = &cobra.Command{
+	Use:   "render FILE",
+	Short: "Print the compiled JSON of a flow definition or input schema",
+	Long: `Compile a flow definition or input schema written in JSON or YAML, with
+its !include and "$ref" includes resolved, and print the JSON that 'globus
+flows create' and 'globus flows update' would upload.
+
+Members are printed in sorted order with two-space indentation, so the output
+is stable and can be checked in and reviewed in diffs.
+
+Examples:
+  # Review the JSON a YAML definition compiles to
+  globus flows render flow.yaml
+
+  # Keep a compiled copy next to the source
+  globus flows render flow.yaml > flow.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFlowsRender,
+}
+
+func runFlowsRender(cmd *cobra.Command, args []string) error {
+	doc, err := loadFlowDocument(args[0])
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", args[0], err)
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc.Data)
+}
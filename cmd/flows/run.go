@@ -26,6 +26,7 @@ You can monitor runs, view their logs, and manage their lifecycle.`,
 	runCmd.AddCommand(RunShowDefinitionCmd)
 	runCmd.AddCommand(RunDeleteCmd)
 	runCmd.AddCommand(RunResumeCmd)
+	runCmd.AddCommand(RunWatchCmd)
 
 	return runCmd
 }
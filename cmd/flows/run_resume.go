@@ -40,6 +40,6 @@ func runFlowsRunResume(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Fprintf(os.Stdout, "Run %s resumed.\n", runID)
-	fmt.Fprintf(os.Stdout, "\nCheck status with: globus flows run show %s\n", runID)
+	fmt.Fprintf(os.Stdout, "\nFollow it with: globus flows run watch %s\n", runID)
 	return nil
 }
@@ -0,0 +1,410 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/flows"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Exit codes of run watch (and start --wait) for runs that did not succeed.
+const (
+	runExitFailed   = 1
+	runExitInactive = 2
+)
+
+// defaultRunWatchTimeout is how long run watch and start --wait follow a run.
+const defaultRunWatchTimeout = 30 * time.Minute
+
+var (
+	runWatchInterval time.Duration
+	runWatchTimeout  time.Duration
+)
+
+// runWatchClient is the Flows calls run watch makes.
+type runWatchClient interface {
+	GetRun(ctx context.Context, runID string, options *flows.GetRunOptions) (*flows.FlowRun, error)
+	GetRunLogs(ctx context.Context, runID string, options *flows.ListRunLogsOptions) (*flows.RunLogList, error)
+}
+
+// RunWatchCmd represents the flows run watch command
+var RunWatchCmd = &cobra.Command{
+	Use:   "watch RUN_ID",
+	Short: "Follow a flow run until it finishes",
+	Long: `Follow a flow run, printing its log entries as they are written, until it
+succeeds, fails, is cancelled or becomes INACTIVE.
+
+Each log entry is shown with the time since the run started and the state it
+belongs to, and a line is printed whenever the run's status or current state
+changes. Progress goes to stderr; the final run document goes to stdout.
+
+A run becomes INACTIVE when it needs something from you, most often a
+consent or a session re-authentication an action requires. The reason is
+shown with the 'globus session consent' or 'globus session update' command
+that satisfies it, followed by 'globus flows run resume'.
+
+The command exits 0 when the run succeeds, 1 when it fails or is cancelled,
+and 2 when it is INACTIVE. 'flows start --wait' follows runs the same way.
+
+Examples:
+  # Follow a run
+  globus flows run watch RUN_ID
+
+  # Give up after two hours
+  globus flows run watch RUN_ID --timeout 2h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFlowsRunWatch,
+}
+
+func init() {
+	RunWatchCmd.Flags().DurationVar(&runWatchInterval, "interval", 5*time.Second, "Polling interval")
+	RunWatchCmd.Flags().DurationVar(&runWatchTimeout, "timeout", defaultRunWatchTimeout, "Give up watching after this long")
+}
+
+func runFlowsRunWatch(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), runWatchTimeout)
+	defer cancel()
+
+	flowsClient, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	run, err := watchRun(ctx, flowsClient, args[0], runWatchInterval, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	return reportRunOutcome(cmd, run)
+}
+
+// watchRun follows a run until it reaches a final status, becomes INACTIVE
+// or ctx expires, writing new log entries and status or state changes to w.
+func watchRun(ctx context.Context, client runWatchClient, runID string, interval time.Duration, w io.Writer) (*flows.FlowRun, error) {
+	var logs runLogCursor
+	lastStatus, lastState := "", ""
+	for {
+		run, err := client.GetRun(ctx, runID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting run: %w", err)
+		}
+
+		// Logs are read after the status, so a final status comes with every
+		// entry written before it.
+		entries, err := logs.next(ctx, client, runID)
+		if err != nil {
+			return nil, err
+		}
+		state := lastState
+		for _, entry := range entries {
+			fmt.Fprintln(w, formatRunLogEntry(run, entry))
+			if name, _ := entry.Details["state_name"].(string); name != "" {
+				state = name
+			}
+		}
+
+		if run.Status != lastStatus || state != lastState {
+			line := fmt.Sprintf("Run %s is %s", run.RunID, run.Status)
+			if state != "" {
+				line += " in state " + state
+			}
+			fmt.Fprintf(w, "%s (%s elapsed)\n", line, formatElapsed(runElapsed(run)))
+			lastStatus, lastState = run.Status, state
+		}
+
+		if runFinished(run.Status) || run.Status == "INACTIVE" {
+			return run, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped watching run %s after %s; it is still %s", runID, formatElapsed(runElapsed(run)), run.Status)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runLogCursor remembers how far into a run's log watchRun has read. Flows
+// gives no marker after the last page, so the cursor keeps the marker of the
+// page holding the newest entry read and how many of that page's entries
+// were read; each poll re-reads only that page and any after it.
+type runLogCursor struct {
+	marker string
+	skip   int
+}
+
+// next returns the log entries written since the last call, oldest first.
+func (c *runLogCursor) next(ctx context.Context, client runWatchClient, runID string) ([]flows.RunLog, error) {
+	var entries []flows.RunLog
+	opts := &flows.ListRunLogsOptions{Limit: 100, Marker: c.marker}
+	for {
+		page, err := client.GetRunLogs(ctx, runID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting run logs: %w", err)
+		}
+		entries = append(entries, page.Entries[min(c.skip, len(page.Entries)):]...)
+		if !page.HasNextPage || page.Marker == "" {
+			c.skip = len(page.Entries)
+			return entries, nil
+		}
+		c.marker, c.skip = page.Marker, 0
+		opts.Marker = page.Marker
+	}
+}
+
+// runFinished reports whether a run status is final. Flows reports cancelled
+// runs as ENDED.
+func runFinished(status string) bool {
+	switch status {
+	case "SUCCEEDED", "FAILED", "ENDED", "CANCELLED":
+		return true
+	}
+	return false
+}
+
+// runElapsed is how long the run has been going, or took.
+func runElapsed(run *flows.FlowRun) time.Duration {
+	if run.StartTime.IsZero() {
+		return 0
+	}
+	if !run.EndTime.IsZero() {
+		return run.EndTime.Sub(run.StartTime)
+	}
+	return time.Since(run.StartTime)
+}
+
+// formatElapsed renders a duration to the second.
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatRunLogEntry renders a log entry as one line: the time since the run
+// started, the code, the state it belongs to and the description.
+func formatRunLogEntry(run *flows.FlowRun, entry flows.RunLog) string {
+	offset := ""
+	if !run.StartTime.IsZero() && !entry.Time.IsZero() {
+		offset = "+" + formatElapsed(entry.Time.Sub(run.StartTime))
+	}
+	line := fmt.Sprintf("%8s  %-20s", offset, entry.Code)
+	if name, _ := entry.Details["state_name"].(string); name != "" {
+		line += " [" + name + "]"
+	}
+	if entry.Description != "" {
+		line += " " + entry.Description
+	}
+	return strings.TrimRight(line, " ")
+}
+
+// reportRunOutcome prints a watched run's final state and turns a run that
+// did not succeed into the matching exit code.
+func reportRunOutcome(cmd *cobra.Command, run *flows.FlowRun) error {
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format != output.FormatText {
+		if err := formatter.FormatOutput(run, nil); err != nil {
+			return err
+		}
+		if run.Status == "INACTIVE" {
+			printResumeHint(cmd.ErrOrStderr(), run)
+		}
+	} else {
+		printRunOutcome(cmd.OutOrStdout(), run)
+	}
+
+	code := 0
+	switch {
+	case run.Status == "INACTIVE":
+		code = runExitInactive
+	case run.Status != "SUCCEEDED":
+		code = runExitFailed
+	}
+	if code != 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &output.ExitCodeError{Code: code}
+	}
+	return nil
+}
+
+// printRunOutcome prints how a run ended: its output when it succeeded, the
+// reason when it failed, and what to do when it is INACTIVE.
+func printRunOutcome(w io.Writer, run *flows.FlowRun) {
+	fmt.Fprintf(w, "\nRun %s: %s after %s\n", run.RunID, run.Status, formatElapsed(runElapsed(run)))
+
+	if run.Status == "INACTIVE" {
+		printResumeHint(w, run)
+		return
+	}
+
+	key := "output"
+	if run.Status != "SUCCEEDED" {
+		if reason := runDetailsReason(run.Details); reason != "" {
+			fmt.Fprintf(w, "Reason: %s\n", reason)
+		}
+		key = "details"
+	}
+	if v, ok := run.Details[key]; ok && v != nil {
+		data, _ := json.MarshalIndent(v, "  ", "  ")
+		fmt.Fprintf(w, "\n%s%s:\n  %s\n", strings.ToUpper(key[:1]), key[1:], data)
+	}
+}
+
+// printResumeHint prints why an INACTIVE run stopped and the commands that
+// let it resume.
+func printResumeHint(w io.Writer, run *flows.FlowRun) {
+	req := runResumeRequirement(run.Details)
+	if req.Reason != "" {
+		fmt.Fprintf(w, "Reason: %s\n", req.Reason)
+	}
+	fmt.Fprintln(w, "\nTo resume it, run:")
+	for _, c := range req.Commands {
+		fmt.Fprintf(w, "  %s\n", c)
+	}
+	fmt.Fprintf(w, "  globus flows run resume %s\n", run.RunID)
+}
+
+// runDetailsReason describes why a run stopped from its details' code and
+// description.
+func runDetailsReason(details map[string]interface{}) string {
+	code, _ := details["code"].(string)
+	description, _ := details["description"].(string)
+	switch {
+	case code != "" && description != "":
+		return code + ": " + description
+	case code != "":
+		return code
+	}
+	return description
+}
+
+// runRequirement is what an INACTIVE run needs before it can resume: the
+// reason Flows gives and the session commands that satisfy it.
+type runRequirement struct {
+	Reason   string
+	Commands []string
+}
+
+// runResumeRequirement reads the consents and session requirements an
+// INACTIVE run's details carry. Flows reports them as a required_scope(s)
+// member or as authorization_parameters, at the top level or nested inside
+// the details of the action that needs them.
+func runResumeRequirement(details map[string]interface{}) runRequirement {
+	req := runRequirement{Reason: runDetailsReason(details)}
+
+	scopes := map[string]bool{}
+	params := map[string]map[string]bool{}
+	mfa := false
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if s, ok := v["required_scope"].(string); ok && s != "" {
+				scopes[s] = true
+			}
+			for _, s := range runStrings(v["required_scopes"]) {
+				scopes[s] = true
+			}
+			if ap, ok := v["authorization_parameters"].(map[string]interface{}); ok {
+				for _, key := range []string{"session_required_identities", "session_required_single_domain", "session_required_policies"} {
+					for _, s := range runStrings(ap[key]) {
+						if params[key] == nil {
+							params[key] = map[string]bool{}
+						}
+						params[key][s] = true
+					}
+				}
+				if b, _ := ap["session_required_mfa"].(bool); b {
+					mfa = true
+				}
+			}
+			for _, key := range sortedKeys(v) {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(details)
+
+	if len(scopes) > 0 {
+		req.Commands = append(req.Commands, "globus session consent "+shellQuoteAll(setMembers(scopes)))
+	}
+	// --domain takes one domain and cannot be combined with --identity, so
+	// each required domain gets its own update; identities, policies and MFA
+	// share another.
+	var flags []string
+	if ids := setMembers(params["session_required_identities"]); len(ids) > 0 {
+		flags = append(flags, "--identity "+shellQuote(strings.Join(ids, ",")))
+	}
+	if policies := setMembers(params["session_required_policies"]); len(policies) > 0 {
+		flags = append(flags, "--policy "+shellQuote(strings.Join(policies, ",")))
+	}
+	domains := setMembers(params["session_required_single_domain"])
+	if mfa && (len(flags) > 0 || len(domains) == 0) {
+		flags = append(flags, "--mfa")
+		mfa = false
+	}
+	if len(flags) > 0 {
+		req.Commands = append(req.Commands, "globus session update "+strings.Join(flags, " "))
+	}
+	for _, domain := range domains {
+		c := "globus session update --domain " + shellQuote(domain)
+		if mfa {
+			c += " --mfa"
+		}
+		req.Commands = append(req.Commands, c)
+	}
+	return req
+}
+
+// runStrings returns the strings in a decoded JSON list.
+func runStrings(v interface{}) []string {
+	list, _ := v.([]interface{})
+	var out []string
+	for _, item := range list {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// setMembers returns a set's members in order.
+func setMembers(set map[string]bool) []string {
+	members := make([]string, 0, len(set))
+	for s := range set {
+		members = append(members, s)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// shellQuote quotes s for a POSIX shell when it needs it.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.,:/@=") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellQuoteAll quotes and joins arguments.
+func shellQuoteAll(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/flows"
+)
+
+// fakeRunWatch replays a run's statuses, one per poll, with the log entries
+// written by each poll.
+type fakeRunWatch struct {
+	start    time.Time
+	statuses []string
+	logs     [][]flows.RunLog
+	details  map[string]interface{}
+	polls    int
+	// served counts the log entries returned, to check that polls do not
+	// re-read the whole log.
+	served int
+}
+
+func (f *fakeRunWatch) GetRun(_ context.Context, runID string, _ *flows.GetRunOptions) (*flows.FlowRun, error) {
+	i := min(f.polls, len(f.statuses)-1)
+	f.polls++
+	return &flows.FlowRun{RunID: runID, Status: f.statuses[i], StartTime: f.start, Details: f.details}, nil
+}
+
+func (f *fakeRunWatch) GetRunLogs(_ context.Context, _ string, opts *flows.ListRunLogsOptions) (*flows.RunLogList, error) {
+	var all []flows.RunLog
+	for _, batch := range f.logs[:min(f.polls, len(f.logs))] {
+		all = append(all, batch...)
+	}
+	// Serve two entries per page to exercise the marker.
+	from := 0
+	if opts.Marker != "" {
+		from = len(opts.Marker)
+	}
+	to := min(from+2, len(all))
+	page := &flows.RunLogList{Entries: all[from:to]}
+	f.served += to - from
+	if to < len(all) {
+		page.HasNextPage, page.Marker = true, strings.Repeat("m", to)
+	}
+	return page, nil
+}
+
+// TestWatchRun checks that each log entry is printed once, with state and
+// status changes, until the run finishes.
+func TestWatchRun(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := func(offset time.Duration, code, state string) flows.RunLog {
+		e := flows.RunLog{Code: code, Time: start.Add(offset), Description: strings.ToLower(code)}
+		if state != "" {
+			e.Details = map[string]interface{}{"state_name": state}
+		}
+		return e
+	}
+	fake := &fakeRunWatch{
+		start:    start,
+		statuses: []string{"ACTIVE", "ACTIVE", "ACTIVE", "SUCCEEDED"},
+		logs: [][]flows.RunLog{
+			{entry(0, "FlowStarted", ""), entry(time.Second, "ActionStarted", "Transfer")},
+			{entry(5*time.Second, "ActionPolled", "Transfer")},
+			nil,
+			{entry(65*time.Second, "ActionCompleted", "Transfer"), entry(66*time.Second, "FlowSucceeded", "")},
+		},
+	}
+
+	var out bytes.Buffer
+	run, err := watchRun(context.Background(), fake, "run-1", time.Millisecond, &out)
+	if err != nil || run.Status != "SUCCEEDED" {
+		t.Fatalf("run = %+v, err = %v", run, err)
+	}
+
+	var codes []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if strings.HasPrefix(line, "Run ") {
+			codes = append(codes, line[:strings.Index(line, " (")])
+			continue
+		}
+		codes = append(codes, strings.Fields(line)[1])
+	}
+	want := []string{
+		"FlowStarted", "ActionStarted", "Run run-1 is ACTIVE in state Transfer",
+		"ActionPolled",
+		"ActionCompleted", "FlowSucceeded", "Run run-1 is SUCCEEDED in state Transfer",
+	}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("lines = %q\n%s", codes, out.String())
+	}
+	// Polls read 2, 2+1, 1 and 2+1 entries: the page holding the newest entry
+	// read, then anything after it.
+	if fake.served != 9 {
+		t.Errorf("served %d log entries, want 9", fake.served)
+	}
+	if !strings.Contains(out.String(), "   +1m5s  ActionCompleted      [Transfer] actioncompleted") {
+		t.Errorf("entry format:\n%s", out.String())
+	}
+
+	fake = &fakeRunWatch{start: start, statuses: []string{"ACTIVE"}, logs: [][]flows.RunLog{nil}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := watchRun(ctx, fake, "run-2", time.Millisecond, &out); err == nil || !strings.Contains(err.Error(), "still ACTIVE") {
+		t.Errorf("timeout: err = %v", err)
+	}
+}
+
+// TestRunResumeRequirement checks the commands suggested for INACTIVE runs.
+func TestRunResumeRequirement(t *testing.T) {
+	consent := decodeJSON(t, `{
+		"code": "ConsentRequired",
+		"description": "Missing a required consent",
+		"required_scope": "https://auth.globus.org/scopes/abc/data_access"
+	}`)
+	req := runResumeRequirement(consent)
+	if req.Reason != "ConsentRequired: Missing a required consent" ||
+		!reflect.DeepEqual(req.Commands, []string{"globus session consent https://auth.globus.org/scopes/abc/data_access"}) {
+		t.Errorf("consent: %+v", req)
+	}
+
+	session := decodeJSON(t, `{
+		"code": "ActionInactive",
+		"action_statuses": [{"details": {"authorization_parameters": {
+			"session_required_identities": ["alice@example.org"],
+			"session_required_policies": ["p1", "p2"],
+			"session_required_mfa": true,
+			"required_scopes": ["urn:globus:auth:scope:transfer.api.globus.org:all[*x]"]
+		}}}]
+	}`)
+	req = runResumeRequirement(session)
+	want := []string{
+		"globus session consent 'urn:globus:auth:scope:transfer.api.globus.org:all[*x]'",
+		"globus session update --identity alice@example.org --policy p1,p2 --mfa",
+	}
+	if req.Reason != "ActionInactive" || !reflect.DeepEqual(req.Commands, want) {
+		t.Errorf("session: %+v", req)
+	}
+
+	domains := decodeJSON(t, `{
+		"authorization_parameters": {
+			"session_required_identities": ["alice@example.org"],
+			"session_required_single_domain": ["uchicago.edu", "example.org"]
+		}
+	}`)
+	want = []string{
+		"globus session update --identity alice@example.org",
+		"globus session update --domain example.org",
+		"globus session update --domain uchicago.edu",
+	}
+	if req := runResumeRequirement(domains); !reflect.DeepEqual(req.Commands, want) {
+		t.Errorf("domains: %+v", req)
+	}
+
+	var out bytes.Buffer
+	printRunOutcome(&out, &flows.FlowRun{RunID: "run-1", Status: "INACTIVE", Details: consent})
+	if !strings.Contains(out.String(), "globus flows run resume run-1") {
+		t.Errorf("outcome:\n%s", out.String())
+	}
+}
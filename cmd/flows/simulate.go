@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	simulateInput    string
+	simulateStubs    string
+	simulateMaxSteps int
+)
+
+// SimulateCmd represents the flows simulate command
+var SimulateCmd = &cobra.Command{
+	Use:   "simulate DEFINITION_FILE",
+	Short: "Run a flow definition locally against stubbed action providers",
+	Long: `Interpret a flow definition locally, without contacting any Globus service,
+and print the state-by-state trace and the final run state.
+
+Action, Pass, Choice, Wait, Fail and ExpressionEval states are supported.
+Parameters are evaluated against the run state ("key.$" JSONPaths and "key.="
+expressions), results are stored at ResultPath (a missing ResultPath replaces
+the run state, as in the Amazon States Language), Catch handles action
+failures, and Wait states are reported but not slept.
+
+Action states are answered from a stubs file (JSON or YAML) of canned
+responses, keyed by state name or by ActionUrl; a state-name entry wins. A
+key may hold a list of responses, used in turn by successive calls with the
+last one repeating:
+
+  states:
+    Transfer:
+      status: SUCCEEDED
+      details: {task_id: "abc"}
+  action_urls:
+    https://actions.globus.org/hello_world:
+      - status: FAILED
+        details: {reason: "first call fails"}
+      - details: {hello: "world"}
+
+A response has status (SUCCEEDED or FAILED, default SUCCEEDED),
+display_status and details, or error and cause to raise a named error such
+as ActionUnableToRun. A FAILED status raises ActionFailedException unless the
+state sets "ExceptionOnActionFailure": false.
+
+The command exits 1 when the simulated run fails, so flows can be unit
+tested in CI.
+
+Examples:
+  # Simulate a run
+  globus flows simulate flow.yaml --input input.json --stubs stubs.yaml
+
+  # Check the final output in a test
+  globus flows simulate flow.yaml --input '{"n": 3}' --stubs stubs.yaml -F json \\
+    | jq -e '.output.total == 6'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFlowsSimulate,
+}
+
+func init() {
+	SimulateCmd.Flags().StringVar(&simulateInput, "input", "", "Run input: a JSON or YAML file, or an inline JSON object")
+	SimulateCmd.Flags().StringVar(&simulateStubs, "stubs", "", "JSON or YAML file of canned action provider responses")
+	SimulateCmd.Flags().IntVar(&simulateMaxSteps, "max-steps", 1000, "Stop a run that executes more states than this")
+}
+
+func runFlowsSimulate(cmd *cobra.Command, args []string) error {
+	def, err := loadFlowDocument(args[0])
+	if err != nil {
+		return fmt.Errorf("error reading definition file: %w", err)
+	}
+	if findings := lintFlow(def, nil); hasLintErrors(findings) {
+		for _, f := range findings {
+			if f.Severity == lintError {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d: %s: %s: %s\n", f.File, f.Line, f.Severity, f.Pointer, f.Message)
+			}
+		}
+		return fmt.Errorf("flow definition is invalid; see 'globus flows validate --offline'")
+	}
+
+	input := map[string]interface{}{}
+	if simulateInput != "" {
+		if input, err = readRunInput(simulateInput); err != nil {
+			return err
+		}
+	}
+
+	stubs := &simStubs{byState: map[string][]simStub{}, byURL: map[string][]simStub{}}
+	if simulateStubs != "" {
+		doc, err := loadFlowDocument(simulateStubs)
+		if err != nil {
+			return fmt.Errorf("error reading stubs file: %w", err)
+		}
+		if stubs, err = parseSimStubs(doc.Data); err != nil {
+			return fmt.Errorf("error in stubs file %s: %w", simulateStubs, err)
+		}
+	}
+
+	result, simErr := simulateFlow(def.Data, input, stubs, simulateMaxSteps)
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format != output.FormatText {
+		if err := formatter.FormatOutput(result, nil); err != nil {
+			return err
+		}
+	} else {
+		printSimulation(cmd.OutOrStdout(), result, simErr == nil)
+	}
+
+	if simErr != nil {
+		return fmt.Errorf("simulation stopped: %w", simErr)
+	}
+	if result.Status != simSucceeded {
+		// The trace is the verdict: don't follow it with usage text.
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &output.ExitCodeError{Code: 1}
+	}
+	return nil
+}
+
+// readRunInput reads run input given inline as a JSON object or as a file.
+func readRunInput(value string) (map[string]interface{}, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var input map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &input); err != nil {
+			return nil, fmt.Errorf("error parsing input JSON: %w", err)
+		}
+		return input, nil
+	}
+	doc, err := loadFlowDocument(value)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input file: %w", err)
+	}
+	return doc.Data, nil
+}
+
+// hasLintErrors reports whether any finding is an error.
+func hasLintErrors(findings []lintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == lintError {
+			return true
+		}
+	}
+	return false
+}
+
+// printSimulation prints the trace, the outcome and the final run state.
+func printSimulation(w io.Writer, result *simResult, finished bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tSTATE\tTYPE\tOUTCOME\tNEXT")
+	for _, step := range result.Trace {
+		var outcome []string
+		if step.Stub != "" {
+			outcome = append(outcome, "stub "+step.Stub)
+		}
+		if step.Detail != "" {
+			outcome = append(outcome, step.Detail)
+		}
+		switch {
+		case step.Caught:
+			outcome = append(outcome, "caught "+step.Error)
+		case step.Error != "":
+			outcome = append(outcome, "error "+step.Error)
+		}
+		next := step.Next
+		if next == "" && (step.Error == "" || step.Caught) && finished {
+			next = "(end)"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", step.Step, step.State, step.Type, strings.Join(outcome, "; "), next)
+	}
+	_ = tw.Flush()
+
+	if !finished {
+		return
+	}
+	fmt.Fprintf(w, "\nRun %s", result.Status)
+	if result.Error != "" {
+		fmt.Fprintf(w, ": %s", result.Error)
+		if result.Cause != "" {
+			fmt.Fprintf(w, " (%s)", firstLine(result.Cause))
+		}
+	}
+	fmt.Fprintln(w)
+	out, _ := json.MarshalIndent(result.Output, "", "  ")
+	fmt.Fprintf(w, "\nOutput:\n%s\n", out)
+}
+
+// firstLine truncates s at its first newline.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
@@ -0,0 +1,669 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Simulated run statuses.
+const (
+	simSucceeded = "SUCCEEDED"
+	simFailed    = "FAILED"
+)
+
+// simStub is one canned action provider response.
+type simStub struct {
+	Status        string
+	DisplayStatus string
+	Details       interface{}
+	// Error, when set, makes the action raise this error instead of
+	// returning a status (for example ActionUnableToRun).
+	Error string
+	Cause string
+}
+
+// simStubs holds canned responses keyed by state name and by ActionUrl. A
+// key may hold several responses, used in turn by successive calls; the last
+// one repeats.
+type simStubs struct {
+	byState map[string][]simStub
+	byURL   map[string][]simStub
+}
+
+// parseSimStubs reads a stubs document:
+//
+//	states:
+//	  Transfer: {status: SUCCEEDED, details: {...}}
+//	action_urls:
+//	  https://actions.globus.org/hello_world:
+//	    - {status: FAILED, details: {...}}
+//	    - {details: {...}}
+func parseSimStubs(doc map[string]interface{}) (*simStubs, error) {
+	stubs := &simStubs{byState: map[string][]simStub{}, byURL: map[string][]simStub{}}
+	for _, key := range sortedKeys(doc) {
+		var into map[string][]simStub
+		switch key {
+		case "states":
+			into = stubs.byState
+		case "action_urls":
+			into = stubs.byURL
+		default:
+			return nil, fmt.Errorf("unknown stubs section %q (want states or action_urls)", key)
+		}
+		section, ok := doc[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("stubs section %q must be an object", key)
+		}
+		for _, name := range sortedKeys(section) {
+			raw := section[name]
+			list, ok := raw.([]interface{})
+			if !ok {
+				list = []interface{}{raw}
+			}
+			if len(list) == 0 {
+				return nil, fmt.Errorf("%s.%s: no responses", key, name)
+			}
+			for i, item := range list {
+				stub, err := parseSimStub(item)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s[%d]: %w", key, name, i, err)
+				}
+				into[name] = append(into[name], stub)
+			}
+		}
+	}
+	return stubs, nil
+}
+
+func parseSimStub(raw interface{}) (simStub, error) {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return simStub{}, fmt.Errorf("response must be an object")
+	}
+	stub := simStub{Status: simSucceeded, Details: map[string]interface{}{}}
+	for _, key := range sortedKeys(obj) {
+		v := obj[key]
+		switch key {
+		case "details":
+			stub.Details = v
+		case "status", "display_status", "error", "cause":
+			s, ok := v.(string)
+			if !ok {
+				return simStub{}, fmt.Errorf("%s must be a string", key)
+			}
+			switch key {
+			case "status":
+				stub.Status = strings.ToUpper(s)
+			case "display_status":
+				stub.DisplayStatus = s
+			case "error":
+				stub.Error = s
+			case "cause":
+				stub.Cause = s
+			}
+		default:
+			return simStub{}, fmt.Errorf("unknown field %q (want status, display_status, details, error or cause)", key)
+		}
+	}
+	if stub.Status != simSucceeded && stub.Status != simFailed {
+		return simStub{}, fmt.Errorf("status must be SUCCEEDED or FAILED, not %q", stub.Status)
+	}
+	if stub.DisplayStatus == "" {
+		stub.DisplayStatus = stub.Status
+	}
+	return stub, nil
+}
+
+// simStep is one line of a simulated run's trace.
+type simStep struct {
+	Step   int         `json:"step"`
+	State  string      `json:"state"`
+	Type   string      `json:"type"`
+	Stub   string      `json:"stub,omitempty"`
+	Input  interface{} `json:"input,omitempty"`
+	Detail string      `json:"detail,omitempty"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	Cause  string      `json:"cause,omitempty"`
+	Caught bool        `json:"caught,omitempty"`
+	Next   string      `json:"next,omitempty"`
+}
+
+// simResult is the outcome of a simulated run.
+type simResult struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Cause  string                 `json:"cause,omitempty"`
+	Output map[string]interface{} `json:"output"`
+	Trace  []simStep              `json:"trace"`
+}
+
+// flowError is an error raised by a state, which Catch may handle.
+type flowError struct {
+	Name  string
+	Cause string
+}
+
+func (e *flowError) Error() string {
+	if e.Cause == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Cause
+}
+
+// runtimeError wraps a failure evaluating the definition itself (a missing
+// JSONPath value, a bad expression). Like States.Runtime, it is not caught.
+func runtimeError(err error) *flowError {
+	return &flowError{Name: "States.Runtime", Cause: err.Error()}
+}
+
+// flowSimulator interprets a flow definition locally.
+type flowSimulator struct {
+	definition map[string]interface{}
+	stubs      *simStubs
+	maxSteps   int
+	calls      map[string]int
+}
+
+// simulateFlow runs definition on input, answering Action states from
+// stubs. The returned error is for problems that stop the simulation itself
+// (a missing stub, a runaway loop); the partial trace is still returned.
+func simulateFlow(definition, input map[string]interface{}, stubs *simStubs, maxSteps int) (*simResult, error) {
+	s := &flowSimulator{definition: definition, stubs: stubs, maxSteps: maxSteps, calls: map[string]int{}}
+	return s.run(deepCopy(input).(map[string]interface{}))
+}
+
+func (s *flowSimulator) run(runState map[string]interface{}) (*simResult, error) {
+	states, _ := s.definition["States"].(map[string]interface{})
+	name, _ := s.definition["StartAt"].(string)
+	result := &simResult{Trace: []simStep{}}
+
+	for step := 1; ; step++ {
+		if step > s.maxSteps {
+			result.Output = runState
+			return result, fmt.Errorf("stopped after %d steps; the flow may loop forever (raise --max-steps if it should not)", s.maxSteps)
+		}
+		state, ok := states[name].(map[string]interface{})
+		if !ok {
+			result.Output = runState
+			return result, fmt.Errorf("state %q does not exist", name)
+		}
+		typ, _ := state["Type"].(string)
+		trace := simStep{Step: step, State: name, Type: typ}
+
+		next, newState, ferr, err := s.execute(name, state, runState, &trace)
+		if err != nil {
+			result.Trace = append(result.Trace, trace)
+			result.Output = runState
+			return result, err
+		}
+		if ferr != nil {
+			trace.Error, trace.Cause = ferr.Name, ferr.Cause
+			if catchNext, caughtState, ok := s.catch(state, ferr, runState, &trace); ok {
+				next, newState, ferr = catchNext, caughtState, nil
+			}
+		}
+		if ferr != nil {
+			result.Trace = append(result.Trace, trace)
+			result.Status, result.Error, result.Cause = simFailed, ferr.Name, ferr.Cause
+			result.Output = runState
+			return result, nil
+		}
+		runState = newState
+		trace.Next = next
+		result.Trace = append(result.Trace, trace)
+		if next == "" {
+			result.Status = simSucceeded
+			result.Output = runState
+			return result, nil
+		}
+		name = next
+	}
+}
+
+// execute runs one state. It returns the next state ("" to end the run) and
+// the new run state, or a flow error the state raised.
+func (s *flowSimulator) execute(name string, state, runState map[string]interface{}, trace *simStep) (string, map[string]interface{}, *flowError, error) {
+	next, _ := state["Next"].(string)
+	typ, _ := state["Type"].(string)
+
+	var output interface{}
+	switch typ {
+	case "Fail":
+		ferr := &flowError{Name: stringField(state, "Error"), Cause: stringField(state, "Cause")}
+		if ferr.Name == "" {
+			ferr.Name = "States.Fail"
+		}
+		return "", nil, ferr, nil
+
+	case "Choice":
+		target, detail, err := chooseNext(state, runState)
+		if err != nil {
+			return "", nil, runtimeError(err), nil
+		}
+		if target == "" {
+			return "", nil, &flowError{Name: "States.NoChoiceMatched", Cause: "no Choice rule matched and there is no Default"}, nil
+		}
+		trace.Detail = detail
+		return target, runState, nil, nil
+
+	case "Wait":
+		detail, err := describeWait(state, runState)
+		if err != nil {
+			return "", nil, runtimeError(err), nil
+		}
+		trace.Detail = detail
+		return next, runState, nil, nil
+
+	case "Pass", "ExpressionEval":
+		input, err := stateInput(state, runState)
+		if err != nil {
+			return "", nil, runtimeError(err), nil
+		}
+		switch {
+		case state["Parameters"] != nil:
+			if output, err = evalParameters(state["Parameters"], input); err != nil {
+				return "", nil, runtimeError(err), nil
+			}
+		case typ == "Pass" && state["Result"] != nil:
+			output = state["Result"]
+		case typ == "Pass":
+			output = input
+		default:
+			return "", nil, runtimeError(fmt.Errorf("ExpressionEval state has no Parameters")), nil
+		}
+
+	case "Action":
+		params, err := evalParameters(state["Parameters"], runState)
+		if err != nil {
+			return "", nil, runtimeError(err), nil
+		}
+		actionURL := stringField(state, "ActionUrl")
+		stub, source, ok := s.stubFor(name, actionURL)
+		if !ok {
+			return "", nil, nil, fmt.Errorf("no stub for Action state %q (ActionUrl %s); add one under states or action_urls", name, actionURL)
+		}
+		trace.Stub, trace.Input = source, params
+		if stub.Error != "" {
+			return "", nil, &flowError{Name: stub.Error, Cause: stub.Cause}, nil
+		}
+		doc := map[string]interface{}{
+			"action_id":      fmt.Sprintf("simulated-%s-%d", name, s.calls[source]),
+			"status":         stub.Status,
+			"display_status": stub.DisplayStatus,
+			"details":        deepCopy(stub.Details),
+		}
+		trace.Detail = stub.Status
+		if stub.Status == simFailed {
+			if raise, ok := state["ExceptionOnActionFailure"].(bool); !ok || raise {
+				cause, _ := json.Marshal(doc)
+				return "", nil, &flowError{Name: "ActionFailedException", Cause: string(cause)}, nil
+			}
+		}
+		output = doc
+
+	default:
+		return "", nil, nil, fmt.Errorf("state %q has unsupported Type %q", name, typ)
+	}
+
+	trace.Result = output
+	resultPath := stringField(state, "ResultPath")
+	if resultPath == "" {
+		resultPath = "$"
+	}
+	newState, err := setJSONPath(deepCopy(runState).(map[string]interface{}), resultPath, deepCopy(output))
+	if err != nil {
+		return "", nil, runtimeError(err), nil
+	}
+	if end, _ := state["End"].(bool); end {
+		next = ""
+	}
+	return next, newState, nil, nil
+}
+
+// catch finds the first Catch entry matching ferr and applies it.
+func (s *flowSimulator) catch(state map[string]interface{}, ferr *flowError, runState map[string]interface{}, trace *simStep) (string, map[string]interface{}, bool) {
+	if ferr.Name == "States.Runtime" {
+		return "", nil, false
+	}
+	catches, _ := state["Catch"].([]interface{})
+	for _, c := range catches {
+		catch, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		errs, _ := catch["ErrorEquals"].([]interface{})
+		for _, e := range errs {
+			if e != ferr.Name && e != "States.ALL" {
+				continue
+			}
+			resultPath := stringField(catch, "ResultPath")
+			if resultPath == "" {
+				resultPath = "$"
+			}
+			info := map[string]interface{}{"Error": ferr.Name, "Cause": ferr.Cause}
+			newState, err := setJSONPath(deepCopy(runState).(map[string]interface{}), resultPath, info)
+			if err != nil {
+				return "", nil, false
+			}
+			trace.Caught = true
+			return stringField(catch, "Next"), newState, true
+		}
+	}
+	return "", nil, false
+}
+
+// stubFor returns the next canned response for an Action state, preferring
+// one keyed by state name, and names where it came from.
+func (s *flowSimulator) stubFor(name, actionURL string) (simStub, string, bool) {
+	list, source := s.stubs.byState[name], "state "+name
+	if len(list) == 0 {
+		list, source = s.stubs.byURL[actionURL], "action_url "+actionURL
+		if len(list) == 0 {
+			list, source = s.stubs.byURL[strings.TrimSuffix(actionURL, "/")], "action_url "+strings.TrimSuffix(actionURL, "/")
+		}
+	}
+	if len(list) == 0 {
+		return simStub{}, "", false
+	}
+	i := s.calls[source]
+	s.calls[source]++
+	if i >= len(list) {
+		i = len(list) - 1
+	}
+	return list[i], source, true
+}
+
+// stateInput applies a Pass or ExpressionEval state's InputPath.
+func stateInput(state, runState map[string]interface{}) (map[string]interface{}, error) {
+	path := stringField(state, "InputPath")
+	if path == "" {
+		return runState, nil
+	}
+	v, found, err := lookupJSONPath(path, runState)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !found || !ok {
+		return nil, fmt.Errorf("InputPath %s does not select an object", path)
+	}
+	return obj, nil
+}
+
+// evalParameters builds a state's parameters: "key.$" members take the value
+// their JSONPath selects and "key.=" members the value of their expression.
+func evalParameters(v interface{}, runState map[string]interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for _, key := range sortedKeys(v) {
+			item := v[key]
+			switch {
+			case strings.HasSuffix(key, ".$"):
+				path, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s must be a JSONPath string", key)
+				}
+				val, found, err := lookupJSONPath(path, runState)
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					return nil, fmt.Errorf("%s: no value at %s", key, path)
+				}
+				out[strings.TrimSuffix(key, ".$")] = deepCopy(val)
+			case strings.HasSuffix(key, ".="):
+				expr, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s must be an expression string", key)
+				}
+				val, err := evalExpression(expr, runState)
+				if err != nil {
+					return nil, err
+				}
+				out[strings.TrimSuffix(key, ".=")] = val
+			default:
+				val, err := evalParameters(item, runState)
+				if err != nil {
+					return nil, err
+				}
+				out[key] = val
+			}
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			val, err := evalParameters(item, runState)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = val
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// chooseNext evaluates a Choice state's rules in order, returning the target
+// state and a description of what matched. The target is "" when nothing
+// matched and there is no Default.
+func chooseNext(state, runState map[string]interface{}) (string, string, error) {
+	choices, _ := state["Choices"].([]interface{})
+	for i, raw := range choices {
+		rule, _ := raw.(map[string]interface{})
+		ok, err := evalChoiceRule(rule, runState)
+		if err != nil {
+			return "", "", fmt.Errorf("Choices[%d]: %w", i, err)
+		}
+		if ok {
+			return stringField(rule, "Next"), fmt.Sprintf("rule %d matched", i+1), nil
+		}
+	}
+	return stringField(state, "Default"), "Default", nil
+}
+
+// evalChoiceRule evaluates one Choice rule. A comparison whose Variable is
+// missing does not match (IsPresent aside).
+func evalChoiceRule(rule, runState map[string]interface{}) (bool, error) {
+	if list, ok := rule["And"].([]interface{}); ok {
+		for _, sub := range list {
+			subRule, _ := sub.(map[string]interface{})
+			if ok, err := evalChoiceRule(subRule, runState); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	if list, ok := rule["Or"].([]interface{}); ok {
+		for _, sub := range list {
+			subRule, _ := sub.(map[string]interface{})
+			if ok, err := evalChoiceRule(subRule, runState); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	if sub, ok := rule["Not"].(map[string]interface{}); ok {
+		ok, err := evalChoiceRule(sub, runState)
+		return !ok && err == nil, err
+	}
+
+	op := ""
+	for key := range rule {
+		if _, ok := choiceOperandTypes[key]; ok {
+			op = key
+		}
+	}
+	if op == "" {
+		return false, fmt.Errorf("choice rule has no comparison operator")
+	}
+	value, found, err := lookupJSONPath(stringField(rule, "Variable"), runState)
+	if err != nil {
+		return false, err
+	}
+
+	operand := rule[op]
+	switch op {
+	case "IsPresent":
+		return found == (operand == true), nil
+	}
+	if !found {
+		return false, nil
+	}
+	switch op {
+	case "IsNull":
+		return (value == nil) == (operand == true), nil
+	case "IsNumeric":
+		_, is := value.(float64)
+		return is == (operand == true), nil
+	case "IsString":
+		_, is := value.(string)
+		return is == (operand == true), nil
+	case "IsBoolean":
+		_, is := value.(bool)
+		return is == (operand == true), nil
+	case "IsTimestamp":
+		s, _ := value.(string)
+		_, err := time.Parse(time.RFC3339, s)
+		return (err == nil) == (operand == true), nil
+	}
+
+	if strings.HasSuffix(op, "Path") {
+		path, _ := operand.(string)
+		if operand, found, err = lookupJSONPath(path, runState); err != nil || !found {
+			return false, err
+		}
+		op = strings.TrimSuffix(op, "Path")
+	}
+	return compareChoice(op, value, operand), nil
+}
+
+// compareChoice applies a String*, Numeric*, Boolean* or Timestamp*
+// comparison. Values of the wrong type do not match.
+func compareChoice(op string, value, operand interface{}) bool {
+	var cmp int
+	switch {
+	case op == "BooleanEquals":
+		a, ok1 := value.(bool)
+		b, ok2 := operand.(bool)
+		return ok1 && ok2 && a == b
+	case op == "StringMatches":
+		a, ok1 := value.(string)
+		b, ok2 := operand.(string)
+		return ok1 && ok2 && wildcardMatch(b, a)
+	case strings.HasPrefix(op, "String"):
+		a, ok1 := value.(string)
+		b, ok2 := operand.(string)
+		if !ok1 || !ok2 {
+			return false
+		}
+		cmp = strings.Compare(a, b)
+		op = strings.TrimPrefix(op, "String")
+	case strings.HasPrefix(op, "Numeric"):
+		a, ok1 := value.(float64)
+		b, ok2 := operand.(float64)
+		if !ok1 || !ok2 {
+			return false
+		}
+		cmp = compareOrdered(a, b)
+		op = strings.TrimPrefix(op, "Numeric")
+	case strings.HasPrefix(op, "Timestamp"):
+		a, err1 := time.Parse(time.RFC3339, fmt.Sprint(value))
+		b, err2 := time.Parse(time.RFC3339, fmt.Sprint(operand))
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		cmp = a.Compare(b)
+		op = strings.TrimPrefix(op, "Timestamp")
+	default:
+		return false
+	}
+	switch op {
+	case "Equals":
+		return cmp == 0
+	case "LessThan":
+		return cmp < 0
+	case "GreaterThan":
+		return cmp > 0
+	case "LessThanEquals":
+		return cmp <= 0
+	case "GreaterThanEquals":
+		return cmp >= 0
+	}
+	return false
+}
+
+// wildcardMatch reports whether s matches a StringMatches pattern, where *
+// matches any run of characters and \* a literal asterisk.
+func wildcardMatch(pattern, s string) bool {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case pattern[i] == '*':
+			b.WriteString("(?s:.*)")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()).MatchString(s)
+}
+
+// describeWait reports what a Wait state would wait for; the simulator does
+// not sleep.
+func describeWait(state, runState map[string]interface{}) (string, error) {
+	switch {
+	case state["Seconds"] != nil:
+		return fmt.Sprintf("wait %vs (skipped)", state["Seconds"]), nil
+	case state["Timestamp"] != nil:
+		return fmt.Sprintf("wait until %v (skipped)", state["Timestamp"]), nil
+	}
+	for _, field := range []string{"SecondsPath", "TimestampPath"} {
+		path := stringField(state, field)
+		if path == "" {
+			continue
+		}
+		v, found, err := lookupJSONPath(path, runState)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			return "", fmt.Errorf("%s: no value at %s", field, path)
+		}
+		if field == "SecondsPath" {
+			return fmt.Sprintf("wait %vs (skipped)", v), nil
+		}
+		return fmt.Sprintf("wait until %v (skipped)", v), nil
+	}
+	return "wait (skipped)", nil
+}
+
+// deepCopy copies a decoded JSON value so states cannot alias each other.
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = deepCopy(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package flows
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// simTestFlow retries a transfer once after a failure, then branches on the
+// number of files it moved.
+const simTestFlow = `{
+  "StartAt": "Init",
+  "States": {
+    "Init": {"Type": "ExpressionEval", "Parameters": {"tries.=": "0"}, "ResultPath": "$.vars", "Next": "Transfer"},
+    "Transfer": {
+      "Type": "Action",
+      "ActionUrl": "https://transfer.actions.globus.org/transfer",
+      "Parameters": {"source_endpoint.$": "$.src", "label.=": "'try ' + str(vars['tries'])"},
+      "ResultPath": "$.TransferResult",
+      "Catch": [{"ErrorEquals": ["ActionFailedException"], "ResultPath": "$.TransferError", "Next": "Retry"}],
+      "Next": "Check"
+    },
+    "Retry": {"Type": "ExpressionEval", "Parameters": {"tries.=": "vars['tries'] + 1"}, "ResultPath": "$.vars", "Next": "Pause"},
+    "Pause": {"Type": "Wait", "Seconds": 30, "Next": "Transfer"},
+    "Check": {
+      "Type": "Choice",
+      "Choices": [{"Variable": "$.TransferResult.details.files", "NumericGreaterThan": 0, "Next": "Done"}],
+      "Default": "Empty"
+    },
+    "Empty": {"Type": "Fail", "Error": "NoFiles", "Cause": "nothing transferred"},
+    "Done": {"Type": "Pass", "Parameters": {"moved.$": "$.TransferResult.details.files"}, "ResultPath": "$.summary", "End": true}
+  }
+}`
+
+// decodeJSON decodes a JSON object for a test.
+func decodeJSON(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// traceStates lists the states a run visited.
+func traceStates(result *simResult) string {
+	var names []string
+	for _, step := range result.Trace {
+		names = append(names, step.State)
+	}
+	return strings.Join(names, " ")
+}
+
+// TestSimulateFlow checks a run with a caught failure, a retry and a Choice.
+func TestSimulateFlow(t *testing.T) {
+	stubs, err := parseSimStubs(decodeJSON(t, `{"states": {"Transfer": [
+		{"status": "FAILED", "details": {"code": "ENDPOINT_ERROR"}},
+		{"details": {"files": 3}}
+	]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := simulateFlow(decodeJSON(t, simTestFlow), decodeJSON(t, `{"src": "ep1"}`), stubs, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != simSucceeded {
+		t.Fatalf("status = %s (%s: %s)", result.Status, result.Error, result.Cause)
+	}
+	if got := traceStates(result); got != "Init Transfer Retry Pause Transfer Check Done" {
+		t.Errorf("trace = %s", got)
+	}
+	if !result.Trace[1].Caught || result.Trace[1].Error != "ActionFailedException" {
+		t.Errorf("first transfer = %+v", result.Trace[1])
+	}
+	if want := map[string]interface{}{"source_endpoint": "ep1", "label": "try 1"}; !reflect.DeepEqual(result.Trace[4].Input, want) {
+		t.Errorf("second transfer input = %v", result.Trace[4].Input)
+	}
+	if got := result.Output["summary"]; !reflect.DeepEqual(got, map[string]interface{}{"moved": float64(3)}) {
+		t.Errorf("summary = %v", got)
+	}
+	if result.Output["TransferError"].(map[string]interface{})["Error"] != "ActionFailedException" {
+		t.Errorf("TransferError = %v", result.Output["TransferError"])
+	}
+}
+
+// TestSimulateFlowFailures checks Fail states, uncaught errors and stub
+// problems.
+func TestSimulateFlowFailures(t *testing.T) {
+	def := decodeJSON(t, simTestFlow)
+	input := decodeJSON(t, `{"src": "ep1"}`)
+
+	// An empty transfer takes the Default branch to a Fail state.
+	stubs, _ := parseSimStubs(decodeJSON(t, `{"action_urls": {"https://transfer.actions.globus.org/transfer": {"details": {"files": 0}}}}`))
+	result, err := simulateFlow(def, input, stubs, 100)
+	if err != nil || result.Status != simFailed || result.Error != "NoFiles" {
+		t.Errorf("fail state: result = %+v, err = %v", result, err)
+	}
+
+	// A raised error that no Catch names fails the run.
+	stubs, _ = parseSimStubs(decodeJSON(t, `{"states": {"Transfer": {"error": "ActionUnableToRun", "cause": "denied"}}}`))
+	result, err = simulateFlow(def, input, stubs, 100)
+	if err != nil || result.Status != simFailed || result.Error != "ActionUnableToRun" || result.Cause != "denied" {
+		t.Errorf("uncaught error: result = %+v, err = %v", result, err)
+	}
+
+	// A missing input value is a runtime error.
+	result, err = simulateFlow(def, map[string]interface{}{}, stubs, 100)
+	if err != nil || result.Error != "States.Runtime" || !strings.Contains(result.Cause, "$.src") {
+		t.Errorf("missing input: result = %+v, err = %v", result, err)
+	}
+
+	// No stub stops the simulation, keeping the trace so far.
+	result, err = simulateFlow(def, input, &simStubs{}, 100)
+	if err == nil || !strings.Contains(err.Error(), "no stub") || traceStates(result) != "Init Transfer" {
+		t.Errorf("no stub: result = %+v, err = %v", result, err)
+	}
+
+	// Failing forever loops until --max-steps.
+	stubs, _ = parseSimStubs(decodeJSON(t, `{"states": {"Transfer": {"status": "FAILED"}}}`))
+	if _, err = simulateFlow(def, input, stubs, 20); err == nil || !strings.Contains(err.Error(), "stopped after 20 steps") {
+		t.Errorf("loop: err = %v", err)
+	}
+
+	for stubsJSON, want := range map[string]string{
+		`{"state": {}}`: "unknown stubs section",
+		`{"states": {"A": {"status": "RUNNING"}}}`: "status must be SUCCEEDED or FAILED",
+		`{"states": {"A": {"detail": {}}}}`:        `unknown field "detail"`,
+	} {
+		if _, err := parseSimStubs(decodeJSON(t, stubsJSON)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", stubsJSON, err, want)
+		}
+	}
+}
+
+// TestEvalChoiceRule checks comparison operators and missing variables.
+func TestEvalChoiceRule(t *testing.T) {
+	state := decodeJSON(t, `{"s": "data.csv", "n": 5, "m": 5, "b": true, "t": "2026-01-02T00:00:00Z", "nil": null}`)
+	tests := map[string]bool{
+		`{"Variable": "$.s", "StringMatches": "*.csv"}`:                                                           true,
+		`{"Variable": "$.s", "StringMatches": "*.txt"}`:                                                           false,
+		`{"Variable": "$.n", "NumericLessThanEqualsPath": "$.m"}`:                                                 true,
+		`{"Variable": "$.n", "StringEquals": "5"}`:                                                                false,
+		`{"Variable": "$.b", "BooleanEquals": true}`:                                                              true,
+		`{"Variable": "$.t", "TimestampGreaterThan": "2026-01-01T00:00:00Z"}`:                                     true,
+		`{"Variable": "$.nil", "IsNull": true}`:                                                                   true,
+		`{"Variable": "$.gone", "IsPresent": false}`:                                                              true,
+		`{"Variable": "$.gone", "NumericEquals": 1}`:                                                              false,
+		`{"Or": [{"Variable": "$.n", "NumericEquals": 1}, {"Not": {"Variable": "$.b", "BooleanEquals": false}}]}`: true,
+	}
+	for rule, want := range tests {
+		got, err := evalChoiceRule(decodeJSON(t, rule), state)
+		if err != nil || got != want {
+			t.Errorf("%s = %v, %v; want %v", rule, got, err, want)
+		}
+	}
+}
+
+// TestSetJSONPath checks ResultPath assignment.
+func TestSetJSONPath(t *testing.T) {
+	doc := decodeJSON(t, `{"a": {"list": [1, 2]}}`)
+	doc, err := setJSONPath(doc, "$.a.b.c", "x")
+	if err == nil {
+		doc, err = setJSONPath(doc, "$.a.list[1]", "y")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := decodeJSON(t, `{"a": {"list": [1, "y"], "b": {"c": "x"}}}`)
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("doc = %v", doc)
+	}
+	if _, err := setJSONPath(doc, "$", "scalar"); err == nil {
+		t.Error("$ accepted a non-object")
+	}
+	if _, err := setJSONPath(doc, "$.a[*]", 1); err == nil {
+		t.Error("wildcard accepted")
+	}
+}
@@ -22,6 +22,10 @@ var (
 	startMonitors  []string
 	startNotify    []string
 	startWait      bool
+	startTimeout   time.Duration
+
+	startPromptInput    bool
+	startSkipValidation bool
 )
 
 // StartCmd represents the flows start command
@@ -33,6 +37,16 @@ var StartCmd = &cobra.Command{
 The input must conform to the flow's input schema. You can provide input
 from a JSON file or as a JSON string on the command line.
 
+Before the run is submitted, the flow's input schema is fetched and the input
+is checked against it locally; violations are reported as JSON paths, such
+as $.source.path, and nothing is started. --skip-validation leaves the check
+to the Flows service.
+
+--prompt-input asks for each required input property the given input lacks,
+showing its type, description, choices and default, and checks every answer
+as it is given. Required objects are filled in property by property; arrays
+of strings may be answered comma-separated, other arrays and objects as JSON.
+
 Examples:
   # Start a flow from an input file
   globus flows start FLOW_ID --input-file input.json
@@ -45,8 +59,11 @@ Examples:
     --label "Production run" \\
     --tags "production,automated"
 
-  # Start and wait for completion
-  globus flows start FLOW_ID --input-file input.json --wait`,
+  # Start and follow the run until it finishes (see 'flows run watch')
+  globus flows start FLOW_ID --input-file input.json --wait --timeout 1h
+
+  # Answer questions for the required input
+  globus flows start FLOW_ID --prompt-input`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFlowsStart,
 }
@@ -59,15 +76,18 @@ func init() {
 	StartCmd.Flags().StringArrayVar(&startManagers, "manager", nil, "A principal that may manage the execution of the run (repeatable)")
 	StartCmd.Flags().StringArrayVar(&startMonitors, "monitor", nil, "A principal that may monitor the execution of the run (repeatable)")
 	StartCmd.Flags().StringSliceVar(&startNotify, "activity-notification-policy", nil, "Comma-separated run statuses that trigger notifications (INACTIVE, SUCCEEDED, FAILED)")
-	StartCmd.Flags().BoolVar(&startWait, "wait", false, "Wait for flow to complete")
+	StartCmd.Flags().BoolVar(&startWait, "wait", false, "Follow the run as 'flows run watch' does until it finishes")
+	StartCmd.Flags().DurationVar(&startTimeout, "timeout", defaultRunWatchTimeout, "Give up waiting after this long (with --wait)")
+	StartCmd.Flags().BoolVar(&startPromptInput, "prompt-input", false, "Ask for each required input property the input does not set")
+	StartCmd.Flags().BoolVar(&startSkipValidation, "skip-validation", false, "Do not check the input against the flow's input schema before starting")
 }
 
 func runFlowsStart(cmd *cobra.Command, args []string) error {
 	flowID := args[0]
 
 	// Validate input
-	if startInputFile == "" && startInputJSON == "" {
-		return fmt.Errorf("either --input-file or --input must be provided")
+	if startInputFile == "" && startInputJSON == "" && !startPromptInput {
+		return fmt.Errorf("one of --input-file, --input or --prompt-input must be provided")
 	}
 	if startInputFile != "" && startInputJSON != "" {
 		return fmt.Errorf("cannot specify both --input-file and --input")
@@ -82,14 +102,53 @@ func runFlowsStart(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to read input file: %w", err)
 		}
-	} else {
+	} else if startInputJSON != "" {
 		inputJSON = []byte(startInputJSON)
 	}
 
 	// Parse input
-	var input map[string]interface{}
-	if err := json.Unmarshal(inputJSON, &input); err != nil {
-		return fmt.Errorf("failed to parse input JSON: %w", err)
+	input := map[string]interface{}{}
+	if inputJSON != nil {
+		if err := json.Unmarshal(inputJSON, &input); err != nil {
+			return fmt.Errorf("failed to parse input JSON: %w", err)
+		}
+	}
+
+	// Build a v4 Flows client authorized for the current profile.
+	setupCtx, setupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer setupCancel()
+	flowsClient, err := getClient(setupCtx)
+	if err != nil {
+		return err
+	}
+
+	// Check the input against the flow's input schema, asking for what is
+	// missing first when prompting.
+	if startPromptInput || !startSkipValidation {
+		flow, err := flowsClient.GetFlow(setupCtx, flowID)
+		if err != nil {
+			return fmt.Errorf("error getting flow: %w", err)
+		}
+		schema := flow.InputSchema
+		if schema == nil {
+			schema = map[string]interface{}{}
+		}
+		if startPromptInput {
+			if input, err = promptInput(cmd.InOrStdin(), cmd.ErrOrStderr(), schema, input); err != nil {
+				return err
+			}
+			inputText, _ := json.Marshal(input)
+			fmt.Fprintf(cmd.ErrOrStderr(), "\nInput: %s\n\n", inputText)
+		}
+		if !startSkipValidation {
+			if violations := validateInput(schema, input); len(violations) > 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Input does not match the flow's input schema:")
+				for _, v := range violations {
+					fmt.Fprintf(cmd.ErrOrStderr(), "  %s: %s\n", v.Path, v.Message)
+				}
+				return fmt.Errorf("input is invalid (%d problem(s)); no run was started", len(violations))
+			}
+		}
 	}
 
 	// Create context with timeout
@@ -97,18 +156,12 @@ func runFlowsStart(cmd *cobra.Command, args []string) error {
 	var cancel context.CancelFunc
 	if startWait {
 		// Longer timeout for waiting
-		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Minute)
+		ctx, cancel = context.WithTimeout(context.Background(), startTimeout)
 	} else {
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
 	}
 	defer cancel()
 
-	// Build a v4 Flows client authorized for the current profile.
-	flowsClient, err := getClient(ctx)
-	if err != nil {
-		return err
-	}
-
 	// Build run input. In v4 the flow ID is passed to RunFlow directly and the
 	// first-state input goes under Body.
 	runInput := &flows.FlowInput{
@@ -139,26 +192,13 @@ func runFlowsStart(cmd *cobra.Command, args []string) error {
 	if startWait {
 		fmt.Fprintf(os.Stdout, "\nWaiting for flow to complete...\n")
 
-		finalRun, err := flowsClient.WaitForRun(ctx, run.RunID, 5*time.Second)
+		finalRun, err := watchRun(ctx, flowsClient, run.RunID, 5*time.Second, cmd.ErrOrStderr())
 		if err != nil {
-			return fmt.Errorf("error waiting for flow completion: %w", err)
-		}
-
-		fmt.Fprintf(os.Stdout, "\nFlow completed!\n")
-		fmt.Fprintf(os.Stdout, "Final Status:  %s\n", finalRun.Status)
-		if !finalRun.EndTime.IsZero() {
-			fmt.Fprintf(os.Stdout, "Completed At:  %s\n", finalRun.EndTime.Format(time.RFC3339))
+			return err
 		}
-
-		// Display details if available
-		if finalRun.Details != nil {
-			fmt.Fprintf(os.Stdout, "\nDetails:\n")
-			detailsJSON, _ := json.MarshalIndent(finalRun.Details, "  ", "  ")
-			fmt.Fprintf(os.Stdout, "%s\n", string(detailsJSON))
-		}
-	} else {
-		fmt.Fprintf(os.Stdout, "\nMonitor run status with: globus flows run show %s\n", run.RunID)
+		return reportRunOutcome(cmd, finalRun)
 	}
 
+	fmt.Fprintf(os.Stdout, "\nMonitor run status with: globus flows run watch %s\n", run.RunID)
 	return nil
 }
@@ -4,7 +4,6 @@ package flows
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -68,8 +67,8 @@ func init() {
 	UpdateCmd.Flags().StringVar(&updateTitle, "title", "", "Flow title")
 	UpdateCmd.Flags().StringVar(&updateDescription, "description", "", "Flow description")
 	UpdateCmd.Flags().StringVar(&updateSubtitle, "subtitle", "", "A concise summary of the flow's purpose")
-	UpdateCmd.Flags().StringVar(&updateDefinitionFile, "definition-file", "", "Path to flow definition JSON file")
-	UpdateCmd.Flags().StringVar(&updateSchemaFile, "schema-file", "", "Path to input schema JSON file")
+	UpdateCmd.Flags().StringVar(&updateDefinitionFile, "definition-file", "", "Path to flow definition JSON or YAML file")
+	UpdateCmd.Flags().StringVar(&updateSchemaFile, "schema-file", "", "Path to input schema JSON or YAML file")
 	UpdateCmd.Flags().StringSliceVar(&updateKeywords, "keywords", []string{}, "Comma-separated list of keywords (empty string clears)")
 
 	UpdateCmd.Flags().StringVar(&updateOwner, "owner", "", "Assign ownership to your Globus Auth principal ID (you must already be a flow administrator)")
@@ -137,29 +136,19 @@ func runFlowsUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if updateDefinitionFile != "" {
-		definitionData, err := os.ReadFile(updateDefinitionFile)
+		definitionDoc, err := loadFlowDocument(updateDefinitionFile)
 		if err != nil {
 			return fmt.Errorf("failed to read definition file: %w", err)
 		}
-
-		var definition map[string]interface{}
-		if err := json.Unmarshal(definitionData, &definition); err != nil {
-			return fmt.Errorf("failed to parse definition JSON: %w", err)
-		}
-		request.Definition = definition
+		request.Definition = definitionDoc.Data
 	}
 
 	if updateSchemaFile != "" {
-		schemaData, err := os.ReadFile(updateSchemaFile)
+		schemaDoc, err := loadFlowDocument(updateSchemaFile)
 		if err != nil {
 			return fmt.Errorf("failed to read schema file: %w", err)
 		}
-
-		var inputSchema map[string]interface{}
-		if err := json.Unmarshal(schemaData, &inputSchema); err != nil {
-			return fmt.Errorf("failed to parse schema JSON: %w", err)
-		}
-		request.InputSchema = inputSchema
+		request.InputSchema = schemaDoc.Data
 	}
 
 	if len(updateKeywords) > 0 {
@@ -4,7 +4,6 @@ package flows
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -14,7 +13,10 @@ import (
 	"github.com/spf13/viper"
 )
 
-var validateSchemaFile string
+var (
+	validateSchemaFile string
+	validateOffline    bool
+)
 
 // ValidateCmd represents the flows validate command
 var ValidateCmd = &cobra.Command{
@@ -23,41 +25,55 @@ var ValidateCmd = &cobra.Command{
 	Long: `Validate a flow definition against the Globus Flows schema, without
 creating a flow.
 
-DEFINITION_FILE is a path to a JSON file containing the flow definition.
+DEFINITION_FILE is a path to a JSON or YAML file containing the flow
+definition; includes are compiled in as for 'globus flows create'.
+
+With --offline the definition is checked locally, without logging in: StartAt
+and every Next, Default and Catch target must name a state, every state must
+be reachable and lead to an End or Fail state, Choice rules must be well
+formed, and Parameters, ResultPath and other JSONPath fields must parse.
+ActionUrl values that are not Globus action providers produce warnings. With
+--input-schema, the schema's structure is checked too, and JSONPaths reading
+input names the schema does not define are reported. Each finding is printed
+as FILE:LINE: SEVERITY: POINTER: MESSAGE, and the command exits 1 when there
+are errors, so it can run as a pre-commit hook.
 
 Examples:
   # Validate a flow definition
   globus flows validate flow_definition.json
 
   # Validate with an input schema
-  globus flows validate flow_definition.json --input-schema schema.json`,
+  globus flows validate flow_definition.json --input-schema schema.json
+
+  # Lint a definition without credentials
+  globus flows validate flow_definition.json --offline`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFlowsValidate,
 }
 
 func init() {
-	ValidateCmd.Flags().StringVar(&validateSchemaFile, "input-schema", "", "Path to an input schema JSON file")
+	ValidateCmd.Flags().StringVar(&validateSchemaFile, "input-schema", "", "Path to an input schema JSON or YAML file")
+	ValidateCmd.Flags().BoolVar(&validateOffline, "offline", false, "Check the definition locally instead of with Globus Flows")
 }
 
 func runFlowsValidate(cmd *cobra.Command, args []string) error {
-	definitionData, err := os.ReadFile(args[0])
+	if validateOffline {
+		return runFlowsValidateOffline(cmd, args[0])
+	}
+
+	definitionDoc, err := loadFlowDocument(args[0])
 	if err != nil {
 		return fmt.Errorf("error reading definition file: %w", err)
 	}
-	var definition map[string]interface{}
-	if err := json.Unmarshal(definitionData, &definition); err != nil {
-		return fmt.Errorf("error parsing definition JSON: %w", err)
-	}
+	definition := definitionDoc.Data
 
 	var inputSchema map[string]interface{}
 	if validateSchemaFile != "" {
-		schemaData, err := os.ReadFile(validateSchemaFile)
+		schemaDoc, err := loadFlowDocument(validateSchemaFile)
 		if err != nil {
 			return fmt.Errorf("error reading input schema file: %w", err)
 		}
-		if err := json.Unmarshal(schemaData, &inputSchema); err != nil {
-			return fmt.Errorf("error parsing input schema JSON: %w", err)
-		}
+		inputSchema = schemaDoc.Data
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -80,3 +96,63 @@ func runFlowsValidate(cmd *cobra.Command, args []string) error {
 	fmt.Fprintln(cmd.OutOrStdout(), "Flow definition is valid.")
 	return nil
 }
+
+// validateReport is the structured output of validate --offline.
+type validateReport struct {
+	Valid    bool          `json:"valid"`
+	Findings []lintFinding `json:"findings"`
+}
+
+// runFlowsValidateOffline lints the definition locally and prints findings.
+func runFlowsValidateOffline(cmd *cobra.Command, path string) error {
+	def, err := loadFlowDocument(path)
+	if err != nil {
+		return fmt.Errorf("error reading definition file: %w", err)
+	}
+	var schema *flowDocument
+	if validateSchemaFile != "" {
+		if schema, err = loadFlowDocument(validateSchemaFile); err != nil {
+			return fmt.Errorf("error reading input schema file: %w", err)
+		}
+	}
+
+	report := validateReport{Valid: true, Findings: lintFlow(def, schema)}
+	warnings := 0
+	for _, f := range report.Findings {
+		if f.Severity == lintError {
+			report.Valid = false
+		} else {
+			warnings++
+		}
+	}
+	if report.Findings == nil {
+		report.Findings = []lintFinding{}
+	}
+
+	formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+	if formatter.Format != output.FormatText {
+		if err := formatter.FormatOutput(report, nil); err != nil {
+			return err
+		}
+	} else {
+		w := cmd.OutOrStdout()
+		for _, f := range report.Findings {
+			fmt.Fprintf(w, "%s:%d: %s: %s: %s\n", f.File, f.Line, f.Severity, f.Pointer, f.Message)
+		}
+		switch {
+		case !report.Valid:
+		case warnings > 0:
+			fmt.Fprintf(w, "Flow definition is valid (%d warning(s)).\n", warnings)
+		default:
+			fmt.Fprintln(w, "Flow definition is valid.")
+		}
+	}
+
+	if !report.Valid {
+		// The findings are the verdict: don't follow them with usage text.
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &output.ExitCodeError{Code: 1}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/spf13/viper"
 
@@ -40,3 +41,8 @@ func getClient(ctx context.Context) (*transfer.Client, error) {
 	}
 	return client, nil
 }
+
+// endpointPath is the Transfer API path of an endpoint document.
+func endpointPath(endpointID string) string {
+	return "/v0.10/endpoint/" + url.PathEscape(endpointID)
+}
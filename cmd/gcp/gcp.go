@@ -24,10 +24,12 @@ func GCPCmd() *cobra.Command {
 These commands operate on the Globus service (registering and updating GCP
 endpoints and their collections); they do NOT install, start, or stop a local
 Globus Connect Personal agent. 'gcp create mapped' registers an endpoint with
-Globus and prints a setup key you use to configure an installed GCP agent.`,
+Globus and prints a setup key you use to configure an installed GCP agent;
+'gcp setup-key' shows or regenerates it later. 'gcp status' reports on the
+local installation and its connection state.`,
 	}
 
-	gcpCmd.AddCommand(gcpCreateCmd(), gcpSetSubscriptionIDCmd())
+	gcpCmd.AddCommand(gcpCreateCmd(), gcpSetSubscriptionIDCmd(), gcpSetupKeyCmd(), gcpStatusCmd())
 	return gcpCmd
 }
 
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	transfercmd "github.com/scttfrdmn/globus-go-cli/cmd/transfer"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// endpointDocClient is the raw Transfer call the setup-key and status
+// commands make.
+type endpointDocClient interface {
+	DoRequest(ctx context.Context, method, endpoint string, query url.Values, body interface{}, result interface{}) error
+}
+
+// setupKeyResult is what gcp setup-key reports.
+type setupKeyResult struct {
+	EndpointID  string `json:"endpoint_id"`
+	DisplayName string `json:"display_name"`
+	SetupKey    string `json:"setup_key"`
+	Regenerated bool   `json:"regenerated"`
+}
+
+// gcpSetupKeyCmd returns the gcp setup-key command.
+func gcpSetupKeyCmd() *cobra.Command {
+	var regenerate bool
+	cmd := &cobra.Command{
+		Use:   "setup-key ENDPOINT_ID",
+		Short: "Show or regenerate a Globus Connect Personal setup key",
+		Long: `Print the setup key of a Globus Connect Personal endpoint you own, for
+configuring an installed agent with 'globusconnectpersonal -setup KEY'.
+
+Transfer only shows a setup key until an agent has used it. Once the endpoint
+is set up, --regenerate asks Transfer to issue a new key, for reinstalling the
+agent on a new machine.`,
+		Example: `  globus gcp setup-key ENDPOINT_ID
+  globus gcp setup-key ENDPOINT_ID --regenerate`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			client, err := transfercmd.NewRawClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			result, err := endpointSetupKey(ctx, client, args[0], regenerate)
+			if err != nil {
+				return err
+			}
+
+			formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+			if formatter.Format == output.FormatJSON || formatter.Format == output.FormatUnix {
+				return formatter.FormatOutput(result, nil)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Endpoint ID: %s\n", result.EndpointID)
+			fmt.Fprintf(cmd.OutOrStdout(), "Setup Key:   %s\n", result.SetupKey)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&regenerate, "regenerate", false, "Issue a new setup key, replacing any unused one")
+	return cmd
+}
+
+// endpointSetupKey reads the endpoint's setup key, first asking Transfer for a
+// new one when regenerate is set.
+func endpointSetupKey(ctx context.Context, client endpointDocClient, endpointID string, regenerate bool) (*setupKeyResult, error) {
+	doc, err := getEndpointDoc(ctx, client, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if isGCP, _ := doc["is_globus_connect"].(bool); !isGCP {
+		return nil, fmt.Errorf("endpoint %s is not a Globus Connect Personal endpoint", endpointID)
+	}
+
+	if regenerate {
+		// A null setup key asks Transfer to issue a fresh one.
+		update := map[string]interface{}{"DATA_TYPE": "endpoint", "globus_connect_setup_key": nil}
+		if err := client.DoRequest(ctx, "PUT", endpointPath(endpointID), nil, update, nil); err != nil {
+			return nil, fmt.Errorf("failed to regenerate setup key: %w", err)
+		}
+		if doc, err = getEndpointDoc(ctx, client, endpointID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &setupKeyResult{EndpointID: endpointID, Regenerated: regenerate}
+	result.DisplayName, _ = doc["display_name"].(string)
+	result.SetupKey, _ = doc["globus_connect_setup_key"].(string)
+	if result.SetupKey == "" {
+		if regenerate {
+			return nil, fmt.Errorf("transfer did not issue a new setup key for endpoint %s; you must own the endpoint", endpointID)
+		}
+		return nil, fmt.Errorf("endpoint %s has no setup key: it has already been set up (use --regenerate to issue a new one) or you do not own it", endpointID)
+	}
+	return result, nil
+}
+
+// getEndpointDoc fetches an endpoint's raw Transfer document.
+func getEndpointDoc(ctx context.Context, client endpointDocClient, endpointID string) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := client.DoRequest(ctx, "GET", endpointPath(endpointID), nil, nil, &doc); err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s: %w", endpointID, err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// fakeTransfer serves one endpoint document and records PUTs.
+type fakeTransfer struct {
+	doc  map[string]interface{}
+	puts []map[string]interface{}
+	// issue is the key Transfer hands out after a regenerate request.
+	issue string
+}
+
+func (f *fakeTransfer) DoRequest(_ context.Context, method, path string, _ url.Values, body, result interface{}) error {
+	switch method {
+	case "GET":
+		raw, _ := json.Marshal(f.doc)
+		return json.Unmarshal(raw, result)
+	case "PUT":
+		f.puts = append(f.puts, body.(map[string]interface{}))
+		if f.issue != "" {
+			f.doc["globus_connect_setup_key"] = f.issue
+		}
+	}
+	return nil
+}
+
+// TestEndpointSetupKey checks showing and regenerating a setup key.
+func TestEndpointSetupKey(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &fakeTransfer{doc: map[string]interface{}{"is_globus_connect": true, "display_name": "laptop", "globus_connect_setup_key": "key-1"}}
+	result, err := endpointSetupKey(ctx, fake, "ep", false)
+	if err != nil || result.SetupKey != "key-1" || result.DisplayName != "laptop" || len(fake.puts) != 0 {
+		t.Fatalf("result = %+v, err = %v", result, err)
+	}
+
+	fake = &fakeTransfer{doc: map[string]interface{}{"is_globus_connect": true, "globus_connect_setup_key": nil}, issue: "key-2"}
+	if _, err := endpointSetupKey(ctx, fake, "ep", false); err == nil || !strings.Contains(err.Error(), "--regenerate") {
+		t.Errorf("used key: err = %v", err)
+	}
+	result, err = endpointSetupKey(ctx, fake, "ep", true)
+	if err != nil || result.SetupKey != "key-2" || !result.Regenerated {
+		t.Fatalf("regenerate: result = %+v, err = %v", result, err)
+	}
+	if len(fake.puts) != 1 || fake.puts[0]["globus_connect_setup_key"] != nil {
+		t.Errorf("puts = %v", fake.puts)
+	}
+
+	fake = &fakeTransfer{doc: map[string]interface{}{"is_globus_connect": false}}
+	if _, err := endpointSetupKey(ctx, fake, "ep", true); err == nil || len(fake.puts) != 0 {
+		t.Errorf("non-GCP endpoint: err = %v, puts = %v", err, fake.puts)
+	}
+}
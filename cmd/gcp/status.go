@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package gcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	transfercmd "github.com/scttfrdmn/globus-go-cli/cmd/transfer"
+	"github.com/scttfrdmn/globus-go-cli/pkg/gcpconfig"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// gcpProcessNames are the executable names of a running GCP agent on Linux
+// (the globusconnectpersonal script and its gc.py helper) and macOS (the
+// application bundle's executable).
+var gcpProcessNames = []string{"globusconnectpersonal", "gc.py", "Globus Connect Personal"}
+
+// gcpConfigPath is one line of the agent's config-paths file.
+type gcpConfigPath struct {
+	Path      string `json:"path"`
+	Shareable bool   `json:"shareable"`
+	Writable  bool   `json:"writable"`
+}
+
+// gcpStatus is what gcp status reports. Running, Connected and Paused are
+// nil when unknown.
+type gcpStatus struct {
+	ConfigDir       string          `json:"config_dir"`
+	Installed       bool            `json:"installed"`
+	EndpointID      string          `json:"endpoint_id,omitempty"`
+	EndpointIDFile  string          `json:"endpoint_id_file"`
+	ConfigPathsFile string          `json:"config_paths_file,omitempty"`
+	ConfigPaths     []gcpConfigPath `json:"config_paths,omitempty"`
+	Running         *bool           `json:"running"`
+	PID             int             `json:"pid,omitempty"`
+	DisplayName     string          `json:"display_name,omitempty"`
+	Connected       *bool           `json:"gcp_connected"`
+	Paused          *bool           `json:"gcp_paused"`
+	TransferError   string          `json:"transfer_error,omitempty"`
+}
+
+// gcpStatusCmd returns the gcp status command.
+func gcpStatusCmd() *cobra.Command {
+	var configDir string
+	var offline bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the local Globus Connect Personal installation's status",
+		Long: `Report on the Globus Connect Personal installation for the current user:
+its endpoint ID and configuration files (read from ~/.globusonline, as
+'endpoint local-id' does), the paths it shares, and whether the agent process
+is running. The endpoint's connected and paused state is then read from
+Transfer; --offline skips that call.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configDir == "" {
+				var err error
+				if configDir, err = gcpconfig.DefaultDir(); err != nil {
+					return err
+				}
+			}
+
+			status, err := localGCPStatus(configDir)
+			if err != nil {
+				return err
+			}
+			if !status.Installed {
+				return fmt.Errorf("no Globus Connect Personal installation found in %s", configDir)
+			}
+			status.Running, status.PID = findGCPProcess()
+
+			if !offline {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				client, err := transfercmd.NewRawClient(ctx)
+				if err == nil {
+					err = remoteGCPStatus(ctx, client, status)
+				}
+				if err != nil {
+					status.TransferError = err.Error()
+				}
+			}
+
+			formatter := output.NewFormatter(viper.GetString("format"), cmd.OutOrStdout())
+			if formatter.Format == output.FormatJSON || formatter.Format == output.FormatUnix {
+				return formatter.FormatOutput(status, nil)
+			}
+			printGCPStatus(cmd.OutOrStdout(), status, offline)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&configDir, "config-dir", "", "GCP configuration directory (default ~/.globusonline)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Report only local state; do not contact Transfer")
+	return cmd
+}
+
+// localGCPStatus reads the installation in configDir. The endpoint ID lives in
+// <config>/lta/client-id.txt (<config>/client-id.txt on Windows), as
+// globus_sdk.LocalGlobusConnectPersonal reads it, with config-paths beside it.
+// A missing ID file means no installation.
+func localGCPStatus(configDir string) (*gcpStatus, error) {
+	status := &gcpStatus{ConfigDir: configDir, EndpointIDFile: gcpconfig.EndpointIDFile(configDir)}
+
+	id, err := gcpconfig.ReadEndpointID(configDir)
+	if err != nil {
+		return nil, err
+	}
+	status.EndpointID = id
+	status.Installed = id != ""
+	if !status.Installed {
+		return status, nil
+	}
+
+	pathsFile := filepath.Join(gcpconfig.AgentDir(configDir), "config-paths")
+	f, err := os.Open(pathsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return status, nil
+		}
+		return nil, fmt.Errorf("cannot read %s: %w", pathsFile, err)
+	}
+	defer func() { _ = f.Close() }()
+	status.ConfigPathsFile = pathsFile
+	status.ConfigPaths, err = parseConfigPaths(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", pathsFile, err)
+	}
+	return status, nil
+}
+
+// parseConfigPaths parses config-paths lines of the form PATH,SHARE,RW where
+// SHARE and RW are 0 or 1. Blank lines and # comments are skipped.
+func parseConfigPaths(r io.Reader) ([]gcpConfigPath, error) {
+	var paths []gcpConfigPath
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// The path itself may contain commas, so split from the right.
+		fields := strings.Split(line, ",")
+		p := gcpConfigPath{Path: line}
+		if len(fields) >= 3 {
+			p.Path = strings.Join(fields[:len(fields)-2], ",")
+			p.Shareable = strings.TrimSpace(fields[len(fields)-2]) == "1"
+			p.Writable = strings.TrimSpace(fields[len(fields)-1]) == "1"
+		}
+		paths = append(paths, p)
+	}
+	return paths, scanner.Err()
+}
+
+// remoteGCPStatus fills in the endpoint's Transfer connection state.
+func remoteGCPStatus(ctx context.Context, client endpointDocClient, status *gcpStatus) error {
+	doc, err := getEndpointDoc(ctx, client, status.EndpointID)
+	if err != nil {
+		return err
+	}
+	status.DisplayName, _ = doc["display_name"].(string)
+	if v, ok := doc["gcp_connected"].(bool); ok {
+		status.Connected = &v
+	}
+	if v, ok := doc["gcp_paused"].(bool); ok {
+		status.Paused = &v
+	}
+	return nil
+}
+
+// findGCPProcess looks for a running agent. The bool is nil when processes
+// cannot be listed on this platform.
+func findGCPProcess() (*bool, int) {
+	scan := pgrepGCP
+	if runtime.GOOS == "linux" {
+		scan = func() (int, error) { return scanProcDir("/proc", os.Getpid()) }
+	}
+	pid, err := scan()
+	if err != nil {
+		return nil, 0
+	}
+	running := pid != 0
+	return &running, pid
+}
+
+// scanProcDir returns the first process under a /proc-style root whose
+// command line runs one of gcpProcessNames, skipping self.
+func scanProcDir(root string, self int) (int, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join(root, e.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		if matchesGCPProcess(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")) {
+			return pid, nil
+		}
+	}
+	return 0, nil
+}
+
+// pgrepGCP uses pgrep where there is no /proc. Each name must end a path
+// or the executable itself, so a command that merely mentions it does not
+// match.
+func pgrepGCP() (int, error) {
+	if _, err := exec.LookPath("pgrep"); err != nil {
+		return 0, err
+	}
+	for _, name := range gcpProcessNames {
+		out, err := exec.Command("pgrep", "-f", "(^|/)"+regexp.QuoteMeta(name)+"( |$)").Output()
+		if err != nil {
+			// pgrep exits 1 when nothing matches.
+			continue
+		}
+		if pid, err := strconv.Atoi(strings.Fields(string(out) + " 0")[0]); err == nil && pid != 0 {
+			return pid, nil
+		}
+	}
+	return 0, nil
+}
+
+// matchesGCPProcess reports whether a command line runs a GCP agent: its
+// executable, or the script an interpreter runs, is one of gcpProcessNames.
+func matchesGCPProcess(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	program := filepath.Base(args[0])
+	if isScriptInterpreter(program) {
+		for _, arg := range args[1:] {
+			if !strings.HasPrefix(arg, "-") {
+				program = filepath.Base(arg)
+				break
+			}
+		}
+	}
+	for _, name := range gcpProcessNames {
+		if program == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isScriptInterpreter reports whether program runs the script named by its
+// first non-flag argument.
+func isScriptInterpreter(program string) bool {
+	switch program {
+	case "sh", "bash", "dash":
+		return true
+	}
+	return strings.HasPrefix(program, "python")
+}
+
+// printGCPStatus prints the status in text form.
+func printGCPStatus(w io.Writer, s *gcpStatus, offline bool) {
+	fmt.Fprintf(w, "Endpoint ID:       %s\n", s.EndpointID)
+	fmt.Fprintf(w, "Config directory:  %s\n", s.ConfigDir)
+	fmt.Fprintf(w, "Endpoint ID file:  %s\n", s.EndpointIDFile)
+	if s.ConfigPathsFile != "" {
+		fmt.Fprintf(w, "Config paths file: %s\n", s.ConfigPathsFile)
+		for _, p := range s.ConfigPaths {
+			mode := "r"
+			if p.Writable {
+				mode = "rw"
+			}
+			if p.Shareable {
+				mode += ", shareable"
+			}
+			fmt.Fprintf(w, "  %s (%s)\n", p.Path, mode)
+		}
+	}
+
+	switch {
+	case s.Running == nil:
+		fmt.Fprintln(w, "Process:           unknown")
+	case *s.Running:
+		fmt.Fprintf(w, "Process:           running (pid %d)\n", s.PID)
+	default:
+		fmt.Fprintln(w, "Process:           not running")
+	}
+
+	if offline {
+		return
+	}
+	if s.TransferError != "" {
+		fmt.Fprintf(w, "Transfer:          unavailable (%s)\n", s.TransferError)
+		return
+	}
+	fmt.Fprintf(w, "Display name:      %s\n", s.DisplayName)
+	fmt.Fprintf(w, "Connected:         %s\n", yesNoUnknown(s.Connected))
+	fmt.Fprintf(w, "Paused:            %s\n", yesNoUnknown(s.Paused))
+}
+
+// yesNoUnknown renders an optional bool.
+func yesNoUnknown(v *bool) string {
+	switch {
+	case v == nil:
+		return "unknown"
+	case *v:
+		return "yes"
+	default:
+		return "no"
+	}
+}
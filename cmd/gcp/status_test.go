@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package gcp
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestLocalGCPStatus checks reading an installation's config directory.
+func TestLocalGCPStatus(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the POSIX config layout")
+	}
+	dir := t.TempDir()
+
+	status, err := localGCPStatus(dir)
+	if err != nil || status.Installed {
+		t.Fatalf("empty dir: status = %+v, err = %v", status, err)
+	}
+
+	lta := filepath.Join(dir, "lta")
+	if err := os.MkdirAll(lta, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(lta, "client-id.txt"), []byte("ep-123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	paths := "# shared paths\n~/,0,1\n/data/a,b,1,0\n\n"
+	if err := os.WriteFile(filepath.Join(lta, "config-paths"), []byte(paths), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = localGCPStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Installed || status.EndpointID != "ep-123" || status.ConfigPathsFile == "" {
+		t.Errorf("status = %+v", status)
+	}
+	want := []gcpConfigPath{{Path: "~/", Writable: true}, {Path: "/data/a,b", Shareable: true}}
+	if len(status.ConfigPaths) != 2 || status.ConfigPaths[0] != want[0] || status.ConfigPaths[1] != want[1] {
+		t.Errorf("config paths = %+v", status.ConfigPaths)
+	}
+}
+
+// TestScanProcDir checks agent detection against a fake /proc.
+func TestScanProcDir(t *testing.T) {
+	root := t.TempDir()
+	write := func(pid, cmdline string) {
+		if err := os.MkdirAll(filepath.Join(root, pid), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, pid, "cmdline"), []byte(cmdline), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("10", "bash\x00-l\x00")
+	write("20", "globus\x00gcp\x00status\x00")
+	write("self", "ignored")
+
+	if pid, err := scanProcDir(root, 0); err != nil || pid != 0 {
+		t.Errorf("no agent: pid = %d, err = %v", pid, err)
+	}
+
+	write("11", "grep\x00gc.py\x00log\x00")
+	write("12", "/usr/bin/python3\x00/home/me/gc.py.bak/run.py\x00")
+	write("30", "/usr/bin/python3\x00-u\x00/opt/globusconnectpersonal/gc.py\x00-start\x00")
+	if pid, err := scanProcDir(root, 0); err != nil || pid != 30 {
+		t.Errorf("agent: pid = %d, err = %v", pid, err)
+	}
+	if pid, _ := scanProcDir(root, 30); pid != 0 {
+		t.Errorf("self not skipped: pid = %d", pid)
+	}
+}
+
+// TestPrintGCPStatus checks the text report.
+func TestPrintGCPStatus(t *testing.T) {
+	running, connected := true, false
+	status := &gcpStatus{EndpointID: "ep-123", ConfigDir: "/home/u/.globusonline", Running: &running, PID: 42, DisplayName: "laptop", Connected: &connected}
+
+	var out strings.Builder
+	printGCPStatus(&out, status, false)
+	for _, want := range []string{"Endpoint ID:       ep-123", "running (pid 42)", "Connected:         no", "Paused:            unknown"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	status.TransferError = "not logged in"
+	printGCPStatus(&out, status, false)
+	if !strings.Contains(out.String(), "Transfer:          unavailable (not logged in)") || strings.Contains(out.String(), "Connected") {
+		t.Errorf("transfer error report:\n%s", out.String())
+	}
+}
@@ -88,14 +88,18 @@ func ExecuteCmd() error {
 
 // ExitCode runs the root command and returns the process exit code, honoring
 // --map-http-status: if the command fails with an error carrying an HTTP status
-// that the user mapped, that mapped code is returned. Otherwise a non-nil error
-// yields 1 and success yields 0. The error (if any) is also returned so the
-// caller can print it.
+// that the user mapped, that mapped code is returned. An output.ExitCodeError
+// sets the code directly. Otherwise a non-nil error yields 1 and success yields
+// 0. The error (if any) is also returned so the caller can print it.
 func ExitCode() (int, error) {
 	err := rootCmd.Execute()
 	if err == nil {
 		return 0, nil
 	}
+	var exitErr *output.ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code, exitErr.Err
+	}
 	if statusMap, perr := output.ParseHTTPStatusMap(mapHTTPStatus); perr == nil {
 		if code, ok := output.ExitCodeForError(wrapHTTPStatus(err), statusMap); ok {
 			return code, err
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/scttfrdmn/globus-go-cli/pkg/config"
 	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -43,6 +44,7 @@ var (
 	createTransferLabel             string
 	createTransferInclude           []string
 	createTransferExclude           []string
+	createTransferTemplate          string
 )
 
 // CreateTransferCmd represents the timer create transfer command
@@ -76,6 +78,14 @@ Examples:
     --include "*.txt" \
     --exclude "temp/*"
 
+  # Reuse options saved with 'globus transfer template save'
+  globus timer create transfer \
+    --name "Nightly Archive" \
+    --source SOURCE_EP:/data \
+    --dest DEST_EP:/archive \
+    --interval P1D \
+    --template nightly
+
   # Directory mirroring (delete extra files)
   globus timer create transfer \
     --name "Mirror Directories" \
@@ -104,6 +114,7 @@ func init() {
 	CreateTransferCmd.Flags().StringVar(&createTransferLabel, "label", "", "A label for the Transfer tasks submitted by the timer")
 	CreateTransferCmd.Flags().StringArrayVar(&createTransferInclude, "include", []string{}, "Include patterns")
 	CreateTransferCmd.Flags().StringArrayVar(&createTransferExclude, "exclude", []string{}, "Exclude patterns")
+	CreateTransferCmd.Flags().StringVar(&createTransferTemplate, "template", "", "Apply a saved transfer template (see 'globus transfer template'); explicit flags override it")
 
 	_ = CreateTransferCmd.MarkFlagRequired("name")
 	_ = CreateTransferCmd.MarkFlagRequired("source")
@@ -166,6 +177,19 @@ func runCreateTransferTimer(cmd *cobra.Command, args []string) error {
 		transferBody["label"] = createTransferLabel
 	}
 
+	// Options only a template can carry (this command has no flags for them)
+	// go straight into the task document.
+	if createTransferTemplate != "" {
+		tmpl, err := config.LoadTransferTemplate(createTransferTemplate)
+		if err != nil {
+			return err
+		}
+		if err := applyTransferTemplate(cmd, tmpl, transferBody); err != nil {
+			return err
+		}
+		transferItem["recursive"] = createTransferRecursive
+	}
+
 	// Add filter rules if specified
 	if len(createTransferInclude) > 0 || len(createTransferExclude) > 0 {
 		filterRules := []map[string]string{}
@@ -274,3 +298,89 @@ func runCreateTransferTimer(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// applyTransferTemplate merges a saved transfer template into the timer's
+// transfer document. Options this command has flags for are applied to the
+// flag variables (and body) unless set explicitly on the command line; the
+// rest are written directly into body. Template deadlines are dates
+// (YYYY-MM-DD) meant for one-off submissions and are not applied to a
+// recurring timer.
+func applyTransferTemplate(cmd *cobra.Command, tmpl *config.TransferTemplate, body map[string]interface{}) error {
+	flags := cmd.Flags()
+	setBool := func(dst *bool, v *bool, flag, key string) {
+		if v != nil && !flags.Changed(flag) {
+			*dst = *v
+			body[key] = *v
+		}
+	}
+
+	if tmpl.SyncLevel != nil && !flags.Changed("sync-level") {
+		createTransferSyncLevel = *tmpl.SyncLevel
+		body["sync_level"] = createTransferSyncLevel
+	}
+	if tmpl.Recursive != nil && !flags.Changed("recursive") {
+		// Recursion is per item, not per task; the caller copies it onto the
+		// transfer item.
+		createTransferRecursive = *tmpl.Recursive
+	}
+	setBool(&createTransferVerifyChecksum, tmpl.VerifyChecksum, "verify-checksum", "verify_checksum")
+	setBool(&createTransferPreserveTimestamp, tmpl.PreserveTimestamp, "preserve-timestamp", "preserve_timestamp")
+	setBool(&createTransferEncryptData, tmpl.EncryptData, "encrypt-data", "encrypt_data")
+	setBool(&createTransferDelete, tmpl.DeleteDestinationExtra, "delete", "delete_destination_extra")
+	if tmpl.Label != "" && !flags.Changed("label") {
+		createTransferLabel = tmpl.Label
+		body["label"] = tmpl.Label
+	}
+	if len(tmpl.Include) > 0 && !flags.Changed("include") {
+		createTransferInclude = tmpl.Include
+	}
+	if len(tmpl.Exclude) > 0 && !flags.Changed("exclude") {
+		createTransferExclude = tmpl.Exclude
+	}
+
+	if tmpl.SkipSourceErrors != nil {
+		body["skip_source_errors"] = *tmpl.SkipSourceErrors
+	}
+	if tmpl.FailOnQuotaErrors != nil {
+		body["fail_on_quota_errors"] = *tmpl.FailOnQuotaErrors
+	}
+	if tmpl.SourceLocalUser != "" {
+		body["source_local_user"] = tmpl.SourceLocalUser
+	}
+	if tmpl.DestinationLocalUser != "" {
+		body["destination_local_user"] = tmpl.DestinationLocalUser
+	}
+	if tmpl.ChecksumAlgorithm != "" {
+		if items, ok := body["DATA"].([]interface{}); ok {
+			for _, it := range items {
+				if item, ok := it.(map[string]interface{}); ok {
+					item["checksum_algorithm"] = tmpl.ChecksumAlgorithm
+				}
+			}
+		}
+	}
+	if len(tmpl.Notify) > 0 {
+		var succeeded, failed, inactive bool
+		for _, e := range tmpl.Notify {
+			switch strings.ToLower(strings.TrimSpace(e)) {
+			case "on":
+				succeeded, failed, inactive = true, true, true
+			case "off":
+				succeeded, failed, inactive = false, false, false
+			case "succeeded":
+				succeeded = true
+			case "failed":
+				failed = true
+			case "inactive":
+				inactive = true
+			case "":
+			default:
+				return fmt.Errorf("template has invalid notify value %q", e)
+			}
+		}
+		body["notify_on_succeeded"] = succeeded
+		body["notify_on_failed"] = failed
+		body["notify_on_inactive"] = inactive
+	}
+	return nil
+}
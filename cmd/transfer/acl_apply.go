@@ -0,0 +1,449 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// aclRuleSpec is one rule as written in an `endpoint permission apply` file.
+// Exactly one of the principal fields is set.
+type aclRuleSpec struct {
+	Identity              string `yaml:"identity,omitempty"`
+	Group                 string `yaml:"group,omitempty"`
+	AllAuthenticatedUsers bool   `yaml:"all_authenticated_users,omitempty"`
+	Anonymous             bool   `yaml:"anonymous,omitempty"`
+	Path                  string `yaml:"path"`
+	Permissions           string `yaml:"permissions"`
+	Expiration            string `yaml:"expiration,omitempty"`
+}
+
+// aclFile is the document read by `endpoint permission apply -f`.
+type aclFile struct {
+	Rules []aclRuleSpec `yaml:"rules"`
+}
+
+// aclRule is an access rule with its principal resolved to the form the
+// Transfer API stores. Name is the principal as the user wrote it (or the
+// ID, for live rules), for display only.
+type aclRule struct {
+	ID            string `json:"id,omitempty"`
+	PrincipalType string `json:"principal_type"`
+	Principal     string `json:"principal"`
+	Name          string `json:"principal_name,omitempty"`
+	Path          string `json:"path"`
+	Permissions   string `json:"permissions"`
+	Expiration    string `json:"expiration_date,omitempty"`
+}
+
+// key identifies the rule: Transfer allows one rule per principal and path.
+func (r aclRule) key() string {
+	return r.PrincipalType + "\x00" + r.Principal + "\x00" + r.Path
+}
+
+// principalLabel renders the rule's principal for plan output.
+func (r aclRule) principalLabel() string {
+	switch r.PrincipalType {
+	case "all_authenticated_users", "anonymous":
+		return r.PrincipalType
+	}
+	if r.Name != "" && r.Name != r.Principal {
+		return fmt.Sprintf("%s %s (%s)", r.PrincipalType, r.Name, r.Principal)
+	}
+	return r.PrincipalType + " " + r.Principal
+}
+
+// aclChange is one step of an apply plan. Current is the live rule an update
+// or delete acts on.
+type aclChange struct {
+	Action  string   `json:"action"`
+	Rule    aclRule  `json:"rule"`
+	Current *aclRule `json:"current,omitempty"`
+}
+
+// endpointPermissionApplyCmd returns the "endpoint permission apply" command.
+func endpointPermissionApplyCmd() *cobra.Command {
+	var (
+		file string
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply ENDPOINT_ID -f FILE",
+		Short: "Sync an endpoint's access rules to a YAML file",
+		Long: `Make an endpoint's access rules match the rules declared in a YAML file.
+
+The live rule list is compared with the file and a plan of creates, updates
+(permission or expiration changes) and deletes is printed. Nothing changes
+unless --yes is given. Rules on the endpoint that the file does not declare
+are deleted, so the file is the complete access policy.
+
+Each rule names its principal with exactly one of identity (a username or
+identity ID), group (the name of one of your groups, or a group ID),
+all_authenticated_users: true or anonymous: true:
+
+  rules:
+    - identity: alice@uchicago.edu
+      path: /projects/alpha/
+      permissions: rw
+    - group: Alpha Lab Members
+      path: /projects/alpha/
+      permissions: r
+      expiration: 2026-12-31
+    - anonymous: true
+      path: /public/
+      permissions: r
+
+Examples:
+  globus endpoint permission apply ENDPOINT_ID -f acls.yaml
+  globus endpoint permission apply ENDPOINT_ID -f acls.yaml --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyEndpointACLs(cmd, args[0], file, yes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file declaring the desired access rules")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Apply the plan instead of only printing it")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// applyEndpointACLs plans, prints and (with yes) applies an ACL file.
+func applyEndpointACLs(cmd *cobra.Command, endpointID, file string, yes bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	specs, err := loadACLFile(file)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	desired, err := resolveACLSpecs(ctx, newPrincipalResolver(), specs)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.EndpointACLList(ctx, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to list endpoint access rules: %w", err)
+	}
+	plan := planACLChanges(desired, liveACLRules(resp))
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		if err := formatter.FormatOutput(plan, nil); err != nil {
+			return err
+		}
+	} else {
+		printACLPlan(cmd, endpointID, plan)
+	}
+
+	if len(plan) == 0 || !yes {
+		if len(plan) > 0 && formatter.Format != output.FormatJSON {
+			fmt.Fprintln(cmd.OutOrStdout(), "\nRun again with --yes to apply these changes.")
+		}
+		return nil
+	}
+
+	for i, change := range plan {
+		if err := applyACLChange(ctx, client, endpointID, change); err != nil {
+			return fmt.Errorf("%s %s on %s failed after %d of %d changes: %w",
+				change.Action, change.Rule.principalLabel(), change.Rule.Path, i, len(plan), err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s: %s on %s\n", aclActionDone[change.Action], change.Rule.principalLabel(), change.Rule.Path)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Applied %d change(s) to endpoint %s.\n", len(plan), endpointID)
+	return nil
+}
+
+// aclActionDone labels completed plan steps in apply progress output.
+var aclActionDone = map[string]string{"create": "Created", "update": "Updated", "delete": "Deleted"}
+
+// aclRuleClient is the subset of the Transfer client used to apply a plan.
+type aclRuleClient interface {
+	AddEndpointACLRule(ctx context.Context, endpointID string, doc map[string]interface{}) (map[string]interface{}, error)
+	UpdateEndpointACLRule(ctx context.Context, endpointID, ruleID string, doc map[string]interface{}) (map[string]interface{}, error)
+	DeleteEndpointACLRule(ctx context.Context, endpointID, ruleID string) (map[string]interface{}, error)
+}
+
+// applyACLChange performs one plan step.
+func applyACLChange(ctx context.Context, client aclRuleClient, endpointID string, change aclChange) error {
+	rule := change.Rule
+	switch change.Action {
+	case "create":
+		doc := map[string]interface{}{
+			"DATA_TYPE":      "access",
+			"principal_type": rule.PrincipalType,
+			"principal":      rule.Principal,
+			"path":           rule.Path,
+			"permissions":    rule.Permissions,
+		}
+		if rule.Expiration != "" {
+			doc["expiration_date"] = rule.Expiration
+		}
+		_, err := client.AddEndpointACLRule(ctx, endpointID, doc)
+		return err
+	case "update":
+		doc := map[string]interface{}{
+			"DATA_TYPE":   "access",
+			"permissions": rule.Permissions,
+		}
+		// An explicit null clears an expiration the file no longer sets.
+		if rule.Expiration != "" {
+			doc["expiration_date"] = rule.Expiration
+		} else {
+			doc["expiration_date"] = nil
+		}
+		_, err := client.UpdateEndpointACLRule(ctx, endpointID, change.Current.ID, doc)
+		return err
+	case "delete":
+		_, err := client.DeleteEndpointACLRule(ctx, endpointID, change.Current.ID)
+		return err
+	default:
+		return fmt.Errorf("unknown plan action %q", change.Action)
+	}
+}
+
+// printACLPlan prints a plan in text form, one line per change.
+func printACLPlan(cmd *cobra.Command, endpointID string, plan []aclChange) {
+	out := cmd.OutOrStdout()
+	if len(plan) == 0 {
+		fmt.Fprintf(out, "Endpoint %s access rules already match; no changes.\n", endpointID)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, c := range plan {
+		counts[c.Action]++
+	}
+	fmt.Fprintf(out, "Plan for endpoint %s: %d to create, %d to update, %d to delete\n\n",
+		endpointID, counts["create"], counts["update"], counts["delete"])
+
+	for _, c := range plan {
+		switch c.Action {
+		case "create":
+			fmt.Fprintf(out, "  + create  %s  %s  %s%s\n", c.Rule.principalLabel(), c.Rule.Path, c.Rule.Permissions, expirationSuffix(c.Rule.Expiration))
+		case "update":
+			fmt.Fprintf(out, "  ~ update  %s  %s  %s%s -> %s%s  (rule %s)\n", c.Rule.principalLabel(), c.Rule.Path,
+				c.Current.Permissions, expirationSuffix(c.Current.Expiration),
+				c.Rule.Permissions, expirationSuffix(c.Rule.Expiration), c.Current.ID)
+		case "delete":
+			fmt.Fprintf(out, "  - delete  %s  %s  %s%s  (rule %s)\n", c.Rule.principalLabel(), c.Rule.Path, c.Rule.Permissions, expirationSuffix(c.Rule.Expiration), c.Current.ID)
+		}
+	}
+}
+
+// expirationSuffix renders an optional expiration for plan lines.
+func expirationSuffix(exp string) string {
+	if exp == "" {
+		return ""
+	}
+	return " (expires " + exp + ")"
+}
+
+// loadACLFile reads and validates an ACL file.
+func loadACLFile(file string) ([]aclRuleSpec, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var doc aclFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid ACL file %s: %w", file, err)
+	}
+	for i, spec := range doc.Rules {
+		if err := validateACLSpec(spec); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: %w", file, i+1, err)
+		}
+	}
+	return doc.Rules, nil
+}
+
+// validateACLSpec checks a rule for the mistakes the API would otherwise
+// reject one call at a time.
+func validateACLSpec(spec aclRuleSpec) error {
+	n := 0
+	for _, set := range []bool{spec.Identity != "", spec.Group != "", spec.AllAuthenticatedUsers, spec.Anonymous} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("set exactly one of identity, group, all_authenticated_users or anonymous")
+	}
+	if spec.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if spec.Permissions != "r" && spec.Permissions != "rw" {
+		return fmt.Errorf("permissions must be \"r\" or \"rw\", got %q", spec.Permissions)
+	}
+	if spec.Expiration != "" {
+		if _, ok := parseACLTime(spec.Expiration); !ok {
+			return fmt.Errorf("invalid expiration %q (use YYYY-MM-DD or an ISO 8601 timestamp)", spec.Expiration)
+		}
+	}
+	return nil
+}
+
+// resolveACLSpecs resolves each spec's principal and normalizes its path. Two
+// specs for the same principal and path are an error.
+func resolveACLSpecs(ctx context.Context, resolver *principalResolver, specs []aclRuleSpec) ([]aclRule, error) {
+	rules := make([]aclRule, 0, len(specs))
+	seen := map[string]int{}
+	for i, spec := range specs {
+		rule := aclRule{
+			Path:        normalizeACLPath(spec.Path),
+			Permissions: spec.Permissions,
+			Expiration:  spec.Expiration,
+		}
+		var err error
+		switch {
+		case spec.Identity != "":
+			rule.PrincipalType, rule.Name = "identity", spec.Identity
+			rule.Principal, err = resolver.identityID(ctx, spec.Identity)
+		case spec.Group != "":
+			rule.PrincipalType, rule.Name = "group", spec.Group
+			rule.Principal, err = resolver.groupID(ctx, spec.Group)
+		case spec.AllAuthenticatedUsers:
+			rule.PrincipalType = "all_authenticated_users"
+		case spec.Anonymous:
+			rule.PrincipalType = "anonymous"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if prev, ok := seen[rule.key()]; ok {
+			return nil, fmt.Errorf("rules %d and %d both grant %s on %s", prev, i+1, rule.principalLabel(), rule.Path)
+		}
+		seen[rule.key()] = i + 1
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// liveACLRules extracts the rules from an access_list response. Rules the
+// Transfer service derives from endpoint roles are skipped: they are managed
+// through 'endpoint role', not through the access list.
+func liveACLRules(resp map[string]interface{}) []aclRule {
+	data, _ := resp["DATA"].([]interface{})
+	rules := make([]aclRule, 0, len(data))
+	for _, item := range data {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if genericString(m, "role_id") != "" || genericString(m, "id") == "" {
+			continue
+		}
+		principal := genericString(m, "principal")
+		rules = append(rules, aclRule{
+			ID:            genericString(m, "id"),
+			PrincipalType: genericString(m, "principal_type"),
+			Principal:     strings.ToLower(principal),
+			Name:          principal,
+			Path:          normalizeACLPath(genericString(m, "path")),
+			Permissions:   genericString(m, "permissions"),
+			Expiration:    genericString(m, "expiration_date"),
+		})
+	}
+	return rules
+}
+
+// planACLChanges compares desired rules with live ones. The plan lists
+// creates and updates in file order, then deletes sorted by path; it is
+// applied in that order, so access is granted before anything is revoked.
+func planACLChanges(desired, live []aclRule) []aclChange {
+	byKey := make(map[string]aclRule, len(live))
+	for _, r := range live {
+		byKey[r.key()] = r
+	}
+
+	// An empty plan prints as [] in JSON, not null.
+	plan := []aclChange{}
+	matched := map[string]bool{}
+	for _, want := range desired {
+		have, ok := byKey[want.key()]
+		if !ok {
+			plan = append(plan, aclChange{Action: "create", Rule: want})
+			continue
+		}
+		matched[want.key()] = true
+		if have.Permissions != want.Permissions || !sameACLTime(have.Expiration, want.Expiration) {
+			current := have
+			plan = append(plan, aclChange{Action: "update", Rule: want, Current: &current})
+		}
+	}
+
+	var deletes []aclChange
+	for _, have := range live {
+		if matched[have.key()] {
+			continue
+		}
+		current := have
+		deletes = append(deletes, aclChange{Action: "delete", Rule: have, Current: &current})
+	}
+	sort.SliceStable(deletes, func(i, j int) bool { return deletes[i].Rule.Path < deletes[j].Rule.Path })
+	return append(plan, deletes...)
+}
+
+// normalizeACLPath gives a rule path the trailing slash Transfer stores
+// directory rules with, so "/data" in a file matches "/data/" on the service.
+func normalizeACLPath(p string) string {
+	if p == "" || strings.HasSuffix(p, "/") {
+		return p
+	}
+	return p + "/"
+}
+
+// parseACLTime parses an expiration as written in a file or returned by
+// Transfer.
+func parseACLTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05-07:00", "2006-01-02T15:04:05", "2006-01-02 15:04:05-07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sameACLTime reports whether two expirations denote the same instant (or
+// are both unset).
+func sameACLTime(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	ta, okA := parseACLTime(a)
+	tb, okB := parseACLTime(b)
+	if !okA || !okB {
+		return a == b
+	}
+	return ta.Equal(tb)
+}
+
+// genericString returns a string field of a GenericResponse object, or "".
+func genericString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/groups"
+)
+
+const (
+	aliceID = "11111111-1111-1111-1111-111111111111"
+	labID   = "22222222-2222-2222-2222-222222222222"
+)
+
+// stubResolver returns a principalResolver that knows one user and one group.
+func stubResolver() *principalResolver {
+	return &principalResolver{
+		lookupIdentities: func(_ context.Context, opts *auth.GetIdentitiesOptions) ([]auth.Identity, error) {
+			if opts.Usernames[0] == "alice@example.org" {
+				return []auth.Identity{{ID: aliceID, Username: "alice@example.org"}}, nil
+			}
+			return nil, nil
+		},
+		listGroups: func(context.Context) ([]groups.Group, error) {
+			return []groups.Group{{ID: labID, Name: "Lab"}, {ID: "a", Name: "Twin"}, {ID: "b", Name: "Twin"}}, nil
+		},
+	}
+}
+
+// TestLoadACLFile checks parsing and per-rule validation of ACL files.
+func TestLoadACLFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	good := write("good.yaml", `rules:
+  - identity: alice@example.org
+    path: /projects/alpha
+    permissions: rw
+  - anonymous: true
+    path: /public/
+    permissions: r
+    expiration: 2026-12-31
+`)
+	specs, err := loadACLFile(good)
+	if err != nil {
+		t.Fatalf("loadACLFile: %v", err)
+	}
+	if len(specs) != 2 || specs[0].Identity != "alice@example.org" || !specs[1].Anonymous {
+		t.Errorf("specs = %+v", specs)
+	}
+
+	bad := map[string]string{
+		"two-principals": "rules:\n  - identity: a\n    group: b\n    path: /x/\n    permissions: r\n",
+		"no-principal":   "rules:\n  - path: /x/\n    permissions: r\n",
+		"bad-perms":      "rules:\n  - anonymous: true\n    path: /x/\n    permissions: w\n",
+		"no-path":        "rules:\n  - anonymous: true\n    permissions: r\n",
+		"bad-expiration": "rules:\n  - anonymous: true\n    path: /x/\n    permissions: r\n    expiration: soon\n",
+		"unknown-field":  "rules:\n  - anonymous: true\n    path: /x/\n    permission: r\n",
+	}
+	for name, body := range bad {
+		if _, err := loadACLFile(write(name+".yaml", body)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestResolveACLSpecs covers username and group-name resolution, and
+// duplicate detection.
+func TestResolveACLSpecs(t *testing.T) {
+	ctx := context.Background()
+	rules, err := resolveACLSpecs(ctx, stubResolver(), []aclRuleSpec{
+		{Identity: "alice@example.org", Path: "/a", Permissions: "rw"},
+		{Group: "Lab", Path: "/a/", Permissions: "r"},
+		{AllAuthenticatedUsers: true, Path: "/pub/", Permissions: "r"},
+	})
+	if err != nil {
+		t.Fatalf("resolveACLSpecs: %v", err)
+	}
+	if rules[0].Principal != aliceID || rules[0].Path != "/a/" {
+		t.Errorf("identity rule = %+v", rules[0])
+	}
+	if rules[1].Principal != labID || rules[1].PrincipalType != "group" {
+		t.Errorf("group rule = %+v", rules[1])
+	}
+	if rules[2].PrincipalType != "all_authenticated_users" || rules[2].Principal != "" {
+		t.Errorf("all-authenticated rule = %+v", rules[2])
+	}
+
+	for name, specs := range map[string][]aclRuleSpec{
+		"unknown user":    {{Identity: "bob@example.org", Path: "/a/", Permissions: "r"}},
+		"unknown group":   {{Group: "Nope", Path: "/a/", Permissions: "r"}},
+		"ambiguous group": {{Group: "Twin", Path: "/a/", Permissions: "r"}},
+		"duplicate": {
+			{Identity: "alice@example.org", Path: "/a", Permissions: "r"},
+			{Identity: aliceID, Path: "/a/", Permissions: "rw"},
+		},
+	} {
+		if _, err := resolveACLSpecs(ctx, stubResolver(), specs); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestPlanACLChanges checks that the plan creates, updates and deletes
+// exactly what differs, and leaves role-derived rules alone.
+func TestPlanACLChanges(t *testing.T) {
+	live := liveACLRules(map[string]interface{}{
+		"DATA": []interface{}{
+			map[string]interface{}{"id": "1", "principal_type": "identity", "principal": aliceID, "path": "/a/", "permissions": "rw"},
+			map[string]interface{}{"id": "2", "principal_type": "group", "principal": labID, "path": "/a/", "permissions": "r", "expiration_date": "2026-12-31T00:00:00+00:00"},
+			map[string]interface{}{"id": "3", "principal_type": "anonymous", "principal": "", "path": "/old/", "permissions": "r"},
+			map[string]interface{}{"id": "4", "principal_type": "identity", "principal": aliceID, "path": "/b/", "permissions": "r"},
+			map[string]interface{}{"id": "5", "principal_type": "identity", "principal": labID, "path": "/", "permissions": "rw", "role_id": "r1"},
+		},
+	})
+	desired := []aclRule{
+		{PrincipalType: "identity", Principal: aliceID, Path: "/a/", Permissions: "rw"},
+		{PrincipalType: "group", Principal: labID, Path: "/a/", Permissions: "r", Expiration: "2026-12-31"},
+		{PrincipalType: "identity", Principal: aliceID, Path: "/b/", Permissions: "rw"},
+		{PrincipalType: "all_authenticated_users", Path: "/pub/", Permissions: "r"},
+	}
+
+	plan := planACLChanges(desired, live)
+	var got []string
+	for _, c := range plan {
+		id := ""
+		if c.Current != nil {
+			id = c.Current.ID
+		}
+		got = append(got, c.Action+" "+c.Rule.Path+" "+id)
+	}
+	want := []string{"update /b/ 4", "create /pub/ ", "delete /old/ 3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+
+	plan = planACLChanges(desired[:2], live[:2])
+	if data, _ := json.Marshal(plan); string(data) != "[]" {
+		t.Errorf("expected an empty plan, got %s", data)
+	}
+}
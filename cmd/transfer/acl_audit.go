@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// Audit statuses. A rule may carry several, joined with ",".
+const (
+	aclStatusOK           = "ok"
+	aclStatusExpired      = "expired"
+	aclStatusDeleted      = "deleted"
+	aclStatusUnresolvable = "unresolvable"
+)
+
+// aclAuditRow is one access rule in an audit report, with its principal
+// resolved to a human-readable name.
+type aclAuditRow struct {
+	EndpointID    string `json:"endpoint_id"`
+	EndpointName  string `json:"endpoint_name"`
+	Path          string `json:"path"`
+	RuleID        string `json:"rule_id"`
+	PrincipalType string `json:"principal_type"`
+	Principal     string `json:"principal"`
+	PrincipalName string `json:"principal_name"`
+	Permissions   string `json:"permissions"`
+	Expiration    string `json:"expiration_date"`
+	Status        string `json:"status"`
+}
+
+// aclAuditHeaders are the CSV/table columns of an audit report.
+var aclAuditHeaders = []string{"EndpointID", "EndpointName", "Path", "RuleID", "PrincipalType", "Principal", "PrincipalName", "Permissions", "Expiration", "Status"}
+
+// endpointPermissionAuditCmd returns the "endpoint permission audit" command.
+func endpointPermissionAuditCmd() *cobra.Command {
+	var (
+		sharedOn    string
+		flaggedOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit [ENDPOINT_ID...]",
+		Short: "Report access rules with resolved principals and problems flagged",
+		Long: `Report the access rules of one or more endpoints for review.
+
+Identity principals are resolved to usernames through Globus Auth and group
+principals to group names through Globus Groups. Each rule gets a status:
+
+  ok            nothing to report
+  expired       the rule's expiration date has passed
+  deleted       the identity's account is closed
+  unresolvable  Auth or Groups does not know the principal (or the group
+                is not visible to you)
+
+Text output groups rules by path under each endpoint. Use -F csv or -F json
+to export the rows for compliance review.
+
+With --my-shared-endpoints HOST_ENDPOINT_ID, every shared endpoint you created
+on the host (as listed by 'endpoint my-shared-endpoint-list') is audited in
+addition to any ENDPOINT_IDs given. An endpoint whose rules cannot be listed
+is reported and the audit carries on; the command then exits non-zero.
+
+Examples:
+  globus endpoint permission audit ENDPOINT_ID
+  globus endpoint permission audit --my-shared-endpoints HOST_ENDPOINT_ID -F csv > acl-review.csv
+  globus endpoint permission audit ENDPOINT_ID --flagged-only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && sharedOn == "" {
+				return fmt.Errorf("give at least one ENDPOINT_ID or --my-shared-endpoints HOST_ENDPOINT_ID")
+			}
+			return auditEndpointACLs(cmd, args, sharedOn, flaggedOnly)
+		},
+	}
+
+	cmd.Flags().StringVar(&sharedOn, "my-shared-endpoints", "", "Also audit every shared endpoint you created on this host endpoint")
+	cmd.Flags().BoolVar(&flaggedOnly, "flagged-only", false, "Only report rules whose status is not ok")
+
+	return cmd
+}
+
+// auditEndpoint pairs an endpoint ID with its display name, when known.
+type auditEndpoint struct {
+	ID   string
+	Name string
+}
+
+// auditEndpointACLs gathers, resolves and prints the audit report.
+func auditEndpointACLs(cmd *cobra.Command, endpointIDs []string, sharedOn string, flaggedOnly bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make([]auditEndpoint, 0, len(endpointIDs))
+	for _, id := range endpointIDs {
+		ep := auditEndpoint{ID: id}
+		if doc, err := client.GetEndpoint(ctx, id); err == nil {
+			ep.Name = doc.DisplayName
+		}
+		endpoints = append(endpoints, ep)
+	}
+	if sharedOn != "" {
+		resp, err := client.MySharedEndpointList(ctx, sharedOn)
+		if err != nil {
+			return fmt.Errorf("failed to list shared endpoints on %s: %w", sharedOn, err)
+		}
+		data, _ := resp["DATA"].([]interface{})
+		for _, item := range data {
+			if m, ok := item.(map[string]interface{}); ok && genericString(m, "id") != "" {
+				endpoints = append(endpoints, auditEndpoint{ID: genericString(m, "id"), Name: genericString(m, "display_name")})
+			}
+		}
+	}
+
+	resolver := newPrincipalResolver()
+	now := time.Now()
+	// A clean audit prints as [] in JSON, not null.
+	rows := []aclAuditRow{}
+	failed := 0
+	for _, ep := range endpoints {
+		resp, err := client.EndpointACLList(ctx, ep.ID)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to list access rules for %s: %v\n", ep.ID, err)
+			failed++
+			continue
+		}
+		epRows, err := auditACLRules(ctx, resolver, ep, resp, now)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, epRows...)
+	}
+
+	if flaggedOnly {
+		kept := rows[:0]
+		for _, r := range rows {
+			if r.Status != aclStatusOK {
+				kept = append(kept, r)
+			}
+		}
+		rows = kept
+	}
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatText {
+		printACLAudit(cmd.OutOrStdout(), rows)
+		printACLAuditSummary(cmd.ErrOrStderr(), rows)
+	} else if err := formatter.FormatOutput(rows, aclAuditHeaders); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not audit %d of %d endpoint(s)", failed, len(endpoints))
+	}
+	return nil
+}
+
+// auditACLRules turns an access_list response into audit rows, resolving
+// every principal and sorting by path. Role-derived and implicit rules are
+// included: an audit reports everything that grants access.
+func auditACLRules(ctx context.Context, resolver *principalResolver, ep auditEndpoint, resp map[string]interface{}, now time.Time) ([]aclAuditRow, error) {
+	data, _ := resp["DATA"].([]interface{})
+
+	var identityIDs []string
+	for _, item := range data {
+		if m, ok := item.(map[string]interface{}); ok && genericString(m, "principal_type") == "identity" && genericString(m, "principal") != "" {
+			identityIDs = append(identityIDs, strings.ToLower(genericString(m, "principal")))
+		}
+	}
+	identities, err := resolver.describeIdentities(ctx, identityIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]aclAuditRow, 0, len(data))
+	for _, item := range data {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		row := aclAuditRow{
+			EndpointID:    ep.ID,
+			EndpointName:  ep.Name,
+			Path:          genericString(m, "path"),
+			RuleID:        genericString(m, "id"),
+			PrincipalType: genericString(m, "principal_type"),
+			Principal:     genericString(m, "principal"),
+			Permissions:   genericString(m, "permissions"),
+			Expiration:    genericString(m, "expiration_date"),
+		}
+
+		var flags []string
+		switch row.PrincipalType {
+		case "identity":
+			if ident := identities[strings.ToLower(row.Principal)]; ident == nil {
+				flags = append(flags, aclStatusUnresolvable)
+			} else {
+				row.PrincipalName = ident.Username
+				if ident.Status == "closed" {
+					flags = append(flags, aclStatusDeleted)
+				}
+			}
+		case "group":
+			g, err := resolver.describeGroup(ctx, strings.ToLower(row.Principal))
+			if err != nil {
+				return nil, err
+			}
+			if g == nil {
+				flags = append(flags, aclStatusUnresolvable)
+			} else {
+				row.PrincipalName = g.Name
+			}
+		case "all_authenticated_users":
+			row.PrincipalName = "all authenticated users"
+		case "anonymous":
+			row.PrincipalName = "anonymous"
+		}
+		if row.Expiration != "" {
+			if t, ok := parseACLTime(row.Expiration); ok && !t.After(now) {
+				flags = append(flags, aclStatusExpired)
+			}
+		}
+
+		row.Status = aclStatusOK
+		if len(flags) > 0 {
+			row.Status = strings.Join(flags, ",")
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Path != rows[j].Path {
+			return rows[i].Path < rows[j].Path
+		}
+		return rows[i].PrincipalName < rows[j].PrincipalName
+	})
+	return rows, nil
+}
+
+// printACLAudit prints rows grouped by endpoint, then by path.
+func printACLAudit(w io.Writer, rows []aclAuditRow) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No access rules to report.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	endpoint, path := "", ""
+	for i, r := range rows {
+		if i == 0 || r.EndpointID != endpoint {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			endpoint, path = r.EndpointID, ""
+			if r.EndpointName != "" {
+				fmt.Fprintf(tw, "Endpoint %s (%s)\n", r.EndpointID, r.EndpointName)
+			} else {
+				fmt.Fprintf(tw, "Endpoint %s\n", r.EndpointID)
+			}
+		}
+		if r.Path != path {
+			path = r.Path
+			fmt.Fprintf(tw, "  %s\n", path)
+		}
+
+		name := r.PrincipalName
+		if name == "" {
+			name = r.Principal
+		}
+		expires := ""
+		if r.Expiration != "" {
+			expires = "expires " + r.Expiration
+		}
+		status := ""
+		if r.Status != aclStatusOK {
+			status = strings.ToUpper(r.Status)
+		}
+		ruleID := r.RuleID
+		if ruleID == "" {
+			ruleID = "(implicit)"
+		}
+		fmt.Fprintf(tw, "    %s\t%s\t%s\t%s\t%s\t%s\n", r.Permissions, r.PrincipalType, name, ruleID, expires, status)
+	}
+	tw.Flush()
+}
+
+// printACLAuditSummary prints rule and finding counts.
+func printACLAuditSummary(w io.Writer, rows []aclAuditRow) {
+	counts := map[string]int{}
+	flagged := 0
+	for _, r := range rows {
+		if r.Status == aclStatusOK {
+			continue
+		}
+		flagged++
+		for _, s := range strings.Split(r.Status, ",") {
+			counts[s]++
+		}
+	}
+	fmt.Fprintf(w, "\n%d rule(s), %d flagged (%d expired, %d deleted, %d unresolvable)\n",
+		len(rows), flagged, counts[aclStatusExpired], counts[aclStatusDeleted], counts[aclStatusUnresolvable])
+}
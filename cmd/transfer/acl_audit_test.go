@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/groups"
+)
+
+// TestAuditACLRules checks principal resolution and status flags.
+func TestAuditACLRules(t *testing.T) {
+	const (
+		closedID = "33333333-3333-3333-3333-333333333333"
+		ghostID  = "44444444-4444-4444-4444-444444444444"
+		goneGrp  = "55555555-5555-5555-5555-555555555555"
+	)
+	resolver := &principalResolver{
+		lookupIdentities: func(_ context.Context, opts *auth.GetIdentitiesOptions) ([]auth.Identity, error) {
+			var out []auth.Identity
+			for _, id := range opts.IDs {
+				switch id {
+				case aliceID:
+					out = append(out, auth.Identity{ID: aliceID, Username: "alice@example.org", Status: "used"})
+				case closedID:
+					out = append(out, auth.Identity{ID: closedID, Username: "old@example.org", Status: "closed"})
+				}
+			}
+			return out, nil
+		},
+		listGroups: func(context.Context) ([]groups.Group, error) {
+			return []groups.Group{{ID: labID, Name: "Lab"}}, nil
+		},
+		getGroup: func(context.Context, string) (*groups.Group, error) {
+			return nil, &core.APIError{StatusCode: 404, Message: "not found"}
+		},
+	}
+
+	resp := map[string]interface{}{
+		"DATA": []interface{}{
+			map[string]interface{}{"id": "1", "principal_type": "identity", "principal": aliceID, "path": "/b/", "permissions": "rw"},
+			map[string]interface{}{"id": "2", "principal_type": "identity", "principal": closedID, "path": "/a/", "permissions": "r"},
+			map[string]interface{}{"id": "3", "principal_type": "identity", "principal": ghostID, "path": "/a/", "permissions": "r", "expiration_date": "2026-01-01T00:00:00+00:00"},
+			map[string]interface{}{"id": "4", "principal_type": "group", "principal": labID, "path": "/a/", "permissions": "r"},
+			map[string]interface{}{"id": "5", "principal_type": "group", "principal": goneGrp, "path": "/a/", "permissions": "r"},
+			map[string]interface{}{"id": "6", "principal_type": "anonymous", "principal": "", "path": "/pub/", "permissions": "r"},
+		},
+	}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	rows, err := auditACLRules(context.Background(), resolver, auditEndpoint{ID: "ep", Name: "Guest"}, resp, now)
+	if err != nil {
+		t.Fatalf("auditACLRules: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, r := range rows {
+		got[r.RuleID] = r.PrincipalName + "|" + r.Status
+	}
+	want := map[string]string{
+		"1": "alice@example.org|ok",
+		"2": "old@example.org|deleted",
+		"3": "|unresolvable,expired",
+		"4": "Lab|ok",
+		"5": "|unresolvable",
+		"6": "anonymous|ok",
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("rule %s = %q, want %q", id, got[id], w)
+		}
+	}
+	if rows[0].Path != "/a/" || rows[len(rows)-1].Path != "/pub/" {
+		t.Errorf("rows not sorted by path: first %s, last %s", rows[0].Path, rows[len(rows)-1].Path)
+	}
+
+	var out bytes.Buffer
+	printACLAudit(&out, rows)
+	if !strings.Contains(out.String(), "Endpoint ep (Guest)") || strings.Count(out.String(), "  /a/\n") != 1 {
+		t.Errorf("text report not grouped by path:\n%s", out.String())
+	}
+}
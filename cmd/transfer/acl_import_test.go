@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestParseImportCSV checks header validation and blank-row handling.
+func TestParseImportCSV(t *testing.T) {
+	required := []string{"principal", "principal_type", "role"}
+
+	records, err := parseImportCSV(strings.NewReader("\ufeffPrincipal, principal_type,role\nalice@example.org,,administrator\n,,\nLab,group,activity_monitor\n"), "roles.csv", required, nil)
+	if err != nil {
+		t.Fatalf("parseImportCSV: %v", err)
+	}
+	if len(records) != 2 || records[1].Line != 4 || records[1].Fields["principal_type"] != "group" {
+		t.Errorf("records = %+v", records)
+	}
+
+	if _, err := parseImportCSV(strings.NewReader("principal,role\n"), "roles.csv", required, nil); err == nil {
+		t.Error("expected an error for a missing column")
+	}
+	if _, err := parseImportCSV(strings.NewReader("principal,principal_type,role,notes\n"), "roles.csv", required, nil); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+// TestImportRows checks principal resolution and per-row validation.
+func TestImportRows(t *testing.T) {
+	ctx := context.Background()
+
+	roles := roleImportRows(ctx, stubResolver(), []importRecord{
+		{Line: 2, Fields: map[string]string{"principal": "alice@example.org", "role": "Administrator"}},
+		{Line: 3, Fields: map[string]string{"principal": "Lab", "principal_type": "group", "role": "activity_monitor"}},
+		{Line: 4, Fields: map[string]string{"principal": "alice@example.org", "role": "owner"}},
+		{Line: 5, Fields: map[string]string{"principal": "nobody@example.org", "role": "administrator"}},
+	})
+	if roles[0].Err != nil || roles[0].Doc["principal"] != aliceID || roles[0].Doc["role"] != "administrator" {
+		t.Errorf("row 2 = %+v", roles[0])
+	}
+	if roles[1].Err != nil || roles[1].Doc["principal"] != labID {
+		t.Errorf("row 3 = %+v", roles[1])
+	}
+	if roles[2].Err == nil || roles[3].Err == nil {
+		t.Errorf("expected errors for an unknown role and username, got %v, %v", roles[2].Err, roles[3].Err)
+	}
+
+	acls := aclImportRows(ctx, stubResolver(), []importRecord{
+		{Line: 2, Fields: map[string]string{"principal": "alice@example.org", "path": "/projects/alpha", "permissions": "RW", "expiration": "2027-06-30"}},
+		{Line: 3, Fields: map[string]string{"principal_type": "anonymous", "path": "/pub/", "permissions": "r"}},
+		{Line: 4, Fields: map[string]string{"principal_type": "group", "path": "/x/", "permissions": "r"}},
+		{Line: 5, Fields: map[string]string{"principal": "alice@example.org", "path": "/x/", "permissions": "w"}},
+	})
+	if acls[0].Err != nil || acls[0].Doc["path"] != "/projects/alpha/" || acls[0].Doc["expiration_date"] != "2027-06-30" || acls[0].Doc["permissions"] != "rw" {
+		t.Errorf("row 2 = %+v", acls[0])
+	}
+	if acls[1].Err != nil || acls[1].Principal != "anonymous" {
+		t.Errorf("row 3 = %+v", acls[1])
+	}
+	if acls[2].Err == nil || acls[3].Err == nil {
+		t.Errorf("expected errors for a missing principal and bad permissions, got %v, %v", acls[2].Err, acls[3].Err)
+	}
+}
+
+// TestRunImport checks skipping, duplicate detection, concurrency and rate
+// limiting.
+func TestRunImport(t *testing.T) {
+	rows := []*importRow{
+		{Line: 2, Key: "a"},
+		{Line: 3, Key: "exists"},
+		{Line: 4, Key: "b"},
+		{Line: 5, Key: "a"},
+		{Line: 6, Err: errors.New("bad row")},
+		{Line: 7, Key: "fails"},
+		{Line: 8, Key: "c"},
+	}
+	var inFlight, peak int32
+	create := func(_ context.Context, row *importRow) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if row.Key == "fails" {
+			return "", errors.New("conflict")
+		}
+		return "id-" + row.Key, nil
+	}
+
+	start := time.Now()
+	results := runImport(context.Background(), rows, map[string]bool{"exists": true}, importOptions{Concurrency: 2, Rate: 100}, create)
+	elapsed := time.Since(start)
+
+	want := []string{"created", "skipped", "created", "skipped", "error", "error", "created"}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("line %d = %s (%s), want %s", r.Line, r.Status, r.Detail, want[i])
+		}
+	}
+	if results[3].Detail != "duplicate of line 2" || results[6].Detail != "id-c" {
+		t.Errorf("details = %q, %q", results[3].Detail, results[6].Detail)
+	}
+	if peak > 2 {
+		t.Errorf("%d requests in flight, want at most 2", peak)
+	}
+	// Four creates at 100/s are spaced 10ms apart.
+	if elapsed < 30*time.Millisecond {
+		t.Errorf("creates finished in %v; rate limit not applied", elapsed)
+	}
+}
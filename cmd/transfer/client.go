@@ -5,11 +5,15 @@ package transfer
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/spf13/viper"
 
 	"github.com/scttfrdmn/globus-go-cli/pkg/config"
 	"github.com/scttfrdmn/globus-go-cli/pkg/globusauth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/groups"
 	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
 )
 
@@ -36,3 +40,88 @@ func getClient(ctx context.Context) (*transfer.Client, error) {
 	}
 	return client, nil
 }
+
+// NewRawClient builds a raw Transfer API client for the current profile, for
+// the endpoint document fields the SDK's typed structs do not carry. Paths are
+// relative to the Transfer host.
+func NewRawClient(ctx context.Context) (*core.Client, error) {
+	profile := viper.GetString("profile")
+
+	clientCfg, err := config.LoadClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client configuration: %w", err)
+	}
+
+	cfg, err := globusauth.ClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, globusauth.ServiceTransfer)
+	if err != nil {
+		return nil, fmt.Errorf("not logged in: %w", err)
+	}
+	cfg.BaseURL = "https://transfer.api.globus.org"
+
+	client, err := core.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer client: %w", err)
+	}
+	return client, nil
+}
+
+// getEndpointDocument fetches an endpoint's full Transfer document. The SDK's
+// typed Endpoint omits most of the fields endpoint update can set (contact
+// details, default_directory, force_encryption, parallelism, ...), so commands
+// that compare or copy settings read the raw document instead.
+func getEndpointDocument(ctx context.Context, endpointID string) (map[string]interface{}, error) {
+	client, err := NewRawClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := client.DoRequest(ctx, "GET", "/v0.10/endpoint/"+url.PathEscape(endpointID), nil, nil, &doc); err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s: %w", endpointID, err)
+	}
+	return doc, nil
+}
+
+// getAuthClient builds a v4 Auth client for the current profile, used to
+// resolve usernames in access rules.
+func getAuthClient(ctx context.Context) (*auth.Client, error) {
+	profile := viper.GetString("profile")
+
+	clientCfg, err := config.LoadClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client configuration: %w", err)
+	}
+
+	cfg, err := globusauth.AuthClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("not logged in: %w", err)
+	}
+
+	client, err := auth.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth client: %w", err)
+	}
+	return client, nil
+}
+
+// getGroupsClient builds a v4 Groups client for the current profile, used to
+// resolve group names in access rules.
+func getGroupsClient(ctx context.Context) (*groups.Client, error) {
+	profile := viper.GetString("profile")
+
+	clientCfg, err := config.LoadClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client configuration: %w", err)
+	}
+
+	cfg, err := globusauth.ClientConfig(ctx, profile, clientCfg.ClientID, clientCfg.ClientSecret, globusauth.ServiceGroups)
+	if err != nil {
+		return nil, fmt.Errorf("not logged in: %w", err)
+	}
+
+	client, err := groups.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create groups client: %w", err)
+	}
+	return client, nil
+}
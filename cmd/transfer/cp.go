@@ -5,6 +5,7 @@ package transfer
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"github.com/scttfrdmn/globus-go-cli/pkg/config"
 	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
 )
 
@@ -37,12 +39,15 @@ var (
 	transferChecksumAlgo     string
 	transferSourceLocalUser  string
 	transferDestLocalUser    string
+	transferManifest         string
+	transferTemplate         string
+	transferNoGlob           bool
 )
 
 // CpCmd returns the cp command
 func CpCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "transfer SOURCE_ENDPOINT:SOURCE_PATH DEST_ENDPOINT:DEST_PATH",
+		Use:   "transfer SOURCE_ENDPOINT:SOURCE_PATH... DEST_ENDPOINT:DEST_PATH",
 		Short: "Transfer files between Globus endpoints",
 		Long: `Transfer files between Globus endpoints.
 
@@ -50,22 +55,63 @@ This command submits a transfer task to copy files from a source endpoint to a
 destination endpoint. The transfer runs asynchronously, and the command returns
 a task ID that can be used to monitor the transfer.
 
+Source paths may contain shell-style globs ('*', '?', '[...]'), which are
+expanded on the source endpoint before submission; quote them so your local
+shell does not expand them first. Several sources (all on one endpoint) may be
+given, as with cp. When more than one path results, DEST_PATH is treated as a
+directory and each source keeps its base name inside it. Use --dry-run to see
+the expansion without submitting.
+
 Examples:
   globus transfer cp ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/file.txt ddb59af0-6d04-11e5-ba46-22000b92c6ec:/path/
-  globus transfer cp --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/folder/ ddb59af0-6d04-11e5-ba46-22000b92c6ec:/dest/`,
-		Args: cobra.ExactArgs(2),
+  globus transfer cp --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/folder/ ddb59af0-6d04-11e5-ba46-22000b92c6ec:/dest/
+  globus transfer --manifest run42.json SRC_ENDPOINT:/data/run42 DEST_ENDPOINT:/archive/run42
+  globus transfer --template nightly SRC_ENDPOINT:/data DEST_ENDPOINT:/backup
+  globus transfer --dry-run 'SRC_ENDPOINT:/data/run_*.h5' DEST_ENDPOINT:/dest/
+  globus transfer SRC_ENDPOINT:/data/a.txt SRC_ENDPOINT:/data/b.txt DEST_ENDPOINT:/dest/`,
+		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Parse source endpoint and path
-			sourceEndpointID, sourcePath := parseEndpointAndPath(args[0])
+			// Every argument but the last is a source on the same endpoint.
+			sourceEndpointID, sourcePaths, err := splitEndpointArgs(args[:len(args)-1])
+			if err != nil {
+				return err
+			}
 
 			// Parse destination endpoint and path
-			destEndpointID, destPath := parseEndpointAndPath(args[1])
+			destEndpointID, destPath := parseEndpointAndPath(args[len(args)-1])
+
+			if transferTemplate != "" {
+				tmpl, err := config.LoadTransferTemplate(transferTemplate)
+				if err != nil {
+					return err
+				}
+				applyTransferTemplate(cmd, tmpl)
+			}
 
-			return transferFiles(cmd, sourceEndpointID, sourcePath, destEndpointID, destPath)
+			return transferFiles(cmd, sourceEndpointID, sourcePaths, destEndpointID, destPath)
 		},
 	}
 
 	// Add flags
+	addTransferOptionFlags(cmd)
+	cmd.Flags().StringVar(&transferSubmissionID, "submission-id", "", "Task submission ID for safe resubmission")
+	cmd.Flags().StringVar(&transferExternalChecksum, "external-checksum", "", "External checksum to verify source file integrity")
+	cmd.Flags().BoolVar(&transferWait, "wait", false, "Wait for the transfer to complete")
+	cmd.Flags().BoolVar(&transferDryRun, "dry-run", false, "Show the items that would be transferred (after glob expansion) without submitting")
+	cmd.Flags().BoolVar(&transferNoGlob, "no-glob", false, "Treat '*', '?' and '[' in source paths literally instead of expanding them")
+	cmd.Flags().StringVar(&transferManifest, "manifest", "", "Transfer every entry of a manifest (from 'transfer manifest create'), relative to SOURCE_PATH and DEST_PATH, with per-item external checksums")
+	cmd.Flags().StringVar(&transferTemplate, "template", "", "Apply a saved transfer template (see 'globus transfer template'); explicit flags override it")
+
+	cmd.AddCommand(ManifestCmd(), TemplateCmd())
+
+	return cmd
+}
+
+// addTransferOptionFlags registers the transfer options that describe how a
+// transfer behaves, as opposed to what it moves or how it is submitted. They
+// are shared by `transfer` and `transfer template save`, so every option a
+// transfer accepts can also be saved in a template.
+func addTransferOptionFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&transferRecursive, "recursive", "r", false, "Transfer directories recursively")
 	cmd.Flags().StringVarP(&transferSyncLevel, "sync-level", "s", "", "Sync level: exists, size, mtime, checksum (or 0-3)")
 	cmd.Flags().BoolVar(&transferPreserveTime, "preserve-timestamp", false, "Preserve file modification times")
@@ -73,26 +119,20 @@ Examples:
 	cmd.Flags().BoolVar(&transferVerify, "verify-checksum", true, "Verify checksum after transfer")
 	cmd.Flags().BoolVar(&transferEncrypt, "encrypt-data", false, "Encrypt data sent through the network")
 	cmd.Flags().StringVar(&transferLabel, "label", "", "Label for the transfer task")
-	cmd.Flags().StringVar(&transferSubmissionID, "submission-id", "", "Task submission ID for safe resubmission")
 	cmd.Flags().StringSliceVar(&transferNotify, "notify", nil, "Comma-separated task events that notify by email (on, off, succeeded, failed, inactive)")
 	cmd.Flags().BoolVar(&transferSkipSourceErrors, "skip-source-errors", false, "Skip source paths that hit permission-denied or not-found errors")
 	cmd.Flags().BoolVar(&transferFailOnQuota, "fail-on-quota-errors", false, "Fail the task if any quota-exceeded errors are hit")
 	cmd.Flags().BoolVar(&transferDeleteDestExtra, "delete-destination-extra", false, "Delete files in the destination not in the source (recursive mirroring)")
 	cmd.Flags().StringArrayVar(&transferInclude, "include", nil, "Include files matching the given glob pattern in recursive transfers (repeatable)")
 	cmd.Flags().StringArrayVar(&transferExclude, "exclude", nil, "Exclude files matching the given glob pattern in recursive transfers (repeatable)")
-	cmd.Flags().StringVar(&transferExternalChecksum, "external-checksum", "", "External checksum to verify source file integrity")
 	cmd.Flags().StringVar(&transferChecksumAlgo, "checksum-algorithm", "", "Algorithm for --external-checksum or --verify-checksum")
 	cmd.Flags().StringVar(&transferSourceLocalUser, "source-local-user", "", "Local user to map to on the source (GCSv5 mapped collections)")
 	cmd.Flags().StringVar(&transferDestLocalUser, "destination-local-user", "", "Local user to map to on the destination (GCSv5 mapped collections)")
-	cmd.Flags().BoolVar(&transferWait, "wait", false, "Wait for the transfer to complete")
-	cmd.Flags().BoolVar(&transferDryRun, "dry-run", false, "Don't actually perform the transfer (test only)")
 	cmd.Flags().StringVar(&transferDeadline, "deadline", "", "Transfer deadline (YYYY-MM-DD)")
-
-	return cmd
 }
 
 // transferFiles transfers files between endpoints
-func transferFiles(cmd *cobra.Command, sourceEndpointID, sourcePath, destEndpointID, destPath string) error {
+func transferFiles(cmd *cobra.Command, sourceEndpointID string, sourcePaths []string, destEndpointID, destPath string) error {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -129,24 +169,39 @@ func transferFiles(cmd *cobra.Command, sourceEndpointID, sourcePath, destEndpoin
 		return err
 	}
 
-	// Show transfer details and confirm if not in dry run mode
-	if !transferDryRun {
-		fmt.Println("Transfer Details:")
-		fmt.Printf("  Source:      %s:%s\n", sourceEndpointID, sourcePath)
-		fmt.Printf("  Destination: %s:%s\n", destEndpointID, destPath)
-		fmt.Printf("  Recursive:   %t\n", transferRecursive)
-		fmt.Printf("  Sync Level:  %d\n", transferSync)
+	items, err := buildTransferItems(ctx, transferClient, sourceEndpointID, sourcePaths, destPath)
+	if err != nil {
+		return err
+	}
 
-		confirm := promptui.Prompt{
-			Label:     "Proceed with transfer",
-			IsConfirm: true,
-		}
+	fmt.Println("Transfer Details:")
+	for _, p := range sourcePaths {
+		fmt.Printf("  Source:      %s:%s\n", sourceEndpointID, p)
+	}
+	fmt.Printf("  Destination: %s:%s\n", destEndpointID, destPath)
+	fmt.Printf("  Recursive:   %t\n", transferRecursive)
+	fmt.Printf("  Sync Level:  %d\n", transferSync)
+	switch {
+	case transferManifest != "":
+		fmt.Printf("  Items:       %d (from manifest %s)\n", len(items), transferManifest)
+	case len(items) > 1 || transferDryRun:
+		fmt.Printf("  Items:       %d\n", len(items))
+		printTransferItems(items, transferDryRun)
+	}
 
-		result, err := confirm.Run()
-		if err != nil || strings.ToLower(result) != "y" {
-			fmt.Println("Transfer canceled.")
-			return nil
-		}
+	if transferDryRun {
+		fmt.Println("Dry run: transfer not submitted.")
+		return nil
+	}
+
+	confirm := promptui.Prompt{
+		Label:     "Proceed with transfer",
+		IsConfirm: true,
+	}
+	result, err := confirm.Run()
+	if err != nil || strings.ToLower(result) != "y" {
+		fmt.Println("Transfer canceled.")
+		return nil
 	}
 
 	// Start spinner for submission
@@ -185,16 +240,7 @@ func transferFiles(cmd *cobra.Command, sourceEndpointID, sourcePath, destEndpoin
 		NotifyOnInactive:       notifyInactive,
 		Deadline:               deadline,
 		FilterRules:            buildFilterRules(transferInclude, transferExclude),
-		Items: []transfer.TransferItem{
-			{
-				DATA_TYPE:         "transfer_item",
-				SourcePath:        sourcePath,
-				DestinationPath:   destPath,
-				Recursive:         transferRecursive,
-				ExternalChecksum:  transferExternalChecksum,
-				ChecksumAlgorithm: transferChecksumAlgo,
-			},
-		},
+		Items:                  items,
 	}
 
 	// Submit the transfer
@@ -218,6 +264,116 @@ func transferFiles(cmd *cobra.Command, sourceEndpointID, sourcePath, destEndpoin
 	return nil
 }
 
+// maxListedTransferItems bounds how many items the confirmation summary lists;
+// --dry-run always lists them all.
+const maxListedTransferItems = 10
+
+// printTransferItems lists items as "source -> destination" lines.
+func printTransferItems(items []transfer.TransferItem, all bool) {
+	for i, item := range items {
+		if !all && i == maxListedTransferItems {
+			fmt.Printf("    ... and %d more\n", len(items)-i)
+			return
+		}
+		suffix := ""
+		if item.Recursive {
+			suffix = " (recursive)"
+		}
+		fmt.Printf("    %s -> %s%s\n", item.SourcePath, item.DestinationPath, suffix)
+	}
+}
+
+// buildTransferItems returns the transfer items for the source paths ->
+// DEST_PATH. A single literal source is the single item described by the
+// flags; globs and multiple sources become one item per path inside DEST_PATH;
+// --manifest yields one item per manifest entry rooted at the two paths.
+func buildTransferItems(ctx context.Context, lister directoryLister, sourceEndpointID string, sourcePaths []string, destPath string) ([]transfer.TransferItem, error) {
+	if transferManifest != "" {
+		if len(sourcePaths) != 1 {
+			return nil, fmt.Errorf("--manifest takes a single SOURCE_PATH root")
+		}
+		return manifestItems(sourcePaths[0], destPath)
+	}
+
+	sources := make([]globMatch, 0, len(sourcePaths))
+	for _, p := range sourcePaths {
+		sources = append(sources, globMatch{Path: p, Literal: true})
+	}
+	if !transferNoGlob {
+		var err error
+		sources, _, err = expandPaths(ctx, lister, sourceEndpointID, sourcePaths, transferSourceLocalUser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sources) == 1 && sources[0].Literal {
+		sourcePath := sources[0].Path
+		return []transfer.TransferItem{
+			{
+				DATA_TYPE:         "transfer_item",
+				SourcePath:        sourcePath,
+				DestinationPath:   destPath,
+				Recursive:         transferRecursive,
+				ExternalChecksum:  transferExternalChecksum,
+				ChecksumAlgorithm: transferChecksumAlgo,
+			},
+		}, nil
+	}
+
+	if transferExternalChecksum != "" {
+		return nil, fmt.Errorf("--external-checksum applies to a single source file")
+	}
+
+	// Several sources: DEST_PATH is a directory, and each source lands in it
+	// under its own base name, as with cp a b c dir/.
+	items := make([]transfer.TransferItem, 0, len(sources))
+	seen := make(map[string]string, len(sources))
+	for _, src := range sources {
+		recursive := transferRecursive
+		if !src.Literal {
+			if src.IsDir && !transferRecursive {
+				return nil, fmt.Errorf("%s is a directory; use --recursive to transfer directories", src.Path)
+			}
+			recursive = src.IsDir
+		}
+
+		dest := path.Join(destPath, path.Base(src.Path))
+		if prev, ok := seen[dest]; ok {
+			return nil, fmt.Errorf("%s and %s would both be transferred to %s", prev, src.Path, dest)
+		}
+		seen[dest] = src.Path
+
+		items = append(items, transfer.TransferItem{
+			DATA_TYPE:         "transfer_item",
+			SourcePath:        src.Path,
+			DestinationPath:   dest,
+			Recursive:         recursive,
+			ChecksumAlgorithm: transferChecksumAlgo,
+		})
+	}
+	return items, nil
+}
+
+// manifestItems returns one transfer item per --manifest entry, rooted at
+// sourcePath and destPath.
+func manifestItems(sourcePath, destPath string) ([]transfer.TransferItem, error) {
+	if transferRecursive {
+		return nil, fmt.Errorf("--manifest cannot be combined with --recursive; the manifest already lists every file")
+	}
+	if transferExternalChecksum != "" {
+		return nil, fmt.Errorf("--manifest cannot be combined with --external-checksum; checksums come from the manifest")
+	}
+	m, err := loadManifest(transferManifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Entries) == 0 {
+		return nil, fmt.Errorf("manifest %s has no entries", transferManifest)
+	}
+	return manifestTransferItems(m, sourcePath, destPath), nil
+}
+
 // parseSyncLevel maps Python's named sync levels to the integer the Transfer
 // API expects, while still accepting the raw integers 0-3 for compatibility.
 func parseSyncLevel(v string) (int, error) {
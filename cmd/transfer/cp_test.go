@@ -322,8 +322,8 @@ func TestCpCmd_InvalidArguments(t *testing.T) {
 		t.Error("Expected error for insufficient arguments, got none")
 	}
 
-	if !strings.Contains(err.Error(), "accepts 2 arg(s)") {
-		t.Errorf("Expected error about requiring 2 arguments, got: %v", err)
+	if !strings.Contains(err.Error(), "requires at least 2 arg(s)") {
+		t.Errorf("Expected error about requiring at least 2 arguments, got: %v", err)
 	}
 }
 
@@ -18,6 +18,7 @@ var (
 	deleteLabel         string
 	deleteDeadline      string
 	deleteLocalUser     string
+	deleteNoGlob        bool
 	deleteEnableGlobs   bool
 	deleteNotify        []string
 )
@@ -25,7 +26,7 @@ var (
 // DeleteCmd returns the delete command
 func DeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete ENDPOINT_ID:PATH",
+		Use:   "delete ENDPOINT_ID:PATH...",
 		Short: "Submit a delete task for a path on an endpoint",
 		Long: `Submit a delete task for a file or directory on a Globus endpoint.
 
@@ -33,19 +34,31 @@ This is the task-based delete operation. If --recursive is specified, it will
 delete directories and their contents. If --ignore-missing is specified, the
 task will not error when the path does not exist.
 
+Several paths on the same endpoint may be given, and shell-style globs in
+them are expanded on the endpoint before submission (quote them so your local
+shell does not). Escape a metacharacter with a backslash ('a\[1\].txt'),
+or give --no-glob to take every path literally. With --enable-globs the
+patterns are instead sent as-is for the Transfer service to interpret.
+
 Examples:
   globus transfer delete ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/file
-  globus transfer delete --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/directory`,
-		Args: cobra.ExactArgs(1),
+  globus transfer delete --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/directory
+  globus transfer delete 'ddb59aef-6d04-11e5-ba46-22000b92c6ec:/scratch/run_*.tmp'`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Parse endpoint ID and path
-			endpointID, path := parseEndpointAndPath(args[0])
+			// Parse endpoint ID and paths
+			endpointID, paths, err := splitEndpointArgs(args)
+			if err != nil {
+				return err
+			}
 
-			if path == "/" {
-				return fmt.Errorf("path must be specified for delete command")
+			for _, path := range paths {
+				if path == "/" {
+					return fmt.Errorf("path must be specified for delete command")
+				}
 			}
 
-			return submitDeleteTask(cmd, endpointID, path)
+			return submitDeleteTask(cmd, endpointID, paths)
 		},
 	}
 
@@ -55,14 +68,15 @@ Examples:
 	cmd.Flags().StringVar(&deleteLabel, "label", "", "Set a label for this task")
 	cmd.Flags().StringVar(&deleteDeadline, "deadline", "", "Deadline for the task (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&deleteLocalUser, "local-user", "", "Local user to map to (GCSv5 mapped collections)")
-	cmd.Flags().BoolVar(&deleteEnableGlobs, "enable-globs", false, "Interpret shell-style globs in paths")
+	cmd.Flags().BoolVar(&deleteNoGlob, "no-glob", false, "Treat '*', '?' and '[' in paths literally instead of expanding them")
+	cmd.Flags().BoolVar(&deleteEnableGlobs, "enable-globs", false, "Have the Transfer service interpret shell-style globs instead of expanding them locally")
 	cmd.Flags().StringSliceVar(&deleteNotify, "notify", nil, "Notification settings: any of on, off, succeeded, failed, inactive")
 
 	return cmd
 }
 
-// submitDeleteTask submits a delete task for a path on an endpoint
-func submitDeleteTask(cmd *cobra.Command, endpointID, path string) error {
+// submitDeleteTask submits a delete task for paths on an endpoint
+func submitDeleteTask(cmd *cobra.Command, endpointID string, paths []string) error {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -73,6 +87,11 @@ func submitDeleteTask(cmd *cobra.Command, endpointID, path string) error {
 		return err
 	}
 
+	matches, err := resolveDeletePaths(ctx, transferClient, endpointID, paths, deleteLocalUser, deleteNoGlob, deleteEnableGlobs, deleteRecursive)
+	if err != nil {
+		return err
+	}
+
 	// The v4 SDK requires a submission ID minted from the service and carries
 	// recursion/ignore-missing on the Delete request itself.
 	submissionID, err := transferClient.GetSubmissionID(ctx)
@@ -103,9 +122,7 @@ func submitDeleteTask(cmd *cobra.Command, endpointID, path string) error {
 		NotifyOnSucceeded: notifySucceeded,
 		NotifyOnFailed:    notifyFailed,
 		NotifyOnInactive:  notifyInactive,
-		Items: []transfer.DeleteItem{
-			{DATA_TYPE: "delete_item", Path: path},
-		},
+		Items:             deleteItems(matches),
 	}
 
 	taskResponse, err := transferClient.SubmitDelete(ctx, deleteRequest)
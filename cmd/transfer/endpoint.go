@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/scttfrdmn/globus-go-cli/pkg/output"
-	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
 )
 
 // EndpointCmd returns the endpoint command
@@ -28,8 +27,11 @@ searching, and displaying endpoint details.`,
 	endpointCmd.AddCommand(
 		endpointListCmd(),
 		endpointShowCmd(),
+		endpointCheckCmd(),
 		endpointSearchCmd(),
 		endpointUpdateCmd(),
+		endpointDiffCmd(),
+		endpointCopySettingsCmd(),
 		endpointDeleteCmd(),
 		endpointRoleCmd(),
 		endpointPermissionCmd(),
@@ -41,47 +43,59 @@ searching, and displaying endpoint details.`,
 	return endpointCmd
 }
 
-// Filter options for endpoint listing
-var (
-	filterOwner        string
-	filterRecentlyUsed bool
-	filterIsManagedBy  string
-	filterOrganization string
-	filterRole         string
-	filterSubscribeID  string
-	filterMyTasksOnly  bool
-	searchText         string
-	limit              int
-
-	// Endpoint search-specific filters (globus endpoint search).
-	searchFilterScope      string
-	searchFilterEntityType string
-)
-
 // endpointListCmd returns the endpoint list command
 func endpointListCmd() *cobra.Command {
+	var (
+		filters      endpointFilters
+		recentlyUsed bool
+		myTasks      bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List Globus endpoints",
 		Long: `List Globus Transfer endpoints visible to the current user.
 
 This command lists endpoints that the current user has access to,
-with filtering options to narrow down the results.`,
+with filtering options to narrow down the results. Without --search or
+--filter-scope it lists your own endpoints (filter_scope my-endpoints).
+
+Results are paged automatically until --limit endpoints are found. The
+--filter-subscription-id, --filter-gcs-version, --filter-https,
+--filter-keyword, --organization and --role filters are applied to each page
+client-side, as the Transfer API has no parameters for them.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return listEndpoints(cmd)
+			if err := mergeFilterFlags(cmd, &filters.OwnerID, "filter-owner-id", "owner"); err != nil {
+				return err
+			}
+			if err := mergeFilterFlags(cmd, &filters.Subscription, "filter-subscription-id", "subscription", "managed-by"); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("filter-scope") {
+				switch {
+				case recentlyUsed:
+					filters.Scope = "recently-used"
+				case myTasks:
+					filters.Scope = "in-use"
+				}
+			}
+			return runEndpointSearch(cmd, &filters)
 		},
 	}
 
-	// Add flags for filtering
-	cmd.Flags().StringVar(&filterOwner, "owner", "", "Filter by owner ID")
-	cmd.Flags().BoolVar(&filterRecentlyUsed, "recently-used", false, "Show only recently used endpoints")
-	cmd.Flags().StringVar(&filterIsManagedBy, "managed-by", "", "Filter by managing entity")
-	cmd.Flags().StringVar(&filterOrganization, "organization", "", "Filter by organization")
-	cmd.Flags().StringVar(&filterRole, "role", "", "Filter by role (manager, administrator, etc.)")
-	cmd.Flags().StringVar(&filterSubscribeID, "subscription", "", "Filter by subscription ID")
-	cmd.Flags().BoolVar(&filterMyTasksOnly, "my-tasks", false, "Show only endpoints with my tasks")
-	cmd.Flags().StringVar(&searchText, "search", "", "Search text to filter endpoints")
-	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of endpoints to return")
+	addEndpointFilterFlags(cmd, &filters, "")
+
+	// Older spellings of the shared filters, merged in by mergeFilterFlags.
+	cmd.Flags().String("owner", "", "Filter by owner ID (same as --filter-owner-id)")
+	cmd.Flags().String("subscription", "", "Filter by subscription ID (same as --filter-subscription-id)")
+	cmd.Flags().StringVar(&filters.Fulltext, "search", "", "Search text to filter endpoints")
+	cmd.Flags().BoolVar(&recentlyUsed, "recently-used", false, "Show only recently used endpoints (--filter-scope recently-used)")
+	cmd.Flags().BoolVar(&myTasks, "my-tasks", false, "Show only endpoints with my tasks (--filter-scope in-use)")
+	cmd.Flags().String("managed-by", "", "Filter by managing subscription ID")
+	_ = cmd.Flags().MarkDeprecated("managed-by", "use --filter-subscription-id")
+	cmd.Flags().StringVar(&filters.Organization, "organization", "", "Filter by organization")
+	cmd.Flags().StringVar(&filters.Role, "role", "", "Filter by your effective role (administrator, activity_manager, activity_monitor, access_manager)")
 
 	return cmd
 }
@@ -104,106 +118,43 @@ including server configuration, access rules, and more.`,
 
 // endpointSearchCmd returns the endpoint search command
 func endpointSearchCmd() *cobra.Command {
+	var filters endpointFilters
+
 	cmd := &cobra.Command{
-		Use:   "search SEARCH_TEXT",
+		Use:   "search [SEARCH_TEXT]",
 		Short: "Search for Globus endpoints",
 		Long: `Search for Globus endpoints by name, description, or other attributes.
 
 This command performs a search across all endpoints visible to the current user,
-returning matches based on the provided search text.`,
-		Args: cobra.ExactArgs(1),
+returning matches based on the provided search text. SEARCH_TEXT is required
+with the default --filter-scope all and optional with any narrower scope.
+
+Results are paged automatically until --limit endpoints are found (the
+Transfer API stops at 1000). --filter-subscription-id, --filter-gcs-version,
+--filter-https and --filter-keyword are applied client-side to each page.
+
+With --interactive, the matches are shown in a menu that narrows as you type;
+the chosen endpoint's ID is printed on its own, ready for $(...).
+
+Examples:
+  globus endpoint search "Lab Storage"
+  globus endpoint search --filter-scope administered-by-me --filter-gcs-version 5.4
+  globus endpoint search genomics --filter-https --filter-keyword sequencing
+  EP=$(globus endpoint search lab --interactive)`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			searchText = args[0]
-			return listEndpoints(cmd)
+			if len(args) == 1 {
+				filters.Fulltext = args[0]
+			}
+			return runEndpointSearch(cmd, &filters)
 		},
 	}
 
-	// Add flags for filtering
-	cmd.Flags().StringVar(&searchFilterScope, "filter-scope", "all", "The set of endpoints to search over (all, administered-by-me, my-endpoints, my-gcp-endpoints, recently-used, in-use, shared-by-me, shared-with-me)")
-	cmd.Flags().StringVar(&filterOwner, "filter-owner-id", "", "Filter results to endpoints owned by a specific identity (ID or username)")
-	cmd.Flags().StringVar(&searchFilterEntityType, "filter-entity-type", "", "Filter results to a specific entity type (gcp_mapped_collection, gcp_guest_collection, gcsv5_endpoint, gcsv5_mapped_collection, gcsv5_guest_collection)")
-	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of endpoints to return")
+	addEndpointFilterFlags(cmd, &filters, "all")
 
 	return cmd
 }
 
-// listEndpoints lists Globus endpoints
-func listEndpoints(cmd *cobra.Command) error {
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Build a v4 Transfer client authorized for the current profile.
-	transferClient, err := getClient(ctx)
-	if err != nil {
-		return err
-	}
-
-	// Prepare options for endpoint search.
-	options := &transfer.EndpointSearchOptions{
-		Limit: limit,
-	}
-
-	if filterOwner != "" {
-		options.FilterOwnerID = filterOwner
-	}
-
-	// Filter scope handling.
-	if filterRecentlyUsed {
-		options.FilterScope = "recently-used"
-	} else if filterMyTasksOnly {
-		options.FilterScope = "in-use"
-	}
-
-	// endpoint search: --filter-scope (default "all" means no server filter) and
-	// --filter-entity-type. These flags are only registered on the search command.
-	if searchFilterScope != "" && searchFilterScope != "all" {
-		options.FilterScope = searchFilterScope
-	}
-	if searchFilterEntityType != "" {
-		options.FilterEntityType = searchFilterEntityType
-	}
-
-	// Search text for full text search.
-	if searchText != "" {
-		options.FilterFulltext = searchText
-	}
-
-	// Get the endpoints
-	endpoints, err := transferClient.EndpointSearch(ctx, options)
-	if err != nil {
-		return fmt.Errorf("failed to list endpoints: %w", err)
-	}
-
-	// Route all formats through the shared formatter so -F (text/json/unix) and
-	// --jmespath/--jq work uniformly. For JSON/JMESPath, emit the raw endpoint
-	// documents; for text/unix, a projected row set.
-	format := viper.GetString("format")
-	formatter := output.NewFormatter(format, cmd.OutOrStdout())
-
-	if formatter.Format == output.FormatJSON {
-		// Emit the enveloped service document ({"DATA_TYPE","DATA":[...],...}),
-		// matching the Python CLI's JSON output shape.
-		return formatter.FormatOutput(endpoints, nil)
-	}
-
-	type endpointRow struct {
-		ID        string
-		Name      string
-		Owner     string
-		Activated bool
-		Public    bool
-	}
-	rows := make([]endpointRow, 0, len(endpoints.Data))
-	for _, e := range endpoints.Data {
-		rows = append(rows, endpointRow{
-			ID: e.ID, Name: e.DisplayName, Owner: e.Owner,
-			Activated: e.Activated, Public: e.Public,
-		})
-	}
-	return formatter.FormatOutput(rows, []string{"ID", "Name", "Owner", "Activated", "Public"})
-}
-
 // showEndpoint shows details for a specific endpoint
 func showEndpoint(cmd *cobra.Command, endpointID string) error {
 	// Create context with timeout
@@ -240,7 +240,8 @@ func endpointRoleCmd() *cobra.Command {
 	roleCmd := &cobra.Command{
 		Use:   "role",
 		Short: "Manage endpoint role assignments",
-		Long:  `List, show, create, and delete role assignments on a Globus endpoint.`,
+		Long: `List, show, create, and delete role assignments on a Globus endpoint, or
+create them in bulk from CSV with import.`,
 	}
 
 	roleCmd.AddCommand(
@@ -248,6 +249,7 @@ func endpointRoleCmd() *cobra.Command {
 		endpointRoleShowCmd(),
 		endpointRoleCreateCmd(),
 		endpointRoleDeleteCmd(),
+		endpointRoleImportCmd(),
 	)
 
 	return roleCmd
@@ -398,7 +400,9 @@ func endpointPermissionCmd() *cobra.Command {
 	permCmd := &cobra.Command{
 		Use:   "permission",
 		Short: "Manage endpoint access rules (ACLs)",
-		Long:  `List, show, create, update, and delete access rules on a Globus endpoint.`,
+		Long: `List, show, create, update, and delete access rules on a Globus endpoint,
+sync them to a YAML file with apply, create them in bulk from CSV with
+import, or review them with audit.`,
 	}
 
 	permCmd.AddCommand(
@@ -407,6 +411,9 @@ func endpointPermissionCmd() *cobra.Command {
 		endpointPermissionCreateCmd(),
 		endpointPermissionUpdateCmd(),
 		endpointPermissionDeleteCmd(),
+		endpointPermissionApplyCmd(),
+		endpointPermissionImportCmd(),
+		endpointPermissionAuditCmd(),
 	)
 
 	return permCmd
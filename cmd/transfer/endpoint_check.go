@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// Health check statuses, from best to worst.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// Exit codes of endpoint check. A failure shares 1 with ordinary errors, so a
+// check that could not run at all is never mistaken for a mere warning.
+const (
+	checkExitFail = 1
+	checkExitWarn = 2
+)
+
+// checkResult is one row of an endpoint check report.
+type checkResult struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// checkHeaders are the table columns of an endpoint check report.
+var checkHeaders = []string{"Check", "Status", "Detail"}
+
+// endpointCheckClient is the subset of the Transfer client used by endpoint
+// check.
+type endpointCheckClient interface {
+	GetEndpoint(ctx context.Context, endpointID string) (*transfer.Endpoint, error)
+	EndpointServerList(ctx context.Context, endpointID string) (transfer.GenericResponse, error)
+	ListDirectory(ctx context.Context, endpointID, path string, options *transfer.ListDirectoryOptions) (*transfer.DirectoryListing, error)
+	MyEffectivePauseRuleList(ctx context.Context, endpointID string) (transfer.GenericResponse, error)
+}
+
+// endpointCheckCmd returns the "endpoint check" command.
+func endpointCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check ENDPOINT_ID [PATH]",
+		Short: "Run a health check against an endpoint or collection",
+		Long: `Check that an endpoint or collection is usable, in one step:
+
+  endpoint       the endpoint document can be fetched
+  connectivity   Globus Connect Personal is connected, or the GCS Manager
+                 of a Globus Connect Server v5 endpoint answers /api/info
+  ls             PATH (default: the endpoint's default directory) lists
+  authorization  no data_access consent or session re-authentication is
+                 needed first (a warning when the listing failed otherwise)
+  subscription   the subscription the endpoint is managed under
+  pause rules    administrative pause rules currently in effect for you
+
+Each check passes, warns or fails. The command exits 0 when every check
+passes, 2 when some only warn and 1 when any fails.
+
+Examples:
+  globus endpoint check ENDPOINT_ID
+  globus endpoint check COLLECTION_ID /project/data
+  globus endpoint check ENDPOINT_ID -F json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 2 {
+				path = args[1]
+			}
+			return checkEndpoint(cmd, args[0], path)
+		},
+	}
+}
+
+// checkEndpoint runs the checks, prints the report and sets the exit code.
+func checkEndpoint(cmd *cobra.Command, endpointID, path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := runEndpointChecks(ctx, client, probeGCSManager, endpointID, path, time.Now())
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if err := formatter.FormatOutput(results, checkHeaders); err != nil {
+		return err
+	}
+
+	// The report is the verdict: don't follow it with cobra's usage text.
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	switch worstCheckStatus(results) {
+	case checkFail:
+		return &output.ExitCodeError{Code: checkExitFail, Err: fmt.Errorf("endpoint %s failed its health check", endpointID)}
+	case checkWarn:
+		return &output.ExitCodeError{Code: checkExitWarn}
+	}
+	return nil
+}
+
+// runEndpointChecks performs every check. probe fetches a GCS Manager's info
+// document. If the endpoint itself cannot be fetched nothing else is checked.
+func runEndpointChecks(ctx context.Context, client endpointCheckClient, probe func(ctx context.Context, managerURL string) error, endpointID, path string, now time.Time) []checkResult {
+	ep, err := client.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return []checkResult{{Check: "endpoint", Status: checkFail, Detail: err.Error()}}
+	}
+	results := []checkResult{{Check: "endpoint", Status: checkPass, Detail: endpointLabel(ep)}}
+
+	results = append(results, checkConnectivity(ctx, client, probe, ep))
+	results = append(results, checkListing(ctx, client, endpointID, path)...)
+
+	if ep.SubscriptionID != "" {
+		results = append(results, checkResult{Check: "subscription", Status: checkPass, Detail: ep.SubscriptionID})
+	} else {
+		results = append(results, checkResult{Check: "subscription", Status: checkPass, Detail: "none (not a managed endpoint)"})
+	}
+
+	results = append(results, checkPauseRules(ctx, client, endpointID, now))
+	return results
+}
+
+// endpointLabel describes an endpoint for the first report row.
+func endpointLabel(ep *transfer.Endpoint) string {
+	kind := "endpoint"
+	if ep.GCSManagerURL != "" {
+		kind = "Globus Connect Server v5"
+		if ep.GCSVersion != "" {
+			kind += " (" + ep.GCSVersion + ")"
+		}
+	}
+	return fmt.Sprintf("%s, %s", ep.DisplayName, kind)
+}
+
+// checkConnectivity probes the GCS Manager of a GCSv5 endpoint, or the
+// is_connected flag that Globus Connect Personal reports in the server list.
+func checkConnectivity(ctx context.Context, client endpointCheckClient, probe func(context.Context, string) error, ep *transfer.Endpoint) checkResult {
+	if ep.GCSManagerURL != "" {
+		if err := probe(ctx, ep.GCSManagerURL); err != nil {
+			return checkResult{Check: "connectivity", Status: checkFail, Detail: fmt.Sprintf("GCS Manager %s unreachable: %v", ep.GCSManagerURL, err)}
+		}
+		return checkResult{Check: "connectivity", Status: checkPass, Detail: "GCS Manager " + ep.GCSManagerURL + " reachable"}
+	}
+
+	resp, err := client.EndpointServerList(ctx, ep.ID)
+	if err != nil {
+		return checkResult{Check: "connectivity", Status: checkWarn, Detail: fmt.Sprintf("could not list servers: %v", err)}
+	}
+	for _, item := range genericList(resp, "DATA") {
+		server, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		connected, isGCP := server["is_connected"].(bool)
+		if !isGCP {
+			continue
+		}
+		if connected {
+			return checkResult{Check: "connectivity", Status: checkPass, Detail: "Globus Connect Personal connected"}
+		}
+		return checkResult{Check: "connectivity", Status: checkFail, Detail: "Globus Connect Personal is not connected"}
+	}
+	return checkResult{Check: "connectivity", Status: checkPass, Detail: "no connectivity probe for this endpoint type"}
+}
+
+// checkListing lists path and reports the ls and authorization rows. Consent
+// and session requirements are warnings: the endpoint is fine, but the caller
+// has to re-authenticate before using it.
+func checkListing(ctx context.Context, client endpointCheckClient, endpointID, path string) []checkResult {
+	target := path
+	if target == "" {
+		target = "default directory"
+	}
+
+	_, err := client.ListDirectory(ctx, endpointID, path, &transfer.ListDirectoryOptions{Limit: 1})
+	if err == nil {
+		return []checkResult{
+			{Check: "ls", Status: checkPass, Detail: "listed " + target},
+			{Check: "authorization", Status: checkPass, Detail: "no additional consent or session required"},
+		}
+	}
+
+	if req := authorizationRequirement(err); req != "" {
+		return []checkResult{
+			{Check: "ls", Status: checkWarn, Detail: "could not list " + target + " until authorization is resolved"},
+			{Check: "authorization", Status: checkWarn, Detail: req},
+		}
+	}
+	return []checkResult{
+		{Check: "ls", Status: checkFail, Detail: fmt.Sprintf("listing %s failed: %v", target, err)},
+		{Check: "authorization", Status: checkWarn, Detail: "not determined: listing failed"},
+	}
+}
+
+// authorizationRequirement describes the consent or session requirement an
+// API error carries, or returns "" when it carries none.
+func authorizationRequirement(err error) string {
+	var apiErr *core.APIError
+	if !errors.As(err, &apiErr) || apiErr.Details == nil {
+		return ""
+	}
+
+	if apiErr.Code == "ConsentRequired" {
+		scopes := toStrings(apiErr.Details["required_scopes"])
+		if len(scopes) == 0 {
+			return "consent required"
+		}
+		return "consent required; run: globus session consent " + quoteAll(scopes)
+	}
+
+	params, ok := apiErr.Details["authorization_parameters"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	var needs []string
+	if ids := toStrings(params["session_required_identities"]); len(ids) > 0 {
+		needs = append(needs, "identities "+strings.Join(ids, ", "))
+	}
+	if domains := toStrings(params["session_required_single_domain"]); len(domains) > 0 {
+		needs = append(needs, "a login from "+strings.Join(domains, " or "))
+	}
+	if policies := toStrings(params["session_required_policies"]); len(policies) > 0 {
+		needs = append(needs, "policies "+strings.Join(policies, ", "))
+	}
+	if mfa, _ := params["session_required_mfa"].(bool); mfa {
+		needs = append(needs, "multi-factor authentication")
+	}
+	if len(needs) == 0 {
+		return ""
+	}
+	return "session re-authentication required (" + strings.Join(needs, "; ") + "); run: globus session update"
+}
+
+// checkPauseRules reports the pause rules that apply to the caller.
+func checkPauseRules(ctx context.Context, client endpointCheckClient, endpointID string, now time.Time) checkResult {
+	resp, err := client.MyEffectivePauseRuleList(ctx, endpointID)
+	if err != nil {
+		return checkResult{Check: "pause rules", Status: checkWarn, Detail: fmt.Sprintf("could not list pause rules: %v", err)}
+	}
+
+	var active, scheduled []string
+	for _, item := range genericList(resp, "DATA") {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		msg := genericString(rule, "message")
+		if msg == "" {
+			msg = genericString(rule, "id")
+		}
+		if start, ok := parseACLTime(genericString(rule, "start_time")); ok && start.After(now) {
+			scheduled = append(scheduled, fmt.Sprintf("%s (from %s)", msg, start.UTC().Format(time.RFC3339)))
+			continue
+		}
+		active = append(active, msg)
+	}
+	sort.Strings(active)
+	sort.Strings(scheduled)
+
+	switch {
+	case len(active) > 0:
+		detail := fmt.Sprintf("%d active: %s", len(active), strings.Join(active, "; "))
+		if len(scheduled) > 0 {
+			detail += fmt.Sprintf("; %d scheduled", len(scheduled))
+		}
+		return checkResult{Check: "pause rules", Status: checkWarn, Detail: detail}
+	case len(scheduled) > 0:
+		return checkResult{Check: "pause rules", Status: checkPass, Detail: fmt.Sprintf("none active; %d scheduled: %s", len(scheduled), strings.Join(scheduled, "; "))}
+	}
+	return checkResult{Check: "pause rules", Status: checkPass, Detail: "none"}
+}
+
+// worstCheckStatus returns the most severe status in results.
+func worstCheckStatus(results []checkResult) string {
+	worst := checkPass
+	for _, r := range results {
+		switch {
+		case r.Status == checkFail:
+			return checkFail
+		case r.Status == checkWarn:
+			worst = checkWarn
+		}
+	}
+	return worst
+}
+
+// probeGCSManager fetches the unauthenticated /api/info document of a GCS
+// Manager, so the check never needs the endpoint's management consent.
+func probeGCSManager(ctx context.Context, managerURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(managerURL, "/")+"/api/info", nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// toStrings converts a decoded JSON string list into a []string.
+func toStrings(v interface{}) []string {
+	list, _ := v.([]interface{})
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// quoteAll single-quotes each value for a copy-pasteable command line.
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	return strings.Join(quoted, " ")
+}
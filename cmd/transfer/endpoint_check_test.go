@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// stubCheckClient answers endpoint check calls from canned values.
+type stubCheckClient struct {
+	ep      *transfer.Endpoint
+	epErr   error
+	servers transfer.GenericResponse
+	lsErr   error
+	pauses  transfer.GenericResponse
+}
+
+func (s *stubCheckClient) GetEndpoint(context.Context, string) (*transfer.Endpoint, error) {
+	return s.ep, s.epErr
+}
+
+func (s *stubCheckClient) EndpointServerList(context.Context, string) (transfer.GenericResponse, error) {
+	return s.servers, nil
+}
+
+func (s *stubCheckClient) ListDirectory(context.Context, string, string, *transfer.ListDirectoryOptions) (*transfer.DirectoryListing, error) {
+	if s.lsErr != nil {
+		return nil, s.lsErr
+	}
+	return &transfer.DirectoryListing{}, nil
+}
+
+func (s *stubCheckClient) MyEffectivePauseRuleList(context.Context, string) (transfer.GenericResponse, error) {
+	return s.pauses, nil
+}
+
+// checkStatuses maps check names to statuses.
+func checkStatuses(results []checkResult) map[string]string {
+	m := map[string]string{}
+	for _, r := range results {
+		m[r.Check] = r.Status
+	}
+	return m
+}
+
+// TestRunEndpointChecks covers a healthy GCSv5 endpoint, a disconnected GCP
+// endpoint with an active pause rule, a consent requirement and an endpoint
+// that cannot be fetched.
+func TestRunEndpointChecks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	reachable := func(context.Context, string) error { return nil }
+
+	healthy := &stubCheckClient{
+		ep:     &transfer.Endpoint{ID: "ep", DisplayName: "Lab", GCSManagerURL: "https://gcs.example.org", SubscriptionID: "sub"},
+		pauses: transfer.GenericResponse{"DATA": []interface{}{map[string]interface{}{"message": "later", "start_time": "2026-12-01T00:00:00+00:00"}}},
+	}
+	results := runEndpointChecks(ctx, healthy, reachable, "ep", "", now)
+	if worstCheckStatus(results) != checkPass || len(results) != 6 {
+		t.Errorf("healthy endpoint = %+v", results)
+	}
+
+	gcp := &stubCheckClient{
+		ep:      &transfer.Endpoint{ID: "ep", DisplayName: "Laptop"},
+		servers: transfer.GenericResponse{"DATA": []interface{}{map[string]interface{}{"is_connected": false}}},
+		pauses:  transfer.GenericResponse{"DATA": []interface{}{map[string]interface{}{"message": "maintenance", "start_time": "2026-11-01T06:00:00+00:00"}}},
+	}
+	got := checkStatuses(runEndpointChecks(ctx, gcp, reachable, "ep", "/~/", now))
+	if got["connectivity"] != checkFail || got["pause rules"] != checkWarn {
+		t.Errorf("GCP endpoint = %v", got)
+	}
+
+	consent := &stubCheckClient{
+		ep:    &transfer.Endpoint{ID: "ep", DisplayName: "Lab"},
+		lsErr: &core.APIError{StatusCode: 403, Code: "ConsentRequired", Details: map[string]interface{}{"required_scopes": []interface{}{"urn:scope"}}},
+	}
+	results = runEndpointChecks(ctx, consent, reachable, "ep", "", now)
+	if got := checkStatuses(results); got["ls"] != checkWarn || got["authorization"] != checkWarn || worstCheckStatus(results) != checkWarn {
+		t.Errorf("consent required = %v", got)
+	}
+	for _, r := range results {
+		if r.Check == "authorization" && !strings.Contains(r.Detail, "globus session consent 'urn:scope'") {
+			t.Errorf("authorization detail = %q", r.Detail)
+		}
+	}
+
+	broken := &stubCheckClient{
+		ep:    &transfer.Endpoint{ID: "ep", DisplayName: "Lab"},
+		lsErr: &core.APIError{StatusCode: 502, Code: "ExternalError.DirListingFailed"},
+	}
+	for _, r := range runEndpointChecks(ctx, broken, reachable, "ep", "", now) {
+		if r.Check == "authorization" && (r.Status != checkWarn || r.Detail != "not determined: listing failed") {
+			t.Errorf("failed listing: authorization = %+v", r)
+		}
+	}
+
+	missing := &stubCheckClient{epErr: errors.New("not found")}
+	results = runEndpointChecks(ctx, missing, reachable, "ep", "", now)
+	if len(results) != 1 || results[0].Status != checkFail {
+		t.Errorf("missing endpoint = %+v", results)
+	}
+}
+
+// TestAuthorizationRequirement checks session requirement detection.
+func TestAuthorizationRequirement(t *testing.T) {
+	err := &core.APIError{StatusCode: 403, Details: map[string]interface{}{
+		"authorization_parameters": map[string]interface{}{
+			"session_required_single_domain": []interface{}{"example.org"},
+			"session_required_mfa":           true,
+		},
+	}}
+	got := authorizationRequirement(err)
+	if !strings.Contains(got, "a login from example.org") || !strings.Contains(got, "multi-factor") {
+		t.Errorf("authorizationRequirement = %q", got)
+	}
+	if got := authorizationRequirement(&core.APIError{StatusCode: 404, Details: map[string]interface{}{}}); got != "" {
+		t.Errorf("plain 404 = %q", got)
+	}
+}
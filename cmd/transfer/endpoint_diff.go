@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// endpointSettingFields are the endpoint document fields that endpoint update
+// can set, in display order.
+var endpointSettingFields = []string{
+	"display_name", "description", "organization", "department",
+	"contact_email", "contact_info", "info_link", "keywords",
+	"default_directory", "public", "force_encryption", "disable_verify",
+	"subscription_id", "network_use", "max_concurrency", "preferred_concurrency",
+	"max_parallelism", "preferred_parallelism", "user_message", "user_message_link",
+	"oauth_server", "myproxy_server", "myproxy_dn", "location",
+}
+
+// endpointUncopiedFields are compared by endpoint diff but left out of
+// copy-settings unless named in --fields: they identify the endpoint, need
+// subscription-manager rights, or describe one host's servers.
+var endpointUncopiedFields = map[string]bool{
+	"display_name":    true,
+	"description":     true,
+	"subscription_id": true,
+	"oauth_server":    true,
+	"myproxy_server":  true,
+	"myproxy_dn":      true,
+	"location":        true,
+}
+
+// endpointNetworkFields only take effect, and are only accepted by Transfer,
+// when network_use is "custom".
+var endpointNetworkFields = []string{"max_concurrency", "preferred_concurrency", "max_parallelism", "preferred_parallelism"}
+
+// endpointFieldDiff is one compared setting.
+type endpointFieldDiff struct {
+	Field   string      `json:"field"`
+	Left    interface{} `json:"left"`
+	Right   interface{} `json:"right"`
+	Differs bool        `json:"differs"`
+}
+
+// endpointDiffCmd returns the "endpoint diff" command.
+func endpointDiffCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "diff ENDPOINT_ID OTHER_ENDPOINT_ID",
+		Short: "Compare the settings of two endpoints",
+		Long: `Compare the settings that 'endpoint update' can change on two endpoints
+or collections, side by side. Only differing fields are shown unless --all is
+given.
+
+Compared fields: ` + strings.Join(endpointSettingFields, ", ") + `.
+
+Examples:
+  globus endpoint diff OLD_ENDPOINT_ID NEW_ENDPOINT_ID
+  globus endpoint diff OLD_ENDPOINT_ID NEW_ENDPOINT_ID --all -F json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			left, err := getEndpointDocument(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			right, err := getEndpointDocument(ctx, args[1])
+			if err != nil {
+				return err
+			}
+
+			diffs := diffEndpointSettings(left, right)
+			if !all {
+				diffs = differingFields(diffs)
+			}
+
+			format := viper.GetString("format")
+			formatter := output.NewFormatter(format, cmd.OutOrStdout())
+			if formatter.Format != output.FormatText {
+				return formatter.FormatOutput(diffs, []string{"Field", "Left", "Right", "Differs"})
+			}
+			printEndpointDiff(cmd.OutOrStdout(), endpointLabelOf(args[0], left), endpointLabelOf(args[1], right), diffs)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Show every compared field, not only the differing ones")
+
+	return cmd
+}
+
+// endpointCopySettingsCmd returns the "endpoint copy-settings" command.
+func endpointCopySettingsCmd() *cobra.Command {
+	var (
+		fields []string
+		yes    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "copy-settings FROM_ENDPOINT_ID TO_ENDPOINT_ID",
+		Short: "Copy settings from one endpoint to another",
+		Long: `Update TO_ENDPOINT_ID so its settings match FROM_ENDPOINT_ID.
+
+The update document is built from 'endpoint diff FROM TO': only differing
+fields are sent. By default every compared field is copied except
+display_name, description, subscription_id and the per-host oauth_server,
+myproxy_server, myproxy_dn and location; name fields with --fields to choose
+exactly what is copied (including those).
+
+Concurrency and parallelism are only copied when the resulting network_use is
+"custom", as Transfer rejects them otherwise; naming them in --fields when it
+is not is an error.
+
+The changes are listed and confirmed before the update (skip with --yes).
+
+Examples:
+  globus endpoint copy-settings OLD_ENDPOINT_ID NEW_ENDPOINT_ID
+  globus endpoint copy-settings OLD_ENDPOINT_ID NEW_ENDPOINT_ID --fields contact_email,contact_info,info_link`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			for _, f := range fields {
+				if !containsFold(endpointSettingFields, f) {
+					return fmt.Errorf("unknown field %q (use %s)", f, strings.Join(endpointSettingFields, ", "))
+				}
+			}
+
+			from, err := getEndpointDocument(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			to, err := getEndpointDocument(ctx, args[1])
+			if err != nil {
+				return err
+			}
+
+			doc, changes, err := buildEndpointCopyDoc(from, to, fields)
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Settings already match; nothing to copy.")
+				return nil
+			}
+
+			printEndpointDiff(cmd.OutOrStdout(), endpointLabelOf(args[0], from), endpointLabelOf(args[1], to), changes)
+			if !yes && !confirmAction(fmt.Sprintf("Update %d field(s) on %s", len(changes), args[1])) {
+				fmt.Fprintln(cmd.OutOrStdout(), "Operation canceled.")
+				return nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			resp, err := client.UpdateEndpoint(ctx, args[1], doc)
+			if err != nil {
+				return fmt.Errorf("failed to update endpoint: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Endpoint %s updated.\n", args[1])
+			printResponseCodeMessage(cmd, resp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only copy these fields (comma-separated)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+// diffEndpointSettings compares the settings fields of two endpoint documents.
+func diffEndpointSettings(left, right map[string]interface{}) []endpointFieldDiff {
+	diffs := make([]endpointFieldDiff, 0, len(endpointSettingFields))
+	for _, field := range endpointSettingFields {
+		l, r := left[field], right[field]
+		diffs = append(diffs, endpointFieldDiff{
+			Field:   field,
+			Left:    l,
+			Right:   r,
+			Differs: !sameSetting(l, r),
+		})
+	}
+	return diffs
+}
+
+// differingFields keeps the diffs whose values differ.
+func differingFields(diffs []endpointFieldDiff) []endpointFieldDiff {
+	kept := make([]endpointFieldDiff, 0, len(diffs))
+	for _, d := range diffs {
+		if d.Differs {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// buildEndpointCopyDoc returns the update document that makes to's settings
+// match from's, limited to fields (or the default copied set), together with
+// the diffs it resolves. Concurrency and parallelism only apply to custom
+// network use, so they are left out otherwise, and named in an error when
+// fields asked for them.
+func buildEndpointCopyDoc(from, to map[string]interface{}, fields []string) (map[string]interface{}, []endpointFieldDiff, error) {
+	selected := map[string]bool{}
+	for _, f := range fields {
+		selected[strings.ToLower(f)] = true
+	}
+
+	doc := map[string]interface{}{"DATA_TYPE": "endpoint"}
+	var changes []endpointFieldDiff
+	for _, d := range differingFields(diffEndpointSettings(from, to)) {
+		if len(selected) > 0 && !selected[d.Field] || len(selected) == 0 && endpointUncopiedFields[d.Field] {
+			continue
+		}
+		doc[d.Field] = d.Left
+		changes = append(changes, d)
+	}
+
+	networkUse, ok := doc["network_use"]
+	if !ok {
+		networkUse = to["network_use"]
+	}
+	if networkUse != "custom" {
+		kept := changes[:0]
+		var dropped []string
+		for _, c := range changes {
+			if containsFold(endpointNetworkFields, c.Field) {
+				delete(doc, c.Field)
+				if selected[c.Field] {
+					dropped = append(dropped, c.Field)
+				}
+				continue
+			}
+			kept = append(kept, c)
+		}
+		if len(dropped) > 0 {
+			return nil, nil, fmt.Errorf("cannot copy %s: they only apply when network_use is \"custom\", and it would be %s (add network_use to --fields)", strings.Join(dropped, ", "), formatSetting(networkUse))
+		}
+		changes = kept
+	}
+	return doc, changes, nil
+}
+
+// sameSetting compares two document values, treating null and "" alike.
+func sameSetting(a, b interface{}) bool {
+	if isEmptySetting(a) && isEmptySetting(b) {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isEmptySetting(v interface{}) bool {
+	s, ok := v.(string)
+	return v == nil || ok && s == ""
+}
+
+// formatSetting renders a document value for the text diff.
+func formatSetting(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if t == "" {
+			return "-"
+		}
+		return t
+	case float64:
+		return fmt.Sprintf("%g", t)
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = formatSetting(item)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// endpointLabelOf names an endpoint column by display name, falling back to
+// its ID.
+func endpointLabelOf(id string, doc map[string]interface{}) string {
+	if name := genericString(doc, "display_name"); name != "" {
+		return name
+	}
+	return id
+}
+
+// printEndpointDiff prints diffs as a Field/left/right table.
+func printEndpointDiff(w io.Writer, leftLabel, rightLabel string, diffs []endpointFieldDiff) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No differences.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Field\t%s\t%s\n", leftLabel, rightLabel)
+	for _, d := range diffs {
+		marker := ""
+		if d.Differs {
+			marker = "  *"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s%s\n", d.Field, formatSetting(d.Left), formatSetting(d.Right), marker)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestDiffEndpointSettings checks that null and "" compare equal and that
+// only settings fields are compared.
+func TestDiffEndpointSettings(t *testing.T) {
+	left := map[string]interface{}{"display_name": "Old", "contact_email": "a@example.org", "description": nil, "id": "1", "force_encryption": true}
+	right := map[string]interface{}{"display_name": "New", "contact_email": "a@example.org", "description": "", "id": "2", "force_encryption": false}
+
+	diffs := differingFields(diffEndpointSettings(left, right))
+	var fields []string
+	for _, d := range diffs {
+		fields = append(fields, d.Field)
+	}
+	if got := strings.Join(fields, ","); got != "display_name,force_encryption" {
+		t.Errorf("differing fields = %s", got)
+	}
+
+	var out bytes.Buffer
+	printEndpointDiff(&out, "Old", "New", diffs)
+	if !strings.Contains(out.String(), "force_encryption") || !strings.Contains(out.String(), "true") {
+		t.Errorf("diff table:\n%s", out.String())
+	}
+}
+
+// TestBuildEndpointCopyDoc covers the default field set, --fields and the
+// network_use rule for concurrency.
+func TestBuildEndpointCopyDoc(t *testing.T) {
+	from := map[string]interface{}{
+		"display_name": "Old", "organization": "Lab", "keywords": "a,b",
+		"network_use": "custom", "max_concurrency": float64(8),
+	}
+	to := map[string]interface{}{"display_name": "New", "network_use": "normal"}
+
+	doc, changes, err := buildEndpointCopyDoc(from, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["display_name"]; ok {
+		t.Error("display_name copied by default")
+	}
+	if doc["organization"] != "Lab" || doc["keywords"] != "a,b" || doc["network_use"] != "custom" || doc["max_concurrency"] != float64(8) {
+		t.Errorf("default doc = %v", doc)
+	}
+	if len(changes) != 4 {
+		t.Errorf("changes = %+v", changes)
+	}
+
+	doc, _, err = buildEndpointCopyDoc(from, to, []string{"display_name"})
+	if err != nil || doc["display_name"] != "Old" {
+		t.Errorf("--fields display_name not copied: %v, %v", doc, err)
+	}
+	if _, _, err := buildEndpointCopyDoc(from, to, []string{"display_name", "max_concurrency"}); err == nil || !strings.Contains(err.Error(), "max_concurrency") {
+		t.Errorf("max_concurrency while network_use stays normal: err = %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package transfer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -141,18 +142,20 @@ func endpointManagerACLListCmd() *cobra.Command {
 
 func endpointManagerTaskListCmd() *cobra.Command {
 	var (
-		filterStatus   []string
-		filterEndpoint string
-		filterOwnerID  string
-		taskLimit      int
+		filters   taskFilters
+		taskLimit int
 	)
 
 	cmd := &cobra.Command{
 		Use:   "task-list",
 		Short: "List tasks on managed endpoints as an administrator",
-		Args:  cobra.NoArgs,
+		Long: `List tasks on managed endpoints as an administrator.
+
+Pages are followed until --limit tasks are listed; --limit 0 lists every
+matching task.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
 			client, err := getClient(ctx)
@@ -160,25 +163,21 @@ func endpointManagerTaskListCmd() *cobra.Command {
 				return err
 			}
 
-			options := &transfer.EndpointManagerTaskListOptions{
-				FilterStatus:   filterStatus,
-				FilterOwnerID:  filterOwnerID,
-				FilterEndpoint: filterEndpoint,
-				Limit:          taskLimit,
+			options, err := filters.options(ctx, newPrincipalResolver())
+			if err != nil {
+				return err
 			}
 
-			resp, err := client.EndpointManagerTaskList(ctx, options)
+			tasks, err := listEndpointManagerTasks(ctx, client, options, taskLimit)
 			if err != nil {
-				return fmt.Errorf("failed to list tasks: %w", err)
+				return err
 			}
-			return formatTypedData(cmd, resp.Data)
+			return formatTypedData(cmd, tasks)
 		},
 	}
 
-	cmd.Flags().StringSliceVar(&filterStatus, "filter-status", nil, "Filter by task status (repeatable or comma-separated)")
-	cmd.Flags().StringVar(&filterEndpoint, "filter-endpoint", "", "Filter by endpoint ID")
-	cmd.Flags().StringVar(&filterOwnerID, "filter-owner-id", "", "Filter by task owner identity ID")
-	cmd.Flags().IntVar(&taskLimit, "limit", 25, "Maximum number of tasks to return")
+	addTaskFilterFlags(cmd, &filters)
+	cmd.Flags().IntVar(&taskLimit, "limit", 25, "Maximum number of tasks to return (0 for all)")
 
 	return cmd
 }
@@ -260,15 +259,37 @@ func endpointManagerTaskPauseInfoCmd() *cobra.Command {
 	}
 }
 
+// bulkTaskHelp is the selection help shared by task-cancel, task-pause and
+// task-resume.
+const bulkTaskHelp = `Select tasks either by TASK_ID or with --filter-* options. Filtered
+selections follow every page of the task list (defaulting to ACTIVE and
+INACTIVE tasks when --filter-status is not given), print a preview with the
+match count, and ask for confirmation unless --yes is given.`
+
 func endpointManagerTaskCancelCmd() *cobra.Command {
-	var message string
+	var (
+		message  string
+		filters  taskFilters
+		yes      bool
+		watch    bool
+		interval time.Duration
+	)
 
 	cmd := &cobra.Command{
-		Use:   "task-cancel TASK_ID...",
+		Use:   "task-cancel [TASK_ID...]",
 		Short: "Cancel one or more tasks as an administrator",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Cancel tasks as an administrator.
+
+` + bulkTaskHelp + `
+
+Cancellation runs asynchronously; --watch follows it (as 'cancel-status
+--watch' would) until every task is processed.
+
+Examples:
+  globus endpoint-manager task-cancel TASK_ID TASK_ID
+  globus endpoint-manager task-cancel --filter-endpoint ENDPOINT_ID --filter-owner-id bob@example.org --message "Endpoint maintenance" --watch`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 			defer cancel()
 
 			client, err := getClient(ctx)
@@ -276,26 +297,66 @@ func endpointManagerTaskCancelCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.EndpointManagerCancelTasks(ctx, args, message)
+			taskIDs, err := bulkTaskIDs(ctx, cmd, client, args, &filters, "Cancel", yes)
+			if err != nil || taskIDs == nil {
+				return err
+			}
+
+			resp, err := client.EndpointManagerCancelTasks(ctx, taskIDs, message)
 			if err != nil {
 				return fmt.Errorf("failed to cancel tasks: %w", err)
 			}
-			return formatGenericResponse(cmd, resp)
+			if !watch {
+				return formatGenericResponse(cmd, resp)
+			}
+
+			adminCancelID := genericString(resp, "id")
+			if adminCancelID == "" {
+				return formatGenericResponse(cmd, resp)
+			}
+			final, err := watchCancelStatus(ctx, cmd, client, adminCancelID, interval)
+			if err != nil {
+				return err
+			}
+			return formatGenericResponse(cmd, final)
 		},
 	}
 
 	cmd.Flags().StringVar(&message, "message", "", "Message describing the reason for cancellation")
+	addBulkTaskFlags(cmd, &filters, &yes)
+	cmd.Flags().BoolVar(&watch, "watch", false, "Follow the admin cancel until every task is processed")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval for --watch")
 
 	return cmd
 }
 
+// addBulkTaskFlags registers the selection flags of the bulk task commands.
+func addBulkTaskFlags(cmd *cobra.Command, filters *taskFilters, yes *bool) {
+	addTaskFilterFlags(cmd, filters)
+	cmd.Flags().BoolVarP(yes, "yes", "y", false, "Act on filtered tasks without asking for confirmation")
+}
+
 func endpointManagerCancelStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		watch    bool
+		interval time.Duration
+		timeout  time.Duration
+	)
+
+	cmd := &cobra.Command{
 		Use:   "cancel-status ADMIN_CANCEL_ID",
 		Short: "Show the status of an administrative cancel request",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the status of an administrative cancel request.
+
+With --watch, progress (tasks processed out of tasks requested) is printed as
+it changes until the request reports done, then the final status is shown.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if watch {
+				cancel()
+				ctx, cancel = context.WithTimeout(context.Background(), timeout)
+			}
 			defer cancel()
 
 			client, err := getClient(ctx)
@@ -303,6 +364,14 @@ func endpointManagerCancelStatusCmd() *cobra.Command {
 				return err
 			}
 
+			if watch {
+				resp, err := watchCancelStatus(ctx, cmd, client, args[0], interval)
+				if err != nil {
+					return err
+				}
+				return formatGenericResponse(cmd, resp)
+			}
+
 			resp, err := client.EndpointManagerCancelStatus(ctx, args[0])
 			if err != nil {
 				return fmt.Errorf("failed to get cancel status: %w", err)
@@ -310,17 +379,33 @@ func endpointManagerCancelStatusCmd() *cobra.Command {
 			return formatGenericResponse(cmd, resp)
 		},
 	}
+
+	cmd.Flags().BoolVar(&watch, "watch", false, "Poll until the cancel request is done, printing progress")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval for --watch")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Give up watching after this long")
+
+	return cmd
 }
 
 func endpointManagerTaskPauseCmd() *cobra.Command {
-	var message string
+	var (
+		message string
+		filters taskFilters
+		yes     bool
+	)
 
 	cmd := &cobra.Command{
-		Use:   "task-pause TASK_ID...",
+		Use:   "task-pause [TASK_ID...]",
 		Short: "Pause one or more tasks as an administrator",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Pause tasks as an administrator.
+
+` + bulkTaskHelp + `
+
+Examples:
+  globus endpoint-manager task-pause TASK_ID
+  globus endpoint-manager task-pause --filter-status ACTIVE --filter-owner-id bob@example.org --filter-endpoint ENDPOINT_ID`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
 			client, err := getClient(ctx)
@@ -328,7 +413,12 @@ func endpointManagerTaskPauseCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.EndpointManagerPauseTasks(ctx, args, message)
+			taskIDs, err := bulkTaskIDs(ctx, cmd, client, args, &filters, "Pause", yes)
+			if err != nil || taskIDs == nil {
+				return err
+			}
+
+			resp, err := client.EndpointManagerPauseTasks(ctx, taskIDs, message)
 			if err != nil {
 				return fmt.Errorf("failed to pause tasks: %w", err)
 			}
@@ -337,17 +427,29 @@ func endpointManagerTaskPauseCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&message, "message", "", "Message describing the reason for pausing")
+	addBulkTaskFlags(cmd, &filters, &yes)
 
 	return cmd
 }
 
 func endpointManagerTaskResumeCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "task-resume TASK_ID...",
+	var (
+		filters taskFilters
+		yes     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "task-resume [TASK_ID...]",
 		Short: "Resume one or more tasks as an administrator",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Resume administratively paused tasks.
+
+` + bulkTaskHelp + `
+
+Examples:
+  globus endpoint-manager task-resume TASK_ID
+  globus endpoint-manager task-resume --filter-endpoint ENDPOINT_ID --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
 			client, err := getClient(ctx)
@@ -355,13 +457,22 @@ func endpointManagerTaskResumeCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.EndpointManagerResumeTasks(ctx, args)
+			taskIDs, err := bulkTaskIDs(ctx, cmd, client, args, &filters, "Resume", yes)
+			if err != nil || taskIDs == nil {
+				return err
+			}
+
+			resp, err := client.EndpointManagerResumeTasks(ctx, taskIDs)
 			if err != nil {
 				return fmt.Errorf("failed to resume tasks: %w", err)
 			}
 			return formatGenericResponse(cmd, resp)
 		},
 	}
+
+	addBulkTaskFlags(cmd, &filters, &yes)
+
+	return cmd
 }
 
 // endpointManagerPauseRuleCmd returns the "endpoint-manager pause-rule" command
@@ -370,14 +481,17 @@ func endpointManagerPauseRuleCmd() *cobra.Command {
 	pauseRuleCmd := &cobra.Command{
 		Use:   "pause-rule",
 		Short: "Manage administrative pause rules",
-		Long:  `List, show, create, and delete administrative pause rules on managed endpoints.`,
+		Long: `List, show, create, update, and delete administrative pause rules on managed endpoints,
+or declare a scheduled maintenance window with 'pause-rule apply'.`,
 	}
 
 	pauseRuleCmd.AddCommand(
 		endpointManagerPauseRuleListCmd(),
 		endpointManagerPauseRuleShowCmd(),
 		endpointManagerPauseRuleCreateCmd(),
+		endpointManagerPauseRuleUpdateCmd(),
 		endpointManagerPauseRuleDeleteCmd(),
+		endpointManagerPauseRuleApplyCmd(),
 	)
 
 	return pauseRuleCmd
@@ -437,19 +551,57 @@ func endpointManagerPauseRuleShowCmd() *cobra.Command {
 
 func endpointManagerPauseRuleCreateCmd() *cobra.Command {
 	var (
-		endpointID string
-		message    string
+		endpointID  string
+		collections []string
+		flags       pauseRuleFlags
+		endTime     string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create an administrative pause rule",
-		Long: `Create an administrative pause rule on a managed endpoint.
+		Long: `Create an administrative pause rule on a managed endpoint or collection.
+
+By default the rule pauses every operation for every user, starting now.
+--operations narrows it to some of: ` + strings.Join(pauseOperationNames, ", ") + `.
+--identity limits it to one user, and --start-time schedules it for later.
+
+--collection may be repeated to create the same rule on several collections;
+one rule is created per collection.
 
-This builds a minimal pause_rule document from the --endpoint and --message
-flags. More granular pause rule fields are not currently exposed.`,
+Pause rules do not expire: Transfer has no end time for them, and nothing
+removes them for you. --end-time records when maintenance ends; it must be in
+the future, and the exact delete commands to run at that time are printed.
+
+Examples:
+  globus endpoint-manager pause-rule create --endpoint ENDPOINT_ID --message "Storage upgrade"
+  globus endpoint-manager pause-rule create --collection COLL_A --collection COLL_B \
+      --operations write,delete,rename,mkdir --start-time 2026-11-01T06:00:00Z \
+      --end-time 2026-11-01T18:00:00Z --message "Read-only during migration"`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			targets := collections
+			if endpointID != "" {
+				targets = append([]string{endpointID}, targets...)
+			}
+			if len(targets) == 0 {
+				return fmt.Errorf("give --endpoint or at least one --collection")
+			}
+
+			var end time.Time
+			if endTime != "" {
+				var start time.Time
+				var err error
+				if flags.StartTime != "" {
+					if start, _, err = parsePauseTime(flags.StartTime); err != nil {
+						return fmt.Errorf("--start-time: %w", err)
+					}
+				}
+				if end, err = parsePauseEnd(endTime, start, time.Now()); err != nil {
+					return fmt.Errorf("--end-time: %w", err)
+				}
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
@@ -458,23 +610,92 @@ flags. More granular pause rule fields are not currently exposed.`,
 				return err
 			}
 
-			doc := map[string]interface{}{
-				"DATA_TYPE":   "pause_rule",
-				"endpoint_id": endpointID,
-				"message":     message,
+			base, err := flags.doc(ctx, cmd, newPrincipalResolver(), true)
+			if err != nil {
+				return err
 			}
 
-			resp, err := client.EndpointManagerCreatePauseRule(ctx, doc)
+			var created []transfer.GenericResponse
+			for _, target := range targets {
+				doc := map[string]interface{}{"endpoint_id": target}
+				for k, v := range base {
+					doc[k] = v
+				}
+				resp, err := client.EndpointManagerCreatePauseRule(ctx, doc)
+				if err != nil {
+					return fmt.Errorf("failed to create pause rule on %s: %w", target, err)
+				}
+				created = append(created, resp)
+			}
+			if !end.IsZero() {
+				var removals []string
+				for _, resp := range created {
+					removals = append(removals, "globus endpoint-manager pause-rule delete "+genericString(resp, "id"))
+				}
+				printPauseRemoval(cmd, end, removals...)
+			}
+			if len(created) == 1 {
+				return formatGenericResponse(cmd, created[0])
+			}
+			format := viper.GetString("format")
+			formatter := output.NewFormatter(format, cmd.OutOrStdout())
+			if formatter.Format == output.FormatText {
+				for i, resp := range created {
+					fmt.Fprintf(cmd.OutOrStdout(), "Pause rule %s created on %s.\n", genericString(resp, "id"), targets[i])
+				}
+				return nil
+			}
+			return formatter.FormatOutput(created, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&endpointID, "endpoint", "", "Endpoint ID the pause rule applies to")
+	cmd.Flags().StringArrayVar(&collections, "collection", nil, "Collection ID the pause rule applies to (repeatable)")
+	addPauseRuleFlags(cmd, &flags)
+	cmd.Flags().StringVar(&endTime, "end-time", "", "When maintenance ends (RFC 3339 or local YYYY-MM-DDTHH:MM); not sent to Transfer, the rule must still be deleted")
+
+	return cmd
+}
+
+func endpointManagerPauseRuleUpdateCmd() *cobra.Command {
+	var flags pauseRuleFlags
+
+	cmd := &cobra.Command{
+		Use:   "update PAUSE_RULE_ID",
+		Short: "Update an administrative pause rule",
+		Long: `Update an administrative pause rule. Only the options given are changed.
+
+Examples:
+  globus endpoint-manager pause-rule update RULE_ID --message "Extended until 20:00 UTC"
+  globus endpoint-manager pause-rule update RULE_ID --operations all
+  globus endpoint-manager pause-rule update RULE_ID --identity ""`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			doc, err := flags.doc(ctx, cmd, newPrincipalResolver(), false)
+			if err != nil {
+				return err
+			}
+			if len(doc) == 1 {
+				return fmt.Errorf("nothing to update; give at least one of --message, --start-time, --operations or --identity")
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.EndpointManagerUpdatePauseRule(ctx, args[0], doc)
 			if err != nil {
-				return fmt.Errorf("failed to create pause rule: %w", err)
+				return fmt.Errorf("failed to update pause rule: %w", err)
 			}
 			return formatGenericResponse(cmd, resp)
 		},
 	}
 
-	cmd.Flags().StringVar(&endpointID, "endpoint", "", "Endpoint ID the pause rule applies to")
-	cmd.Flags().StringVar(&message, "message", "", "Message shown to affected users")
-	_ = cmd.MarkFlagRequired("endpoint")
+	addPauseRuleFlags(cmd, &flags)
 
 	return cmd
 }
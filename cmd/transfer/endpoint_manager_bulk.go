@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// endpointManagerPageSize is the largest page the endpoint_manager task_list
+// API returns.
+const endpointManagerPageSize = 1000
+
+// maxPreviewTasks bounds how many matching tasks a bulk command lists before
+// asking for confirmation.
+const maxPreviewTasks = 20
+
+// taskFilters holds the --filter-* options shared by task-list and the bulk
+// task-cancel/task-pause/task-resume commands.
+type taskFilters struct {
+	Status      []string
+	TaskIDs     []string
+	OwnerID     string
+	Endpoint    string
+	EndpointUse string
+}
+
+// addTaskFilterFlags registers the --filter-* options on cmd.
+func addTaskFilterFlags(cmd *cobra.Command, f *taskFilters) {
+	cmd.Flags().StringSliceVar(&f.Status, "filter-status", nil, "Filter by task status (repeatable or comma-separated)")
+	cmd.Flags().StringSliceVar(&f.TaskIDs, "filter-task-id", nil, "Filter by task ID (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&f.OwnerID, "filter-owner-id", "", "Filter by task owner (identity ID or username)")
+	cmd.Flags().StringVar(&f.Endpoint, "filter-endpoint", "", "Filter by endpoint ID")
+	cmd.Flags().StringVar(&f.EndpointUse, "filter-endpoint-use", "", "With --filter-endpoint, match it only as the source or destination")
+}
+
+// set reports whether any filter was given.
+func (f *taskFilters) set() bool {
+	return len(f.Status) > 0 || len(f.TaskIDs) > 0 || f.OwnerID != "" || f.Endpoint != "" || f.EndpointUse != ""
+}
+
+// options converts the filters into task_list query options, resolving a
+// username given to --filter-owner-id.
+func (f *taskFilters) options(ctx context.Context, resolver *principalResolver) (*transfer.EndpointManagerTaskListOptions, error) {
+	switch f.EndpointUse {
+	case "", "source", "destination":
+	default:
+		return nil, fmt.Errorf("invalid --filter-endpoint-use %q (use source or destination)", f.EndpointUse)
+	}
+	if f.EndpointUse != "" && f.Endpoint == "" {
+		return nil, fmt.Errorf("--filter-endpoint-use requires --filter-endpoint")
+	}
+
+	owner := f.OwnerID
+	if owner != "" {
+		id, err := resolver.identityID(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		owner = id
+	}
+
+	status := make([]string, 0, len(f.Status))
+	for _, s := range f.Status {
+		status = append(status, strings.ToUpper(s))
+	}
+
+	return &transfer.EndpointManagerTaskListOptions{
+		FilterStatus:      status,
+		FilterTaskID:      f.TaskIDs,
+		FilterOwnerID:     owner,
+		FilterEndpoint:    f.Endpoint,
+		FilterEndpointUse: f.EndpointUse,
+	}, nil
+}
+
+// endpointManagerTaskLister is the subset of the Transfer client used to page
+// through endpoint_manager task lists.
+type endpointManagerTaskLister interface {
+	EndpointManagerTaskList(ctx context.Context, options *transfer.EndpointManagerTaskListOptions) (*transfer.EndpointManagerTaskList, error)
+}
+
+// listEndpointManagerTasks follows last_key through every page of a task
+// list, stopping once limit tasks are collected (limit <= 0 means all).
+func listEndpointManagerTasks(ctx context.Context, client endpointManagerTaskLister, opts *transfer.EndpointManagerTaskListOptions, limit int) ([]map[string]interface{}, error) {
+	page := *opts
+	var tasks []map[string]interface{}
+	for {
+		page.Limit = endpointManagerPageSize
+		if limit > 0 && limit-len(tasks) < page.Limit {
+			page.Limit = limit - len(tasks)
+		}
+		resp, err := client.EndpointManagerTaskList(ctx, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		tasks = append(tasks, resp.Data...)
+		if limit > 0 && len(tasks) >= limit {
+			return tasks[:limit], nil
+		}
+		if resp.LastKey == nil || *resp.LastKey == "" || len(resp.Data) == 0 {
+			return tasks, nil
+		}
+		page.LastKey = *resp.LastKey
+	}
+}
+
+// bulkTaskIDs returns the task IDs a bulk command acts on: the explicit
+// TASK_ID arguments, or every task matching the filters. Filtered selections
+// are previewed and, unless yes is set, confirmed; a nil result with no error
+// means the user declined or nothing matched.
+func bulkTaskIDs(ctx context.Context, cmd *cobra.Command, client endpointManagerTaskLister, args []string, filters *taskFilters, verb string, yes bool) ([]string, error) {
+	if len(args) > 0 {
+		if filters.set() {
+			return nil, fmt.Errorf("give TASK_IDs or --filter-* options, not both")
+		}
+		return args, nil
+	}
+	if !filters.set() {
+		return nil, fmt.Errorf("give TASK_IDs or at least one --filter-* option")
+	}
+
+	// Only tasks that are still running can be cancelled, paused or resumed.
+	if len(filters.Status) == 0 {
+		filters.Status = []string{"ACTIVE", "INACTIVE"}
+	}
+	opts, err := filters.options(ctx, newPrincipalResolver())
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := listEndpointManagerTasks(ctx, client, opts, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No tasks match the filters.")
+		return nil, nil
+	}
+
+	printTaskPreview(cmd.ErrOrStderr(), tasks)
+	if !yes && !confirmAction(fmt.Sprintf("%s %d task(s)", verb, len(tasks))) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Operation canceled.")
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, genericString(t, "task_id"))
+	}
+	return ids, nil
+}
+
+// printTaskPreview lists the first matching tasks and the total count.
+func printTaskPreview(w io.Writer, tasks []map[string]interface{}) {
+	fmt.Fprintf(w, "%d task(s) match:\n", len(tasks))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  Task ID\tStatus\tOwner\tSource\tDestination\tLabel")
+	for i, t := range tasks {
+		if i == maxPreviewTasks {
+			fmt.Fprintf(tw, "  ... and %d more\n", len(tasks)-i)
+			break
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n",
+			genericString(t, "task_id"), genericString(t, "status"), genericString(t, "owner_string"),
+			genericString(t, "source_endpoint_display_name"), genericString(t, "destination_endpoint_display_name"),
+			genericString(t, "label"))
+	}
+	tw.Flush()
+}
+
+// cancelStatusClient is the subset of the Transfer client used to poll an
+// admin cancel.
+type cancelStatusClient interface {
+	EndpointManagerCancelStatus(ctx context.Context, adminCancelID string) (transfer.GenericResponse, error)
+}
+
+// watchCancelStatus polls an admin cancel until it reports done, printing
+// progress whenever it changes.
+func watchCancelStatus(ctx context.Context, cmd *cobra.Command, client cancelStatusClient, adminCancelID string, interval time.Duration) (transfer.GenericResponse, error) {
+	last := ""
+	for {
+		resp, err := client.EndpointManagerCancelStatus(ctx, adminCancelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cancel status: %w", err)
+		}
+		done, _ := resp["done"].(bool)
+		progress := cancelProgress(resp)
+		if progress != last {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Admin cancel %s: %s\n", adminCancelID, progress)
+			last = progress
+		}
+		if done {
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for admin cancel %s (last: %s)", adminCancelID, progress)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// cancelProgress summarizes an admin_cancel document as "N/M tasks processed".
+func cancelProgress(resp transfer.GenericResponse) string {
+	total := len(genericList(resp, "task_id_list"))
+	processed := len(genericList(resp, "processed_task_id_list"))
+	state := "in progress"
+	if done, _ := resp["done"].(bool); done {
+		state = "done"
+	}
+	return fmt.Sprintf("%d/%d task(s) processed, %s", processed, total, state)
+}
+
+// genericList returns a list field of a GenericResponse object, or nil.
+func genericList(m map[string]interface{}, key string) []interface{} {
+	l, _ := m[key].([]interface{})
+	return l
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// pagedTaskLister serves n tasks in pages keyed by last_key, recording the
+// options of every request.
+type pagedTaskLister struct {
+	n    int
+	seen []transfer.EndpointManagerTaskListOptions
+}
+
+func (p *pagedTaskLister) EndpointManagerTaskList(_ context.Context, opts *transfer.EndpointManagerTaskListOptions) (*transfer.EndpointManagerTaskList, error) {
+	p.seen = append(p.seen, *opts)
+	start := 0
+	if opts.LastKey != "" {
+		fmt.Sscanf(opts.LastKey, "k%d", &start)
+	}
+	end := start + opts.Limit
+	if end > p.n {
+		end = p.n
+	}
+	list := &transfer.EndpointManagerTaskList{}
+	for i := start; i < end; i++ {
+		list.Data = append(list.Data, map[string]interface{}{"task_id": fmt.Sprintf("t%d", i), "status": "ACTIVE"})
+	}
+	if end < p.n {
+		key := fmt.Sprintf("k%d", end)
+		list.LastKey = &key
+	}
+	return list, nil
+}
+
+// TestListEndpointManagerTasks checks that every page is followed and that a
+// limit stops paging early.
+func TestListEndpointManagerTasks(t *testing.T) {
+	ctx := context.Background()
+	opts := &transfer.EndpointManagerTaskListOptions{FilterEndpoint: "ep"}
+
+	lister := &pagedTaskLister{n: 2500}
+	tasks, err := listEndpointManagerTasks(ctx, lister, opts, 0)
+	if err != nil {
+		t.Fatalf("listEndpointManagerTasks: %v", err)
+	}
+	if len(tasks) != 2500 || len(lister.seen) != 3 {
+		t.Errorf("got %d tasks in %d requests, want 2500 in 3", len(tasks), len(lister.seen))
+	}
+	if lister.seen[2].LastKey != "k2000" || lister.seen[2].FilterEndpoint != "ep" {
+		t.Errorf("third request = %+v", lister.seen[2])
+	}
+
+	lister = &pagedTaskLister{n: 2500}
+	tasks, err = listEndpointManagerTasks(ctx, lister, opts, 25)
+	if err != nil || len(tasks) != 25 || len(lister.seen) != 1 || lister.seen[0].Limit != 25 {
+		t.Errorf("limited listing = %d tasks, %d requests, %v", len(tasks), len(lister.seen), err)
+	}
+}
+
+// TestBulkTaskIDs covers explicit IDs, filtered selection with --yes, and the
+// argument rules.
+func TestBulkTaskIDs(t *testing.T) {
+	ctx := context.Background()
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	ids, err := bulkTaskIDs(ctx, cmd, &pagedTaskLister{}, []string{"a", "b"}, &taskFilters{}, "Pause", false)
+	if err != nil || !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("explicit IDs = %v, %v", ids, err)
+	}
+
+	if _, err := bulkTaskIDs(ctx, cmd, &pagedTaskLister{}, []string{"a"}, &taskFilters{Endpoint: "ep"}, "Pause", true); err == nil {
+		t.Error("expected an error for TASK_IDs combined with filters")
+	}
+	if _, err := bulkTaskIDs(ctx, cmd, &pagedTaskLister{}, nil, &taskFilters{}, "Pause", true); err == nil {
+		t.Error("expected an error with neither TASK_IDs nor filters")
+	}
+
+	lister := &pagedTaskLister{n: 30}
+	ids, err = bulkTaskIDs(ctx, cmd, lister, nil, &taskFilters{Endpoint: "ep"}, "Pause", true)
+	if err != nil || len(ids) != 30 {
+		t.Fatalf("filtered selection = %d IDs, %v", len(ids), err)
+	}
+	if got := lister.seen[0].FilterStatus; !reflect.DeepEqual(got, []string{"ACTIVE", "INACTIVE"}) {
+		t.Errorf("default status filter = %v", got)
+	}
+	if !strings.Contains(stderr.String(), "30 task(s) match") || !strings.Contains(stderr.String(), "... and 10 more") {
+		t.Errorf("preview = %q", stderr.String())
+	}
+
+	if _, err := (&taskFilters{EndpointUse: "source"}).options(ctx, nil); err == nil {
+		t.Error("expected an error for --filter-endpoint-use without --filter-endpoint")
+	}
+}
+
+// scriptedCancelStatus returns successive admin_cancel documents.
+type scriptedCancelStatus struct {
+	docs []transfer.GenericResponse
+}
+
+func (s *scriptedCancelStatus) EndpointManagerCancelStatus(context.Context, string) (transfer.GenericResponse, error) {
+	doc := s.docs[0]
+	if len(s.docs) > 1 {
+		s.docs = s.docs[1:]
+	}
+	return doc, nil
+}
+
+// TestWatchCancelStatus checks that progress is printed as it changes until
+// the cancel reports done.
+func TestWatchCancelStatus(t *testing.T) {
+	ids := []interface{}{"t1", "t2", "t3"}
+	client := &scriptedCancelStatus{docs: []transfer.GenericResponse{
+		{"done": false, "task_id_list": ids, "processed_task_id_list": []interface{}{}},
+		{"done": false, "task_id_list": ids, "processed_task_id_list": []interface{}{}},
+		{"done": false, "task_id_list": ids, "processed_task_id_list": []interface{}{"t1"}},
+		{"done": true, "task_id_list": ids, "processed_task_id_list": ids},
+	}}
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	resp, err := watchCancelStatus(context.Background(), cmd, client, "c1", time.Millisecond)
+	if err != nil || resp["done"] != true {
+		t.Fatalf("watchCancelStatus = %v, %v", resp, err)
+	}
+	want := "Admin cancel c1: 0/3 task(s) processed, in progress\n" +
+		"Admin cancel c1: 1/3 task(s) processed, in progress\n" +
+		"Admin cancel c1: 3/3 task(s) processed, done\n"
+	if stderr.String() != want {
+		t.Errorf("progress =\n%s\nwant\n%s", stderr.String(), want)
+	}
+}
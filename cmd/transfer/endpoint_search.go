@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// endpointSearchPageSize is the page size used while auto-paginating
+// endpoint_search; endpointSearchMaxResults is the service's hard cap on how
+// far offset may advance.
+const (
+	endpointSearchPageSize   = 100
+	endpointSearchMaxResults = 1000
+)
+
+// endpointFilterScopes are the filter_scope values the Transfer API accepts.
+var endpointFilterScopes = []string{
+	"all", "my-endpoints", "my-gcp-endpoints", "recently-used", "in-use",
+	"shared-by-me", "shared-with-me", "administered-by-me",
+}
+
+// endpointEntityTypes are the filter_entity_type values the Transfer API accepts.
+var endpointEntityTypes = []string{
+	"GCP_mapped_collection", "GCP_guest_collection", "GCSv5_endpoint",
+	"GCSv5_mapped_collection", "GCSv5_guest_collection", "GCSv4_host", "GCSv4_share",
+}
+
+// endpointFilters holds the options shared by endpoint list and endpoint
+// search. Scope, owner, entity type, host endpoint and non-functional are
+// sent to endpoint_search; the rest are applied to each page as it arrives,
+// because the service has no parameters for them.
+type endpointFilters struct {
+	Fulltext      string
+	Scope         string
+	OwnerID       string
+	EntityType    string
+	HostEndpoint  string
+	NonFunctional bool
+	Subscription  string
+	GCSVersion    string
+	HTTPS         bool
+	Keyword       string
+	Organization  string
+	Role          string
+	Limit         int
+	Interactive   bool
+}
+
+// addEndpointFilterFlags registers the shared --filter-* options on cmd.
+func addEndpointFilterFlags(cmd *cobra.Command, f *endpointFilters, defaultScope string) {
+	cmd.Flags().StringVar(&f.Scope, "filter-scope", defaultScope, "The set of endpoints to search over ("+strings.Join(endpointFilterScopes, ", ")+")")
+	cmd.Flags().StringVar(&f.OwnerID, "filter-owner-id", "", "Filter results to endpoints owned by a specific identity (ID or username)")
+	cmd.Flags().StringVar(&f.EntityType, "filter-entity-type", "", "Filter results to a specific entity type ("+strings.Join(endpointEntityTypes, ", ")+")")
+	cmd.Flags().StringVar(&f.HostEndpoint, "filter-host-endpoint", "", "Filter results to collections hosted on this endpoint")
+	cmd.Flags().BoolVar(&f.NonFunctional, "filter-non-functional", false, "Only show non-functional endpoints (GCSv5 endpoint documents)")
+	cmd.Flags().StringVar(&f.Subscription, "filter-subscription-id", "", "Filter results to endpoints under this subscription ID")
+	cmd.Flags().StringVar(&f.GCSVersion, "filter-gcs-version", "", "Filter results by GCS version prefix (e.g. 5.4)")
+	cmd.Flags().BoolVar(&f.HTTPS, "filter-https", false, "Only show collections that support HTTPS access")
+	cmd.Flags().StringVar(&f.Keyword, "filter-keyword", "", "Only show endpoints tagged with this keyword")
+	cmd.Flags().IntVar(&f.Limit, "limit", 25, "Maximum number of endpoints to return (0 for all, up to 1000)")
+	cmd.Flags().BoolVar(&f.Interactive, "interactive", false, "Pick one result interactively and print its ID")
+}
+
+// mergeFilterFlags sets *dst from whichever of the named string flags were
+// given, so older spellings of a filter keep working; flags that disagree are
+// an error rather than the last one parsed silently winning.
+func mergeFilterFlags(cmd *cobra.Command, dst *string, names ...string) error {
+	from := ""
+	for _, name := range names {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		v, _ := cmd.Flags().GetString(name)
+		if from != "" && v != *dst {
+			return fmt.Errorf("--%s %q conflicts with --%s %q", name, v, from, *dst)
+		}
+		from, *dst = name, v
+	}
+	return nil
+}
+
+// options validates the filters and builds the endpoint_search options.
+// filter_scope "all" searches every endpoint and so requires search text; an
+// empty scope means "all" with text and "my-endpoints" without.
+func (f *endpointFilters) options(ctx context.Context, resolver *principalResolver) (*transfer.EndpointSearchOptions, error) {
+	scope := f.Scope
+	if scope == "" {
+		scope = "my-endpoints"
+		if f.Fulltext != "" {
+			scope = "all"
+		}
+	}
+	if !containsFold(endpointFilterScopes, scope) {
+		return nil, fmt.Errorf("invalid --filter-scope %q (use %s)", scope, strings.Join(endpointFilterScopes, ", "))
+	}
+	scope = strings.ToLower(scope)
+	if scope == "all" && f.Fulltext == "" {
+		return nil, fmt.Errorf("search text is required with --filter-scope all")
+	}
+	entityType := ""
+	for _, t := range endpointEntityTypes {
+		if strings.EqualFold(t, f.EntityType) {
+			entityType = t
+		}
+	}
+	if f.EntityType != "" && entityType == "" {
+		return nil, fmt.Errorf("invalid --filter-entity-type %q (use %s)", f.EntityType, strings.Join(endpointEntityTypes, ", "))
+	}
+	if f.Limit < 0 {
+		return nil, fmt.Errorf("--limit must not be negative")
+	}
+
+	opts := &transfer.EndpointSearchOptions{
+		FilterFulltext:     f.Fulltext,
+		FilterScope:        scope,
+		FilterEntityType:   entityType,
+		FilterHostEndpoint: f.HostEndpoint,
+	}
+	if f.OwnerID != "" {
+		id, err := resolver.identityID(ctx, f.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		opts.FilterOwnerID = id
+	}
+	if f.NonFunctional {
+		nonFunctional := true
+		opts.FilterNonFunctional = &nonFunctional
+	}
+	return opts, nil
+}
+
+// match applies the filters endpoint_search cannot.
+func (f *endpointFilters) match(ep transfer.Endpoint) bool {
+	if f.Subscription != "" && !strings.EqualFold(ep.SubscriptionID, f.Subscription) {
+		return false
+	}
+	if f.GCSVersion != "" && !strings.HasPrefix(ep.GCSVersion, f.GCSVersion) {
+		return false
+	}
+	if f.HTTPS && ep.HTTPSServer == "" {
+		return false
+	}
+	if f.Keyword != "" && !containsFold(ep.Keywords, f.Keyword) {
+		return false
+	}
+	if f.Organization != "" && !strings.EqualFold(ep.Organization, f.Organization) {
+		return false
+	}
+	if f.Role != "" && !containsFold(ep.MyEffectiveRoles, f.Role) {
+		return false
+	}
+	return true
+}
+
+// endpointSearcher is the subset of the Transfer client used to page through
+// endpoint_search.
+type endpointSearcher interface {
+	EndpointSearch(ctx context.Context, options *transfer.EndpointSearchOptions) (*transfer.EndpointSearchResult, error)
+}
+
+// searchEndpoints pages through endpoint_search, keeping the endpoints that
+// pass the client-side filters until f.Limit are found (0 means all). The
+// bool result reports whether more matches may exist.
+func searchEndpoints(ctx context.Context, client endpointSearcher, opts *transfer.EndpointSearchOptions, f *endpointFilters) ([]transfer.Endpoint, bool, error) {
+	page := *opts
+	page.Limit = endpointSearchPageSize
+	var found []transfer.Endpoint
+	for {
+		result, err := client.EndpointSearch(ctx, &page)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to search endpoints: %w", err)
+		}
+		for i, ep := range result.Data {
+			if !f.match(ep) {
+				continue
+			}
+			found = append(found, ep)
+			if f.Limit > 0 && len(found) == f.Limit {
+				return found, i < len(result.Data)-1 || result.HasNextPage, nil
+			}
+		}
+		page.Offset += len(result.Data)
+		if !result.HasNextPage || len(result.Data) == 0 {
+			return found, false, nil
+		}
+		if page.Offset >= endpointSearchMaxResults {
+			return found, true, nil
+		}
+	}
+}
+
+// runEndpointSearch is the shared body of endpoint list and endpoint search.
+func runEndpointSearch(cmd *cobra.Command, f *endpointFilters) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	transferClient, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resolver *principalResolver
+	if f.OwnerID != "" {
+		resolver = newPrincipalResolver()
+	}
+	opts, err := f.options(ctx, resolver)
+	if err != nil {
+		return err
+	}
+
+	endpoints, more, err := searchEndpoints(ctx, transferClient, opts, f)
+	if err != nil {
+		return err
+	}
+
+	if f.Interactive {
+		if len(endpoints) == 0 {
+			return fmt.Errorf("no endpoints match")
+		}
+		id, err := pickEndpoint(cmd.ErrOrStderr(), endpoints)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), id)
+		return nil
+	}
+
+	// Route all formats through the shared formatter so -F (text/json/unix) and
+	// --jmespath/--jq work uniformly. For JSON/JMESPath, emit the raw endpoint
+	// documents; for text/unix, a projected row set.
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+
+	if formatter.Format == output.FormatJSON {
+		// Emit the enveloped service document ({"DATA_TYPE","DATA":[...],...}),
+		// matching the Python CLI's JSON output shape.
+		return formatter.FormatOutput(&transfer.EndpointSearchResult{
+			DataType:    "endpoint_list",
+			Data:        endpoints,
+			Limit:       len(endpoints),
+			HasNextPage: more,
+		}, nil)
+	}
+
+	type endpointRow struct {
+		ID        string
+		Name      string
+		Owner     string
+		Activated bool
+		Public    bool
+	}
+	rows := make([]endpointRow, 0, len(endpoints))
+	for _, e := range endpoints {
+		rows = append(rows, endpointRow{
+			ID: e.ID, Name: e.DisplayName, Owner: e.Owner,
+			Activated: e.Activated, Public: e.Public,
+		})
+	}
+	if err := formatter.FormatOutput(rows, []string{"ID", "Name", "Owner", "Activated", "Public"}); err != nil {
+		return err
+	}
+	if more && formatter.Format == output.FormatText {
+		fmt.Fprintf(cmd.ErrOrStderr(), "More endpoints match; raise --limit (0 for all) or narrow the filters.\n")
+	}
+	return nil
+}
+
+// pickEndpoint shows a fuzzy-searchable menu on w and returns the chosen ID.
+func pickEndpoint(w io.Writer, endpoints []transfer.Endpoint) (string, error) {
+	labels := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		labels[i] = fmt.Sprintf("%s  %s  (%s)", ep.DisplayName, ep.Owner, ep.ID)
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select an endpoint (type to search)",
+		Items:             labels,
+		Size:              15,
+		Stdout:            nopWriteCloser{w},
+		StartInSearchMode: true,
+		Searcher: func(input string, index int) bool {
+			return fuzzyMatch(input, labels[index])
+		},
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("no endpoint selected: %w", err)
+	}
+	return endpoints[i].ID, nil
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in order in s,
+// ignoring case and whitespace in the pattern.
+func fuzzyMatch(pattern, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(pattern) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// containsFold reports whether list contains v, ignoring case.
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// nopWriteCloser adapts an io.Writer to the io.WriteCloser promptui expects,
+// so the menu can be drawn on stderr while the chosen ID goes to stdout.
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
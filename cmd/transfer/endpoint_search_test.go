@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// pagedEndpointSearcher serves n endpoints by offset; every third one
+// supports HTTPS.
+type pagedEndpointSearcher struct {
+	n    int
+	seen []transfer.EndpointSearchOptions
+}
+
+func (p *pagedEndpointSearcher) EndpointSearch(_ context.Context, opts *transfer.EndpointSearchOptions) (*transfer.EndpointSearchResult, error) {
+	p.seen = append(p.seen, *opts)
+	result := &transfer.EndpointSearchResult{Offset: opts.Offset, Limit: opts.Limit}
+	for i := opts.Offset; i < opts.Offset+opts.Limit && i < p.n; i++ {
+		ep := transfer.Endpoint{ID: fmt.Sprintf("ep%d", i)}
+		if i%3 == 0 {
+			ep.HTTPSServer = "https://example.org"
+		}
+		result.Data = append(result.Data, ep)
+	}
+	result.HasNextPage = opts.Offset+opts.Limit < p.n
+	return result, nil
+}
+
+// TestSearchEndpoints checks paging, client-side filtering and the limit.
+func TestSearchEndpoints(t *testing.T) {
+	ctx := context.Background()
+	opts := &transfer.EndpointSearchOptions{FilterScope: "my-endpoints"}
+
+	searcher := &pagedEndpointSearcher{n: 250}
+	eps, more, err := searchEndpoints(ctx, searcher, opts, &endpointFilters{})
+	if err != nil || len(eps) != 250 || more || len(searcher.seen) != 3 {
+		t.Errorf("all = %d endpoints, more %v, %d requests, %v", len(eps), more, len(searcher.seen), err)
+	}
+	if searcher.seen[2].Offset != 200 || searcher.seen[2].FilterScope != "my-endpoints" {
+		t.Errorf("third request = %+v", searcher.seen[2])
+	}
+
+	searcher = &pagedEndpointSearcher{n: 250}
+	eps, more, err = searchEndpoints(ctx, searcher, opts, &endpointFilters{HTTPS: true, Limit: 40})
+	if err != nil || len(eps) != 40 || !more || eps[1].ID != "ep3" || len(searcher.seen) != 2 {
+		t.Errorf("HTTPS limit 40 = %d endpoints, more %v, %d requests, %v", len(eps), more, len(searcher.seen), err)
+	}
+
+	searcher = &pagedEndpointSearcher{n: 5000}
+	eps, more, _ = searchEndpoints(ctx, searcher, opts, &endpointFilters{})
+	if len(eps) != endpointSearchMaxResults || !more {
+		t.Errorf("capped search = %d endpoints, more %v", len(eps), more)
+	}
+}
+
+// TestEndpointFilterOptions checks filter_scope defaults and validation.
+func TestEndpointFilterOptions(t *testing.T) {
+	ctx := context.Background()
+
+	opts, err := (&endpointFilters{}).options(ctx, nil)
+	if err != nil || opts.FilterScope != "my-endpoints" {
+		t.Errorf("no text, no scope = %+v, %v", opts, err)
+	}
+	opts, err = (&endpointFilters{Fulltext: "lab", EntityType: "gcsv5_endpoint", NonFunctional: true}).options(ctx, nil)
+	if err != nil || opts.FilterScope != "all" || opts.FilterEntityType != "GCSv5_endpoint" || opts.FilterNonFunctional == nil {
+		t.Errorf("text search = %+v, %v", opts, err)
+	}
+
+	for name, f := range map[string]*endpointFilters{
+		"all without text": {Scope: "all"},
+		"unknown scope":    {Scope: "everything", Fulltext: "x"},
+		"unknown type":     {Fulltext: "x", EntityType: "gcsv6"},
+	} {
+		if _, err := f.options(ctx, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestEndpointFilterMatch checks the client-side filters.
+func TestEndpointFilterMatch(t *testing.T) {
+	ep := transfer.Endpoint{SubscriptionID: "SUB", GCSVersion: "5.4.67", Keywords: transfer.Keywords{"Genomics"}, MyEffectiveRoles: []string{"administrator"}}
+	if !(&endpointFilters{Subscription: "sub", GCSVersion: "5.4", Keyword: "genomics", Role: "administrator"}).match(ep) {
+		t.Error("expected a match")
+	}
+	for _, f := range []*endpointFilters{{GCSVersion: "5.3"}, {HTTPS: true}, {Keyword: "physics"}, {Subscription: "other"}} {
+		if f.match(ep) {
+			t.Errorf("%+v should not match", f)
+		}
+	}
+	if !fuzzyMatch("lb strg", "Lab Storage  alice (id)") || fuzzyMatch("gbl", "Lab Storage") {
+		t.Error("fuzzyMatch")
+	}
+}
+
+// TestMergeFilterFlags checks that older filter spellings fill the shared
+// filter and that differing values are rejected.
+func TestMergeFilterFlags(t *testing.T) {
+	for _, tt := range []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{nil, "", false},
+		{[]string{"--managed-by", "sub1"}, "sub1", false},
+		{[]string{"--filter-subscription-id", "sub1", "--subscription", "sub1"}, "sub1", false},
+		{[]string{"--subscription", "sub1", "--managed-by", "sub2"}, "", true},
+	} {
+		cmd := endpointListCmd()
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		err := mergeFilterFlags(cmd, &got, "filter-subscription-id", "subscription", "managed-by")
+		if (err != nil) != tt.wantErr || !tt.wantErr && got != tt.want {
+			t.Errorf("%v: got %q, err = %v", tt.args, got, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// directoryLister is the subset of the Transfer client used for client-side
+// glob expansion; *transfer.Client satisfies it.
+type directoryLister interface {
+	ListDirectory(ctx context.Context, endpointID, path string, options *transfer.ListDirectoryOptions) (*transfer.DirectoryListing, error)
+}
+
+// globMatch is one path produced by expanding a glob pattern. Literal marks
+// a path given without glob characters, whose type was never looked up.
+type globMatch struct {
+	Path    string
+	IsDir   bool
+	Literal bool
+}
+
+// hasGlobMeta reports whether p contains shell-style glob metacharacters
+// that are not escaped with a backslash.
+func hasGlobMeta(p string) bool {
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case '*', '?', '[':
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeGlob removes the backslashes escaping '*', '?', '[', ']' or another
+// backslash in a path without glob metacharacters, so 'a\[1\].txt' names the
+// file a[1].txt. Other backslashes are kept as they are.
+func unescapeGlob(p string) string {
+	if !strings.Contains(p, "\\") {
+		return p
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] == '\\' && i+1 < len(p) && strings.IndexByte("*?[]\\", p[i+1]) >= 0 {
+			i++
+		}
+		b.WriteByte(p[i])
+	}
+	return b.String()
+}
+
+// expandGlob expands a shell-style pattern against an endpoint's directory
+// listings, one ListDirectory call per directory level that contains a glob.
+// As in a POSIX shell, wildcards do not match a leading '.' unless the pattern
+// component itself starts with one. Literal components are checked against
+// their directory's listing when it was already read, and a final literal
+// component always is; a directory that does not exist simply matches
+// nothing. A backslash escapes a metacharacter. Matches are returned sorted;
+// a pattern that matches nothing is an error.
+func expandGlob(ctx context.Context, lister directoryLister, endpointID, pattern, localUser string) ([]globMatch, error) {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+
+	listings := map[string]*transfer.DirectoryListing{}
+	list := func(dir string) (*transfer.DirectoryListing, error) {
+		if listing, ok := listings[dir]; ok {
+			return listing, nil
+		}
+		listing, err := lister.ListDirectory(ctx, endpointID, dir, &transfer.ListDirectoryOptions{
+			ShowHidden: true,
+			LocalUser:  localUser,
+		})
+		if isNotFound(err) {
+			// A directory reached through a literal component may not
+			// exist; it has no entries.
+			listing, err = &transfer.DirectoryListing{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s:%s while expanding %q: %w", endpointID, dir, pattern, err)
+		}
+		listings[dir] = listing
+		return listing, nil
+	}
+
+	// Every path is a directory until a component says otherwise. Absolute
+	// patterns start at "/", home-relative ones at their "~" component, and
+	// relative ones at the endpoint's default directory ("").
+	root := ""
+	if strings.HasPrefix(pattern, "/") {
+		root = "/"
+	} else if strings.HasPrefix(parts[0], "~") {
+		root, parts = parts[0], parts[1:]
+	}
+	current := []globMatch{{Path: root, IsDir: true}}
+
+	for i, part := range parts {
+		last := i == len(parts)-1
+		var next []globMatch
+		for _, base := range current {
+			if !base.IsDir {
+				continue
+			}
+			if !hasGlobMeta(part) {
+				part := unescapeGlob(part)
+				// Intermediate literal components are joined unchecked
+				// unless their directory was listed already: listing the
+				// next level finds out whether they exist.
+				if _, listed := listings[base.Path]; !listed && !last {
+					next = append(next, globMatch{Path: path.Join(base.Path, part), IsDir: true})
+					continue
+				}
+				listing, err := list(base.Path)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range listing.Data {
+					if item.Name == part {
+						next = append(next, globMatch{Path: path.Join(base.Path, item.Name), IsDir: item.Type == "dir"})
+						break
+					}
+				}
+				continue
+			}
+			if _, err := path.Match(part, ""); err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+			}
+			listing, err := list(base.Path)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range listing.Data {
+				if item.Name == "." || item.Name == ".." {
+					continue
+				}
+				if strings.HasPrefix(item.Name, ".") && !strings.HasPrefix(part, ".") {
+					continue
+				}
+				if ok, _ := path.Match(part, item.Name); ok {
+					next = append(next, globMatch{Path: path.Join(base.Path, item.Name), IsDir: item.Type == "dir"})
+				}
+			}
+		}
+		current = next
+	}
+
+	if len(current) == 0 {
+		return nil, fmt.Errorf("no matches for %s:%s", endpointID, pattern)
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].Path < current[j].Path })
+	return current, nil
+}
+
+// isNotFound reports whether err is Transfer's answer for a path that does
+// not exist.
+func isNotFound(err error) bool {
+	var apiErr *core.APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || strings.HasSuffix(apiErr.Code, "NotFound"))
+}
+
+// expandPaths expands every glob pattern in paths, passing literal paths
+// through, unescaped, as Literal matches. expanded reports whether any
+// pattern was expanded.
+func expandPaths(ctx context.Context, lister directoryLister, endpointID string, paths []string, localUser string) (matches []globMatch, expanded bool, err error) {
+	for _, p := range paths {
+		if !hasGlobMeta(p) {
+			matches = append(matches, globMatch{Path: unescapeGlob(p), Literal: true})
+			continue
+		}
+		m, err := expandGlob(ctx, lister, endpointID, p, localUser)
+		if err != nil {
+			return nil, false, err
+		}
+		matches = append(matches, m...)
+		expanded = true
+	}
+	return matches, expanded, nil
+}
+
+// resolveDeletePaths returns the paths an rm or delete task should remove.
+// With noGlob (--no-glob) paths are taken literally, and with serverGlobs
+// (--enable-globs) patterns are passed through for the Transfer service to
+// interpret; otherwise they are expanded here, and a matched directory
+// requires recursive.
+func resolveDeletePaths(ctx context.Context, lister directoryLister, endpointID string, paths []string, localUser string, noGlob, serverGlobs, recursive bool) ([]globMatch, error) {
+	if noGlob && serverGlobs {
+		return nil, fmt.Errorf("--no-glob cannot be combined with --enable-globs")
+	}
+	if noGlob || serverGlobs {
+		matches := make([]globMatch, 0, len(paths))
+		for _, p := range paths {
+			matches = append(matches, globMatch{Path: p, Literal: true})
+		}
+		return matches, nil
+	}
+
+	matches, _, err := expandPaths(ctx, lister, endpointID, paths, localUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range matches {
+		if !m.Literal && m.IsDir && !recursive {
+			return nil, fmt.Errorf("%s is a directory. Use --recursive to remove directories", m.Path)
+		}
+	}
+	return matches, nil
+}
+
+// deleteItems converts resolved paths into delete task items.
+func deleteItems(matches []globMatch) []transfer.DeleteItem {
+	items := make([]transfer.DeleteItem, 0, len(matches))
+	for _, m := range matches {
+		items = append(items, transfer.DeleteItem{DATA_TYPE: "delete_item", Path: m.Path})
+	}
+	return items
+}
+
+// splitEndpointArgs parses ENDPOINT_ID:PATH arguments that must all name the
+// same endpoint (a single Transfer task has one source endpoint, and a delete
+// task one endpoint). It returns the endpoint and the paths in order.
+func splitEndpointArgs(args []string) (string, []string, error) {
+	var endpointID string
+	paths := make([]string, 0, len(args))
+	for _, arg := range args {
+		ep, p := parseEndpointAndPath(arg)
+		if endpointID == "" {
+			endpointID = ep
+		} else if ep != endpointID {
+			return "", nil, fmt.Errorf("all paths must be on the same endpoint (got %s and %s)", endpointID, ep)
+		}
+		paths = append(paths, p)
+	}
+	return endpointID, paths, nil
+}
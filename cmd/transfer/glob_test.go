@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+// stubLister serves directory listings from a map of path -> entries, where a
+// trailing "/" on an entry name marks a directory.
+type stubLister map[string][]string
+
+func (s stubLister) ListDirectory(_ context.Context, _ string, p string, _ *transfer.ListDirectoryOptions) (*transfer.DirectoryListing, error) {
+	names, ok := s[p]
+	if !ok {
+		return nil, &core.APIError{StatusCode: http.StatusNotFound, Code: "ClientError.NotFound", Message: "no such directory " + p}
+	}
+	listing := &transfer.DirectoryListing{}
+	for _, name := range names {
+		entry := transfer.DirectoryEntry{Name: name, Type: "file"}
+		if name[len(name)-1] == '/' {
+			entry.Name, entry.Type = name[:len(name)-1], "dir"
+		}
+		listing.Data = append(listing.Data, entry)
+	}
+	return listing, nil
+}
+
+var testTree = stubLister{
+	"/data":      {"run_2.h5", "run_1.h5", "run_1.log", ".run_0.h5", "raw/", "cal/", "a1.txt", "a[1].txt"},
+	"/data/raw":  {"x.h5"},
+	"/data/cal":  {"x.h5", "y.h5"},
+	"~/projects": {"a/"},
+}
+
+// TestExpandGlob covers single- and multi-level patterns, hidden files and
+// patterns without matches.
+func TestExpandGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []globMatch
+		wantErr bool
+	}{
+		{"/data/run_*.h5", []globMatch{{Path: "/data/run_1.h5"}, {Path: "/data/run_2.h5"}}, false},
+		{"/data/.run_*", []globMatch{{Path: "/data/.run_0.h5"}}, false},
+		{"/data/r*", []globMatch{{Path: "/data/raw", IsDir: true}, {Path: "/data/run_1.h5"}, {Path: "/data/run_1.log"}, {Path: "/data/run_2.h5"}}, false},
+		{"/data/*/x.h5", []globMatch{{Path: "/data/cal/x.h5"}, {Path: "/data/raw/x.h5"}}, false},
+		{"/data/c?l/[xy].h5", []globMatch{{Path: "/data/cal/x.h5"}, {Path: "/data/cal/y.h5"}}, false},
+		{"~/projects/*", []globMatch{{Path: "~/projects/a", IsDir: true}}, false},
+		{"/data/*/y.h5", []globMatch{{Path: "/data/cal/y.h5"}}, false},
+		{"/data/*/x.h5/z", nil, true},
+		{"/data/*/x.hdf5", nil, true},
+		{"/missing/*.dat", nil, true},
+		{"/data/*.tif", nil, true},
+		{"/data/a[1].txt", []globMatch{{Path: "/data/a1.txt"}}, false},
+		{`/data/a\[1].txt`, []globMatch{{Path: "/data/a[1].txt"}}, false},
+		{`/data/*\[1].txt`, []globMatch{{Path: "/data/a[1].txt"}}, false},
+		{"/data/[", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := expandGlob(context.Background(), testTree, "ep", tt.pattern, "")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("expandGlob(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			continue
+		}
+		if err != nil && tt.pattern != "/data/[" && !strings.Contains(err.Error(), "no matches") {
+			t.Errorf("expandGlob(%q) error = %v, want no matches", tt.pattern, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandGlob(%q) = %+v, want %+v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// TestBuildTransferItemsMultiSource checks that globs and multiple sources
+// become one item per path inside the destination directory.
+func TestBuildTransferItemsMultiSource(t *testing.T) {
+	defer resetTransferOptions()
+	resetTransferOptions()
+
+	ctx := context.Background()
+
+	items, err := buildTransferItems(ctx, testTree, "ep", []string{"/data/run_*.h5", "/data/run_1.log"}, "/dest/")
+	if err != nil {
+		t.Fatalf("buildTransferItems: %v", err)
+	}
+	var got []string
+	for _, item := range items {
+		got = append(got, item.SourcePath+" -> "+item.DestinationPath)
+	}
+	want := []string{
+		"/data/run_1.h5 -> /dest/run_1.h5",
+		"/data/run_2.h5 -> /dest/run_2.h5",
+		"/data/run_1.log -> /dest/run_1.log",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+
+	// A single literal source keeps the destination path as given.
+	items, err = buildTransferItems(ctx, testTree, "ep", []string{"/data/run_1.h5"}, "/dest/renamed.h5")
+	if err != nil || len(items) != 1 || items[0].DestinationPath != "/dest/renamed.h5" {
+		t.Errorf("single source = %+v, %v", items, err)
+	}
+
+	// Matched directories need --recursive.
+	if _, err := buildTransferItems(ctx, testTree, "ep", []string{"/data/*"}, "/dest"); err == nil {
+		t.Error("expected an error for a directory match without --recursive")
+	}
+	transferRecursive = true
+	items, err = buildTransferItems(ctx, testTree, "ep", []string{"/data/ra*"}, "/dest")
+	if err != nil || len(items) != 1 || !items[0].Recursive {
+		t.Errorf("recursive directory match = %+v, %v", items, err)
+	}
+
+	// Two sources with the same base name would collide.
+	if _, err := buildTransferItems(ctx, testTree, "ep", []string{"/data/*/x.h5"}, "/dest"); err == nil {
+		t.Error("expected an error for colliding destination names")
+	}
+
+	// --no-glob passes patterns through literally.
+	transferNoGlob = true
+	items, err = buildTransferItems(ctx, testTree, "ep", []string{"/data/run_*.h5"}, "/dest/")
+	if err != nil || len(items) != 1 || items[0].SourcePath != "/data/run_*.h5" {
+		t.Errorf("--no-glob = %+v, %v", items, err)
+	}
+}
+
+// TestSplitEndpointArgs checks that multi-path arguments must share an
+// endpoint.
+func TestSplitEndpointArgs(t *testing.T) {
+	ep, paths, err := splitEndpointArgs([]string{"ep:/a", "ep:/b"})
+	if err != nil || ep != "ep" || !reflect.DeepEqual(paths, []string{"/a", "/b"}) {
+		t.Errorf("splitEndpointArgs = %s, %v, %v", ep, paths, err)
+	}
+	if _, _, err := splitEndpointArgs([]string{"ep:/a", "other:/b"}); err == nil {
+		t.Error("expected an error for paths on different endpoints")
+	}
+}
+
+// TestResolveDeletePaths covers local expansion, escapes, --no-glob and
+// --enable-globs pass-through for rm and delete.
+func TestResolveDeletePaths(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := resolveDeletePaths(ctx, testTree, "ep", []string{"/data/*.log"}, "", false, false, false)
+	if err != nil || len(got) != 1 || got[0].Path != "/data/run_1.log" {
+		t.Errorf("local expansion = %+v, %v", got, err)
+	}
+	if _, err := resolveDeletePaths(ctx, testTree, "ep", []string{"/data/c*"}, "", false, false, false); err == nil {
+		t.Error("expected an error for a directory match without --recursive")
+	}
+	got, err = resolveDeletePaths(ctx, testTree, "ep", []string{"/data/*.log"}, "", false, true, false)
+	if err != nil || len(got) != 1 || got[0].Path != "/data/*.log" {
+		t.Errorf("--enable-globs = %+v, %v", got, err)
+	}
+
+	// A literal bracketed name is deleted as itself, never as a1.txt.
+	want := []globMatch{{Path: "/data/a[1].txt", Literal: true}}
+	got, err = resolveDeletePaths(ctx, testTree, "ep", []string{"/data/a[1].txt"}, "", true, false, false)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("--no-glob = %+v, %v", got, err)
+	}
+	got, err = resolveDeletePaths(ctx, testTree, "ep", []string{`/data/a\[1\].txt`}, "", false, false, false)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("escaped = %+v, %v", got, err)
+	}
+	if _, err := resolveDeletePaths(ctx, testTree, "ep", []string{"/data/a[1].txt"}, "", true, true, false); err == nil {
+		t.Error("expected an error for --no-glob with --enable-globs")
+	}
+}
@@ -4,12 +4,10 @@ package transfer
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
-	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/gcpconfig"
 )
 
 // endpointLocalIDCmd returns the `endpoint local-id` command, matching the
@@ -42,28 +40,12 @@ current user, by reading the local GCP configuration directory
 	return cmd
 }
 
-// localGCPEndpointID reads the GCP endpoint ID from the local config directory,
-// mirroring globus_sdk.LocalGlobusConnectPersonal: <config>/lta/client-id.txt
-// on non-Windows, <config>/client-id.txt on Windows, where <config> defaults to
-// ~/.globusonline. A missing file yields ("", nil).
+// localGCPEndpointID reads the GCP endpoint ID from the default config
+// directory. A missing installation yields ("", nil).
 func localGCPEndpointID() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("cannot determine home directory: %w", err)
-	}
-	configDir := filepath.Join(home, ".globusonline")
-	var idFile string
-	if runtime.GOOS == "windows" {
-		idFile = filepath.Join(configDir, "client-id.txt")
-	} else {
-		idFile = filepath.Join(configDir, "lta", "client-id.txt")
-	}
-	data, err := os.ReadFile(idFile)
+	configDir, err := gcpconfig.DefaultDir()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return "", nil
-		}
-		return "", fmt.Errorf("cannot read local endpoint ID: %w", err)
+		return "", err
 	}
-	return strings.TrimSpace(string(data)), nil
+	return gcpconfig.ReadEndpointID(configDir)
 }
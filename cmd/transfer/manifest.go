@@ -0,0 +1,571 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/cmd/collection"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/transfer"
+)
+
+var (
+	manifestFormat     string
+	manifestOutput     string
+	manifestAlgorithm  string
+	manifestNoChecksum bool
+	manifestLocalUser  string
+)
+
+// Manifest formats accepted by --manifest-format.
+const (
+	manifestFormatJSON  = "json"
+	manifestFormatBagIt = "bagit"
+)
+
+// manifestEntry records the fixity information for one file, relative to the
+// manifest root.
+type manifestEntry struct {
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"last_modified,omitempty"`
+	Checksum     string `json:"checksum,omitempty"`
+}
+
+// manifest is the JSON manifest document. BagIt manifests load into the same
+// structure with only Path and Checksum populated, and noSizes set.
+type manifest struct {
+	Endpoint  string          `json:"endpoint,omitempty"`
+	Root      string          `json:"root,omitempty"`
+	Algorithm string          `json:"checksum_algorithm,omitempty"`
+	Created   string          `json:"created,omitempty"`
+	Entries   []manifestEntry `json:"entries"`
+
+	noSizes bool
+}
+
+// ManifestCmd returns the manifest command group, attached under the
+// top-level `transfer` verb (globus transfer manifest ...).
+func ManifestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Generate and verify checksum manifests for endpoint paths",
+		Long: `Generate and verify fixity manifests for a directory tree on an endpoint.
+
+For collections with HTTPS enabled, files are read over the collection's
+HTTPS data plane (the same downloader used by 'globus collection cat') and
+their checksums recorded. Other endpoints record size and modification time
+only.
+
+A manifest can be fed into 'globus transfer --manifest FILE' to submit one
+transfer item per entry with --external-checksum set from the manifest.`,
+	}
+
+	cmd.AddCommand(
+		manifestCreateCmd(),
+		manifestVerifyCmd(),
+	)
+
+	return cmd
+}
+
+// manifestCreateCmd returns the manifest create command.
+func manifestCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create ENDPOINT_ID:PATH",
+		Short: "Walk a directory tree and write a manifest",
+		Long: `Walk a directory tree on an endpoint and write a manifest of every file.
+
+The JSON format records path, size, modification time and (for HTTPS-enabled
+collections) a checksum. The bagit format writes a BagIt payload manifest
+(manifest-<algorithm>.txt) and therefore requires checksums; the walked
+directory is the bag's data/ directory, so every path starts with data/.
+
+Examples:
+  globus transfer manifest create ENDPOINT_ID:/data/run42 -o run42.json
+  globus transfer manifest create ENDPOINT_ID:/data/run42 --manifest-format bagit -o manifest-sha256.txt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			endpointID, root := parseEndpointAndPath(args[0])
+			return createManifest(cmd, endpointID, root)
+		},
+	}
+
+	cmd.Flags().StringVar(&manifestFormat, "manifest-format", manifestFormatJSON, "Manifest format: json or bagit")
+	cmd.Flags().StringVarP(&manifestOutput, "output", "o", "", "Write the manifest to this file instead of stdout")
+	cmd.Flags().StringVar(&manifestAlgorithm, "algorithm", "sha256", "Checksum algorithm: md5, sha1, sha256, or sha512")
+	cmd.Flags().BoolVar(&manifestNoChecksum, "no-checksum", false, "Record size and modification time only, even for HTTPS-enabled collections")
+	cmd.Flags().StringVar(&manifestLocalUser, "local-user", "", "Local user to map to (GCSv5 mapped collections)")
+
+	return cmd
+}
+
+// manifestVerifyCmd returns the manifest verify command.
+func manifestVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify ENDPOINT_ID:PATH MANIFEST_FILE",
+		Short: "Check a directory tree against a manifest",
+		Long: `Check a directory tree on an endpoint against a manifest.
+
+Every manifest entry is compared with the live tree: missing files, size and
+modification-time changes, and (for HTTPS-enabled collections) checksum
+mismatches are reported, as are files present on the endpoint but absent from
+the manifest. The command exits non-zero if any difference is found.
+
+Examples:
+  globus transfer manifest verify ENDPOINT_ID:/data/run42 run42.json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			endpointID, root := parseEndpointAndPath(args[0])
+			return verifyManifest(cmd, endpointID, root, args[1])
+		},
+	}
+
+	cmd.Flags().BoolVar(&manifestNoChecksum, "no-checksum", false, "Skip checksum comparison even for HTTPS-enabled collections")
+	cmd.Flags().StringVar(&manifestLocalUser, "local-user", "", "Local user to map to (GCSv5 mapped collections)")
+
+	return cmd
+}
+
+// createManifest walks endpointID:root and writes the resulting manifest.
+func createManifest(cmd *cobra.Command, endpointID, root string) error {
+	if manifestFormat != manifestFormatJSON && manifestFormat != manifestFormatBagIt {
+		return fmt.Errorf("invalid --manifest-format %q (use json or bagit)", manifestFormat)
+	}
+	if _, err := newChecksumHash(manifestAlgorithm); err != nil {
+		return err
+	}
+	if manifestFormat == manifestFormatBagIt && manifestNoChecksum {
+		return fmt.Errorf("the bagit format requires checksums; drop --no-checksum or use --manifest-format json")
+	}
+
+	// Walking and hashing a large tree can take a while; bound it generously.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	transferClient, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := manifestChecksummer(ctx, transferClient, endpointID, manifestAlgorithm)
+	if err != nil {
+		return err
+	}
+	if manifestFormat == manifestFormatBagIt && hasher == nil {
+		return fmt.Errorf("endpoint %s does not have HTTPS enabled; the bagit format requires checksums (use --manifest-format json)", endpointID)
+	}
+
+	m := &manifest{
+		Endpoint: endpointID,
+		Root:     root,
+		Created:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if hasher != nil {
+		m.Algorithm = strings.ToLower(manifestAlgorithm)
+	}
+
+	err = walkTree(ctx, transferClient, endpointID, root, manifestLocalUser, func(rel string, item transfer.DirectoryEntry) error {
+		entry := manifestEntry{Path: rel, Size: item.Size}
+		if !item.LastModified.IsZero() {
+			entry.LastModified = item.LastModified.UTC().Format(time.RFC3339)
+		}
+		if hasher != nil {
+			sum, herr := hasher(path.Join(root, rel))
+			if herr != nil {
+				return herr
+			}
+			entry.Checksum = sum
+		}
+		m.Entries = append(m.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeManifest(&buf, m, manifestFormat); err != nil {
+		return err
+	}
+	if manifestOutput == "" {
+		_, err = cmd.OutOrStdout().Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(manifestOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d entries to %s\n", len(m.Entries), manifestOutput)
+	return nil
+}
+
+// manifestFinding is one difference reported by manifest verify.
+type manifestFinding struct {
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// verifyManifest compares endpointID:root against the manifest in file.
+func verifyManifest(cmd *cobra.Command, endpointID, root, file string) error {
+	m, err := loadManifest(file)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	transferClient, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]manifestEntry)
+	err = walkTree(ctx, transferClient, endpointID, root, manifestLocalUser, func(rel string, item transfer.DirectoryEntry) error {
+		entry := manifestEntry{Path: rel, Size: item.Size}
+		if !item.LastModified.IsZero() {
+			entry.LastModified = item.LastModified.UTC().Format(time.RFC3339)
+		}
+		live[rel] = entry
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only fetch file contents when the manifest actually carries checksums.
+	var hasher func(string) (string, error)
+	if m.Algorithm != "" && hasManifestChecksums(m) {
+		h, herr := manifestChecksummer(ctx, transferClient, endpointID, m.Algorithm)
+		if herr != nil {
+			return herr
+		}
+		hasher = h
+	}
+
+	findings, err := compareManifest(m, live, func(rel string) (string, error) {
+		if hasher == nil {
+			return "", nil
+		}
+		return hasher(path.Join(root, rel))
+	})
+	if err != nil {
+		return err
+	}
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		if err := formatter.FormatOutput(findings, nil); err != nil {
+			return err
+		}
+	} else if len(findings) > 0 {
+		if err := formatter.FormatOutput(findings, []string{"Path", "Status", "Expected", "Actual"}); err != nil {
+			return err
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("manifest verification failed: %d difference(s) found", len(findings))
+	}
+	if formatter.Format != output.FormatJSON {
+		fmt.Fprintf(cmd.OutOrStdout(), "All %d manifest entries verified.\n", len(m.Entries))
+	}
+	return nil
+}
+
+// compareManifest diffs a manifest against the live listing. checksum is called
+// only for entries that carry a checksum and still match on size; an empty
+// result from it means "not checked". Findings are sorted by path.
+func compareManifest(m *manifest, live map[string]manifestEntry, checksum func(rel string) (string, error)) ([]manifestFinding, error) {
+	findings := []manifestFinding{}
+	seen := make(map[string]bool, len(m.Entries))
+
+	for _, want := range m.Entries {
+		seen[want.Path] = true
+		got, ok := live[want.Path]
+		if !ok {
+			findings = append(findings, manifestFinding{Path: want.Path, Status: "missing"})
+			continue
+		}
+		// BagIt manifests carry no size, so only their checksums are compared.
+		if !m.noSizes && want.Size != got.Size {
+			findings = append(findings, manifestFinding{
+				Path: want.Path, Status: "size-mismatch",
+				Expected: fmt.Sprintf("%d", want.Size), Actual: fmt.Sprintf("%d", got.Size),
+			})
+			continue
+		}
+		if want.Checksum != "" {
+			sum, err := checksum(want.Path)
+			if err != nil {
+				return nil, err
+			}
+			if sum != "" && !strings.EqualFold(sum, want.Checksum) {
+				findings = append(findings, manifestFinding{
+					Path: want.Path, Status: "checksum-mismatch",
+					Expected: want.Checksum, Actual: sum,
+				})
+				continue
+			}
+			if sum != "" {
+				// A matching checksum is authoritative; ignore mtime drift.
+				continue
+			}
+		}
+		if want.LastModified != "" && got.LastModified != "" && want.LastModified != got.LastModified {
+			findings = append(findings, manifestFinding{
+				Path: want.Path, Status: "mtime-mismatch",
+				Expected: want.LastModified, Actual: got.LastModified,
+			})
+		}
+	}
+
+	for rel := range live {
+		if !seen[rel] {
+			findings = append(findings, manifestFinding{Path: rel, Status: "extra"})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Path < findings[j].Path })
+	return findings, nil
+}
+
+// hasManifestChecksums reports whether any entry carries a checksum.
+func hasManifestChecksums(m *manifest) bool {
+	for _, e := range m.Entries {
+		if e.Checksum != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// walkTree lists root recursively and calls visit for every regular file with
+// its path relative to root. Directories are descended into; symlinks and
+// other special entries are skipped.
+func walkTree(ctx context.Context, client *transfer.Client, endpointID, root, localUser string, visit func(rel string, item transfer.DirectoryEntry) error) error {
+	pending := []string{""}
+	for len(pending) > 0 {
+		rel := pending[0]
+		pending = pending[1:]
+
+		listing, err := client.ListDirectory(ctx, endpointID, path.Join(root, rel), &transfer.ListDirectoryOptions{
+			ShowHidden: true,
+			LocalUser:  localUser,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list %s:%s: %w", endpointID, path.Join(root, rel), err)
+		}
+
+		for _, item := range listing.Data {
+			if item.Name == "." || item.Name == ".." {
+				continue
+			}
+			child := path.Join(rel, item.Name)
+			switch item.Type {
+			case "dir":
+				pending = append(pending, child)
+			case "file":
+				if err := visit(child, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// manifestChecksummer returns a function that checksums a file on endpointID
+// over its HTTPS data plane using algorithm. It returns a nil function when
+// checksums are disabled or the endpoint has no HTTPS server.
+func manifestChecksummer(ctx context.Context, client *transfer.Client, endpointID, algorithm string) (func(string) (string, error), error) {
+	if manifestNoChecksum {
+		return nil, nil
+	}
+
+	ep, err := client.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up endpoint %s: %w", endpointID, err)
+	}
+	if ep.HTTPSServer == "" {
+		return nil, nil
+	}
+
+	reader, err := collection.NewHTTPSFileReader(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	sum := func(p string) (string, error) {
+		return checksumHTTPSFile(ctx, reader, collection.HTTPSFileURL(ep.HTTPSServer, p), algorithm)
+	}
+	return sum, nil
+}
+
+// checksumHTTPSFile streams fileURI through the hash and returns its hex
+// digest.
+func checksumHTTPSFile(ctx context.Context, reader *collection.HTTPSFileReader, fileURI, algorithm string) (string, error) {
+	h, err := newChecksumHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	body, err := reader.Open(ctx, fileURI)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", fileURI, err)
+	}
+	defer func() { _ = body.Close() }()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", fileURI, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newChecksumHash returns a hash for a manifest algorithm name. Names are
+// case-insensitive and may be given in Transfer's upper-case form (SHA256).
+func newChecksumHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q (use md5, sha1, sha256, or sha512)", algorithm)
+	}
+}
+
+// writeManifest serializes m in the given format.
+func writeManifest(w io.Writer, m *manifest, format string) error {
+	if format == manifestFormatBagIt {
+		for _, e := range m.Entries {
+			if _, err := fmt.Fprintf(w, "%s  %s\n", e.Checksum, bagItPathEscaper.Replace(bagItPayloadDir+e.Path)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// loadManifest reads a JSON or BagIt manifest from file. BagIt manifests take
+// their algorithm from the manifest-<algorithm>.txt file name.
+func loadManifest(file string) (*manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var m manifest
+		if err := json.Unmarshal(trimmed, &m); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %w", file, err)
+		}
+		return &m, nil
+	}
+
+	return parseBagItManifest(bytes.NewReader(data), bagItAlgorithm(file))
+}
+
+// bagItAlgorithm infers the checksum algorithm from a BagIt manifest file name
+// (manifest-sha256.txt -> sha256), defaulting to sha256.
+func bagItAlgorithm(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), ".txt")
+	if alg, ok := strings.CutPrefix(name, "manifest-"); ok && alg != "" {
+		return alg
+	}
+	return "sha256"
+}
+
+// bagItPayloadDir prefixes every path in a BagIt payload manifest.
+const bagItPayloadDir = "data/"
+
+// BagIt percent-encodes line breaks and percent signs in manifest paths.
+var (
+	bagItPathEscaper   = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	bagItPathUnescaper = strings.NewReplacer("%25", "%", "%0D", "\r", "%0d", "\r", "%0A", "\n", "%0a", "\n")
+)
+
+// parseBagItManifest parses "CHECKSUM  PATH" lines, where any run of spaces
+// and tabs separates the two and the rest of the line, whitespace included,
+// is the path. Paths are returned relative to the payload directory, without
+// the data/ prefix.
+func parseBagItManifest(r io.Reader, algorithm string) (*manifest, error) {
+	m := &manifest{Algorithm: algorithm, noSizes: true}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		sep := strings.IndexAny(text, " \t")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid manifest line %d: want \"CHECKSUM  PATH\"", line)
+		}
+		p := strings.TrimLeft(text[sep:], " \t")
+		if p == "" {
+			return nil, fmt.Errorf("invalid manifest line %d: want \"CHECKSUM  PATH\"", line)
+		}
+		m.Entries = append(m.Entries, manifestEntry{
+			Checksum: text[:sep],
+			Path:     strings.TrimPrefix(bagItPathUnescaper.Replace(p), bagItPayloadDir),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return m, nil
+}
+
+// manifestTransferItems builds one transfer item per manifest entry, rooted at
+// sourceRoot and destRoot, carrying each entry's checksum as the item's
+// external checksum.
+func manifestTransferItems(m *manifest, sourceRoot, destRoot string) []transfer.TransferItem {
+	items := make([]transfer.TransferItem, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		item := transfer.TransferItem{
+			DATA_TYPE:       "transfer_item",
+			SourcePath:      path.Join(sourceRoot, e.Path),
+			DestinationPath: path.Join(destRoot, e.Path),
+		}
+		if e.Checksum != "" {
+			item.ExternalChecksum = e.Checksum
+			item.ChecksumAlgorithm = strings.ToUpper(m.Algorithm)
+		}
+		items = append(items, item)
+	}
+	return items
+}
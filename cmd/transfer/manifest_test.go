@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBagItManifestRoundTrip checks that a BagIt manifest written by
+// writeManifest parses back to the same entries.
+func TestBagItManifestRoundTrip(t *testing.T) {
+	m := &manifest{
+		Algorithm: "sha256",
+		Entries: []manifestEntry{
+			{Path: "a.txt", Checksum: "aa"},
+			{Path: "sub dir/b.h5", Checksum: "bb"},
+			{Path: "100%.txt", Checksum: "cc"},
+			{Path: "notes ", Checksum: "dd"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeManifest(&buf, m, manifestFormatBagIt); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+	if want := "aa  data/a.txt\nbb  data/sub dir/b.h5\ncc  data/100%25.txt\ndd  data/notes \n"; buf.String() != want {
+		t.Fatalf("bagit output = %q, want %q", buf.String(), want)
+	}
+
+	got, err := parseBagItManifest(strings.NewReader(buf.String()), "sha256")
+	if err != nil {
+		t.Fatalf("parseBagItManifest: %v", err)
+	}
+	m.noSizes = true
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("parsed = %+v, want %+v", got, m)
+	}
+
+	// Tabs separate too, and only the first run of whitespace does.
+	got, err = parseBagItManifest(strings.NewReader("aa\tdata/a.txt\r\nbb \t data/two  spaces.txt \n"), "sha256")
+	want := []manifestEntry{{Path: "a.txt", Checksum: "aa"}, {Path: "two  spaces.txt ", Checksum: "bb"}}
+	if err != nil || !reflect.DeepEqual(got.Entries, want) {
+		t.Errorf("whitespace: entries = %+v, %v", got, err)
+	}
+
+	for _, bad := range []string{"nopath\n", "aa  \t\n", " data/a.txt\n"} {
+		if _, err := parseBagItManifest(strings.NewReader(bad), "sha256"); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
+
+// TestBagItAlgorithm covers algorithm inference from BagIt file names.
+func TestBagItAlgorithm(t *testing.T) {
+	tests := map[string]string{
+		"manifest-sha256.txt":       "sha256",
+		"/tmp/bag/manifest-md5.txt": "md5",
+		"fixity.txt":                "sha256",
+	}
+	for file, want := range tests {
+		if got := bagItAlgorithm(file); got != want {
+			t.Errorf("bagItAlgorithm(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
+
+// TestCompareManifest covers each finding status reported by manifest verify.
+func TestCompareManifest(t *testing.T) {
+	m := &manifest{
+		Algorithm: "sha256",
+		Entries: []manifestEntry{
+			{Path: "same.txt", Size: 3, LastModified: "2026-01-01T00:00:00Z"},
+			{Path: "gone.txt", Size: 1},
+			{Path: "grew.txt", Size: 1},
+			{Path: "touched.txt", Size: 2, LastModified: "2026-01-01T00:00:00Z"},
+			{Path: "corrupt.bin", Size: 4, Checksum: "good"},
+			{Path: "empty.bin", Checksum: "e3"},
+		},
+	}
+	live := map[string]manifestEntry{
+		"same.txt":    {Path: "same.txt", Size: 3, LastModified: "2026-01-01T00:00:00Z"},
+		"grew.txt":    {Path: "grew.txt", Size: 9},
+		"touched.txt": {Path: "touched.txt", Size: 2, LastModified: "2026-02-01T00:00:00Z"},
+		"corrupt.bin": {Path: "corrupt.bin", Size: 4},
+		"empty.bin":   {Path: "empty.bin", Size: 12},
+		"new.txt":     {Path: "new.txt", Size: 1},
+	}
+	sums := map[string]string{"corrupt.bin": "bad", "empty.bin": "e3", "bagit.bin": "OK"}
+
+	findings, err := compareManifest(m, live, func(rel string) (string, error) { return sums[rel], nil })
+	if err != nil {
+		t.Fatalf("compareManifest: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, f := range findings {
+		got[f.Path] = f.Status
+	}
+	want := map[string]string{
+		"gone.txt":    "missing",
+		"grew.txt":    "size-mismatch",
+		"empty.bin":   "size-mismatch",
+		"touched.txt": "mtime-mismatch",
+		"corrupt.bin": "checksum-mismatch",
+		"new.txt":     "extra",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+
+	// A BagIt manifest has no sizes; its checksums decide.
+	bag := &manifest{Algorithm: "sha256", Entries: []manifestEntry{{Path: "bagit.bin", Checksum: "ok"}}, noSizes: true}
+	findings, err = compareManifest(bag, map[string]manifestEntry{"bagit.bin": {Path: "bagit.bin", Size: 12}}, func(rel string) (string, error) { return sums[rel], nil })
+	if err != nil || len(findings) != 0 {
+		t.Errorf("bagit findings = %+v, %v", findings, err)
+	}
+}
+
+// TestManifestTransferItems checks that manifest entries become rooted
+// transfer items carrying their checksums.
+func TestManifestTransferItems(t *testing.T) {
+	m := &manifest{
+		Algorithm: "sha256",
+		Entries: []manifestEntry{
+			{Path: "a.txt", Checksum: "aa"},
+			{Path: "sub/b.txt"},
+		},
+	}
+
+	items := manifestTransferItems(m, "/data/run42", "/archive/run42/")
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if items[0].SourcePath != "/data/run42/a.txt" || items[0].DestinationPath != "/archive/run42/a.txt" {
+		t.Errorf("item 0 paths = %s -> %s", items[0].SourcePath, items[0].DestinationPath)
+	}
+	if items[0].ExternalChecksum != "aa" || items[0].ChecksumAlgorithm != "SHA256" {
+		t.Errorf("item 0 checksum = %s/%s, want aa/SHA256", items[0].ExternalChecksum, items[0].ChecksumAlgorithm)
+	}
+	if items[1].ExternalChecksum != "" || items[1].ChecksumAlgorithm != "" {
+		t.Errorf("item 1 should carry no checksum, got %s/%s", items[1].ExternalChecksum, items[1].ChecksumAlgorithm)
+	}
+}
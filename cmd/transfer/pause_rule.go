@@ -0,0 +1,533 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// pauseOperationFields maps the operation names accepted by --operations and
+// window files to the pause_rule document's boolean fields.
+var pauseOperationFields = map[string]string{
+	"read":    "pause_task_transfer_read",
+	"write":   "pause_task_transfer_write",
+	"delete":  "pause_task_delete",
+	"rename":  "pause_rename",
+	"mkdir":   "pause_mkdir",
+	"ls":      "pause_ls",
+	"symlink": "pause_symlink",
+}
+
+// pauseOperationNames lists the operations in help and output order.
+var pauseOperationNames = []string{"read", "write", "delete", "rename", "mkdir", "ls", "symlink"}
+
+// pauseOperationDoc returns the pause_* fields for the named operations; an
+// empty list pauses every operation.
+func pauseOperationDoc(ops []string) (map[string]interface{}, error) {
+	selected := map[string]bool{}
+	for _, op := range ops {
+		op = strings.ToLower(strings.TrimSpace(op))
+		if op == "all" {
+			selected = map[string]bool{}
+			break
+		}
+		if _, ok := pauseOperationFields[op]; !ok {
+			return nil, fmt.Errorf("unknown operation %q (use %s or all)", op, strings.Join(pauseOperationNames, ", "))
+		}
+		selected[op] = true
+	}
+
+	doc := map[string]interface{}{}
+	for _, op := range pauseOperationNames {
+		doc[pauseOperationFields[op]] = len(selected) == 0 || selected[op]
+	}
+	return doc, nil
+}
+
+// parsePauseTime parses a window start or end time (RFC 3339, or a local
+// "YYYY-MM-DDTHH:MM") and returns it with the UTC form sent to Transfer.
+func parsePauseTime(s string) (time.Time, string, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, t.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("invalid time %q (use RFC 3339, e.g. 2026-11-01T06:00:00Z, or local YYYY-MM-DDTHH:MM)", s)
+}
+
+// parsePauseEnd parses the end of a pause starting at start (zero for now)
+// and checks that it is still ahead. Transfer pause rules have no end time,
+// so the end is never sent: it only tells the admin when to remove them.
+func parsePauseEnd(s string, start, now time.Time) (time.Time, error) {
+	end, _, err := parsePauseTime(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !start.IsZero() && !end.After(start) {
+		return time.Time{}, fmt.Errorf("end time %s is not after the start time", s)
+	}
+	if !end.After(now) {
+		return time.Time{}, fmt.Errorf("end time %s has already passed", s)
+	}
+	return end, nil
+}
+
+// pauseRuleFlags holds the rule fields shared by pause-rule create and update.
+type pauseRuleFlags struct {
+	Message    string
+	StartTime  string
+	Operations []string
+	Identity   string
+}
+
+// addPauseRuleFlags registers the rule field options on cmd.
+func addPauseRuleFlags(cmd *cobra.Command, f *pauseRuleFlags) {
+	cmd.Flags().StringVar(&f.Message, "message", "", "Message shown to affected users")
+	cmd.Flags().StringVar(&f.StartTime, "start-time", "", "When the rule takes effect (RFC 3339 or local YYYY-MM-DDTHH:MM; default now)")
+	cmd.Flags().StringSliceVar(&f.Operations, "operations", nil, "Operations to pause: "+strings.Join(pauseOperationNames, ", ")+" or all (default all)")
+	cmd.Flags().StringVar(&f.Identity, "identity", "", "Only pause this identity (ID or username; empty on update means everyone)")
+}
+
+// doc builds the pause_rule fields for the flags set on cmd. With all, every
+// field is included (create); otherwise only changed flags are (update).
+func (f *pauseRuleFlags) doc(ctx context.Context, cmd *cobra.Command, resolver *principalResolver, all bool) (map[string]interface{}, error) {
+	doc := map[string]interface{}{"DATA_TYPE": "pause_rule"}
+	changed := func(name string) bool { return all || cmd.Flags().Changed(name) }
+
+	if changed("message") {
+		doc["message"] = f.Message
+	}
+	if f.StartTime != "" && changed("start-time") {
+		_, start, err := parsePauseTime(f.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("--start-time: %w", err)
+		}
+		doc["start_time"] = start
+	}
+	if changed("operations") {
+		ops, err := pauseOperationDoc(f.Operations)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range ops {
+			doc[k] = v
+		}
+	}
+	if changed("identity") {
+		if f.Identity == "" {
+			if !all {
+				doc["identity_id"] = nil
+			}
+		} else {
+			id, err := resolver.identityID(ctx, f.Identity)
+			if err != nil {
+				return nil, err
+			}
+			doc["identity_id"] = id
+		}
+	}
+	return doc, nil
+}
+
+// pauseWindow is the document read by `pause-rule apply -f`: one maintenance
+// window expanded into a pause rule per collection and identity.
+type pauseWindow struct {
+	Message     string   `yaml:"message"`
+	StartTime   string   `yaml:"start_time,omitempty"`
+	EndTime     string   `yaml:"end_time,omitempty"`
+	Operations  []string `yaml:"operations,omitempty"`
+	Collections []string `yaml:"collections"`
+	Identities  []string `yaml:"identities,omitempty"`
+}
+
+// pauseRule is a pause rule reduced to the fields apply compares.
+type pauseRule struct {
+	ID         string          `json:"id,omitempty"`
+	EndpointID string          `json:"endpoint_id"`
+	IdentityID string          `json:"identity_id,omitempty"`
+	Message    string          `json:"message"`
+	StartTime  string          `json:"start_time,omitempty"`
+	Operations map[string]bool `json:"operations"`
+}
+
+// matches reports whether live rule have is the one the window rule r
+// manages: same collection, identity and operations, and the same start time
+// when r sets one. The message is not compared, so it can be edited in place.
+func (r pauseRule) matches(have pauseRule) bool {
+	return have.EndpointID == r.EndpointID && have.IdentityID == r.IdentityID &&
+		samePauseOps(have.Operations, r.Operations) &&
+		(r.StartTime == "" || sameACLTime(have.StartTime, r.StartTime))
+}
+
+// doc renders the rule as a pause_rule document.
+func (r pauseRule) doc() map[string]interface{} {
+	doc := map[string]interface{}{
+		"DATA_TYPE":   "pause_rule",
+		"endpoint_id": r.EndpointID,
+		"message":     r.Message,
+	}
+	if r.IdentityID != "" {
+		doc["identity_id"] = r.IdentityID
+	}
+	if r.StartTime != "" {
+		doc["start_time"] = r.StartTime
+	}
+	for op, field := range pauseOperationFields {
+		doc[field] = r.Operations[op]
+	}
+	return doc
+}
+
+// opsLabel renders the paused operations for plan output.
+func (r pauseRule) opsLabel() string {
+	var ops []string
+	for _, op := range pauseOperationNames {
+		if r.Operations[op] {
+			ops = append(ops, op)
+		}
+	}
+	if len(ops) == len(pauseOperationNames) {
+		return "all operations"
+	}
+	if len(ops) == 0 {
+		return "no operations"
+	}
+	return strings.Join(ops, ",")
+}
+
+// pauseRuleChange is one step of a window apply plan.
+type pauseRuleChange struct {
+	Action  string     `json:"action"`
+	Rule    pauseRule  `json:"rule"`
+	Current *pauseRule `json:"current,omitempty"`
+}
+
+// endpointManagerPauseRuleApplyCmd returns the "pause-rule apply" command.
+func endpointManagerPauseRuleApplyCmd() *cobra.Command {
+	var (
+		file   string
+		remove bool
+		yes    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f FILE",
+		Short: "Create, update or remove the pause rules of a maintenance window",
+		Long: `Declare a maintenance window in YAML and reconcile its pause rules.
+
+One pause rule is managed per collection (or endpoint) and identity listed in
+the file. Rules are matched to the file by collection, identity, operations
+and start time, so editing the message updates the rules in place, while
+changing the operations or start time starts a new window (remove the old
+one with --remove first).
+
+  message: Storage maintenance, transfers resume at 18:00 UTC
+  start_time: 2026-11-01T06:00:00Z
+  end_time: 2026-11-01T18:00:00Z
+  operations: [read, write, delete]   # default: all operations
+  collections:
+    - COLLECTION_ID
+  identities:                         # default: everyone
+    - bob@example.org
+
+PAUSE RULES DO NOT EXPIRE. Transfer pause rules have a start time but no end
+time, and nothing removes them at end_time: the command prints the time and
+the exact 'apply --remove' command to run then. An end_time that has already
+passed is refused unless --remove is given.
+
+A plan is printed; nothing changes unless --yes is given.
+
+Operations: ` + strings.Join(pauseOperationNames, ", ") + `.
+
+Examples:
+  globus endpoint-manager pause-rule apply -f window.yaml
+  globus endpoint-manager pause-rule apply -f window.yaml --yes
+  globus endpoint-manager pause-rule apply -f window.yaml --remove --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyPauseWindow(cmd, file, remove, yes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file describing the maintenance window")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the window's rules instead of creating them")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply the plan instead of only printing it")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// applyPauseWindow plans, prints and (with yes) applies a window file.
+func applyPauseWindow(cmd *cobra.Command, file string, remove, yes bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	window, err := loadPauseWindow(file)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	desired, err := window.rules(ctx, newPrincipalResolver())
+	if err != nil {
+		return err
+	}
+
+	var end time.Time
+	if window.EndTime != "" && !remove {
+		var start time.Time
+		if window.StartTime != "" {
+			start, _, _ = parsePauseTime(window.StartTime)
+		}
+		if end, err = parsePauseEnd(window.EndTime, start, time.Now()); err != nil {
+			return fmt.Errorf("%s: end_time: %w; remove the window's rules with:\n  globus endpoint-manager pause-rule apply -f %s --remove --yes", file, err, file)
+		}
+	}
+
+	var live []pauseRule
+	for _, endpointID := range window.Collections {
+		resp, err := client.EndpointManagerPauseRuleList(ctx, endpointID)
+		if err != nil {
+			return fmt.Errorf("failed to list pause rules for %s: %w", endpointID, err)
+		}
+		live = append(live, livePauseRules(resp)...)
+	}
+
+	plan := planPauseRules(desired, live, remove)
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		if err := formatter.FormatOutput(plan, nil); err != nil {
+			return err
+		}
+	} else {
+		out := cmd.OutOrStdout()
+		printPauseRulePlan(cmd, plan)
+		if len(plan) > 0 && !yes {
+			fmt.Fprintln(out, "\nRun again with --yes to apply these changes.")
+		}
+	}
+	if !end.IsZero() {
+		printPauseRemoval(cmd, end, "globus endpoint-manager pause-rule apply -f "+file+" --remove --yes")
+	}
+	if len(plan) == 0 || !yes {
+		return nil
+	}
+
+	for i, change := range plan {
+		var err error
+		switch change.Action {
+		case "create":
+			_, err = client.EndpointManagerCreatePauseRule(ctx, change.Rule.doc())
+		case "update":
+			_, err = client.EndpointManagerUpdatePauseRule(ctx, change.Current.ID, change.Rule.doc())
+		case "delete":
+			_, err = client.EndpointManagerDeletePauseRule(ctx, change.Current.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("%s pause rule on %s failed after %d of %d changes: %w", change.Action, change.Rule.EndpointID, i, len(plan), err)
+		}
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Applied %d change(s).\n", len(plan))
+	return nil
+}
+
+// loadPauseWindow reads and validates a window file.
+func loadPauseWindow(file string) (*pauseWindow, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var w pauseWindow
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&w); err != nil {
+		return nil, fmt.Errorf("invalid window file %s: %w", file, err)
+	}
+
+	if w.Message == "" {
+		return nil, fmt.Errorf("%s: message is required (it is shown to paused users and identifies the window)", file)
+	}
+	if len(w.Collections) == 0 {
+		return nil, fmt.Errorf("%s: list at least one collection", file)
+	}
+	if _, err := pauseOperationDoc(w.Operations); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	var start, end time.Time
+	if w.StartTime != "" {
+		if start, _, err = parsePauseTime(w.StartTime); err != nil {
+			return nil, fmt.Errorf("%s: start_time: %w", file, err)
+		}
+	}
+	if w.EndTime != "" {
+		if end, _, err = parsePauseTime(w.EndTime); err != nil {
+			return nil, fmt.Errorf("%s: end_time: %w", file, err)
+		}
+		if !start.IsZero() && !end.After(start) {
+			return nil, fmt.Errorf("%s: end_time must be after start_time", file)
+		}
+	}
+	return &w, nil
+}
+
+// rules expands the window into one pause rule per collection and identity.
+func (w *pauseWindow) rules(ctx context.Context, resolver *principalResolver) ([]pauseRule, error) {
+	opsDoc, err := pauseOperationDoc(w.Operations)
+	if err != nil {
+		return nil, err
+	}
+	ops := map[string]bool{}
+	for op, field := range pauseOperationFields {
+		ops[op] = opsDoc[field].(bool)
+	}
+
+	start := ""
+	if w.StartTime != "" {
+		_, start, _ = parsePauseTime(w.StartTime)
+	}
+
+	identities := []string{""}
+	if len(w.Identities) > 0 {
+		identities = identities[:0]
+		for _, ident := range w.Identities {
+			id, err := resolver.identityID(ctx, ident)
+			if err != nil {
+				return nil, err
+			}
+			identities = append(identities, id)
+		}
+	}
+
+	var rules []pauseRule
+	for _, endpointID := range w.Collections {
+		for _, identityID := range identities {
+			rules = append(rules, pauseRule{
+				EndpointID: endpointID,
+				IdentityID: identityID,
+				Message:    w.Message,
+				StartTime:  start,
+				Operations: ops,
+			})
+		}
+	}
+	return rules, nil
+}
+
+// livePauseRules extracts rules from a pause_rule_list response.
+func livePauseRules(resp map[string]interface{}) []pauseRule {
+	var rules []pauseRule
+	for _, item := range genericList(resp, "DATA") {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ops := map[string]bool{}
+		for op, field := range pauseOperationFields {
+			ops[op], _ = m[field].(bool)
+		}
+		rules = append(rules, pauseRule{
+			ID:         genericString(m, "id"),
+			EndpointID: genericString(m, "endpoint_id"),
+			IdentityID: genericString(m, "identity_id"),
+			Message:    genericString(m, "message"),
+			StartTime:  genericString(m, "start_time"),
+			Operations: ops,
+		})
+	}
+	return rules
+}
+
+// planPauseRules compares a window's rules with the live ones. With remove,
+// every live rule of the window is deleted; otherwise missing rules are
+// created and rules whose message drifted are updated.
+func planPauseRules(desired, live []pauseRule, remove bool) []pauseRuleChange {
+	// An empty plan prints as [] in JSON, not null.
+	plan := []pauseRuleChange{}
+	for _, want := range desired {
+		var have pauseRule
+		ok := false
+		for _, r := range live {
+			if want.matches(r) {
+				have, ok = r, true
+				break
+			}
+		}
+		switch {
+		case remove && ok:
+			current := have
+			plan = append(plan, pauseRuleChange{Action: "delete", Rule: have, Current: &current})
+		case remove:
+		case !ok:
+			plan = append(plan, pauseRuleChange{Action: "create", Rule: want})
+		case have.Message != want.Message:
+			current := have
+			plan = append(plan, pauseRuleChange{Action: "update", Rule: want, Current: &current})
+		}
+	}
+	sort.SliceStable(plan, func(i, j int) bool { return plan[i].Rule.EndpointID < plan[j].Rule.EndpointID })
+	return plan
+}
+
+// samePauseOps reports whether two operation sets pause the same operations.
+func samePauseOps(a, b map[string]bool) bool {
+	for _, op := range pauseOperationNames {
+		if a[op] != b[op] {
+			return false
+		}
+	}
+	return true
+}
+
+// printPauseRulePlan prints a window plan in text form.
+func printPauseRulePlan(cmd *cobra.Command, plan []pauseRuleChange) {
+	out := cmd.OutOrStdout()
+	if len(plan) == 0 {
+		fmt.Fprintln(out, "Pause rules already match the window; no changes.")
+		return
+	}
+	for _, c := range plan {
+		who := "everyone"
+		if c.Rule.IdentityID != "" {
+			who = "identity " + c.Rule.IdentityID
+		}
+		switch c.Action {
+		case "create":
+			start := "now"
+			if c.Rule.StartTime != "" {
+				start = c.Rule.StartTime
+			}
+			fmt.Fprintf(out, "  + create  %s  %s  %s  from %s\n", c.Rule.EndpointID, who, c.Rule.opsLabel(), start)
+		case "update":
+			fmt.Fprintf(out, "  ~ update  %s  %s  message %q -> %q  (rule %s)\n", c.Rule.EndpointID, who, c.Current.Message, c.Rule.Message, c.Current.ID)
+		case "delete":
+			fmt.Fprintf(out, "  - delete  %s  %s  %s  (rule %s)\n", c.Rule.EndpointID, who, c.Rule.opsLabel(), c.Current.ID)
+		}
+	}
+}
+
+// printPauseRemoval reminds the admin that pause rules do not expire and
+// prints when and how to remove them.
+func printPauseRemoval(cmd *cobra.Command, end time.Time, commands ...string) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "\nPause rules do not expire. At %s, remove them with:\n", end.UTC().Format(time.RFC3339))
+	for _, c := range commands {
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s\n", c)
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TestPauseOperationDoc checks operation selection and validation.
+func TestPauseOperationDoc(t *testing.T) {
+	doc, err := pauseOperationDoc([]string{"write", "Delete"})
+	if err != nil {
+		t.Fatalf("pauseOperationDoc: %v", err)
+	}
+	if doc["pause_task_transfer_write"] != true || doc["pause_task_delete"] != true || doc["pause_task_transfer_read"] != false || doc["pause_ls"] != false {
+		t.Errorf("write,delete = %v", doc)
+	}
+
+	for _, ops := range [][]string{nil, {"all"}} {
+		doc, _ := pauseOperationDoc(ops)
+		for field, v := range doc {
+			if v != true {
+				t.Errorf("%v: %s = %v, want true", ops, field, v)
+			}
+		}
+	}
+
+	if _, err := pauseOperationDoc([]string{"chmod"}); err == nil {
+		t.Error("expected an error for an unknown operation")
+	}
+}
+
+// TestPauseRuleFlagsDoc checks that update sends only the changed fields.
+func TestPauseRuleFlagsDoc(t *testing.T) {
+	var f pauseRuleFlags
+	cmd := &cobra.Command{}
+	addPauseRuleFlags(cmd, &f)
+	if err := cmd.ParseFlags([]string{"--operations", "read", "--identity", "", "--start-time", "2026-11-01T06:00:00+01:00"}); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := f.doc(context.Background(), cmd, nil, false)
+	if err != nil {
+		t.Fatalf("doc: %v", err)
+	}
+	if _, ok := doc["message"]; ok {
+		t.Error("unchanged --message was sent")
+	}
+	if v, ok := doc["identity_id"]; !ok || v != nil {
+		t.Errorf("identity_id = %v, %v; want explicit null", v, ok)
+	}
+	if doc["start_time"] != "2026-11-01T05:00:00Z" || doc["pause_task_transfer_read"] != true || doc["pause_ls"] != false {
+		t.Errorf("doc = %v", doc)
+	}
+}
+
+// TestLoadPauseWindow checks window file validation.
+func TestLoadPauseWindow(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"no message":     "collections: [c1]\n",
+		"no collections": "message: m\n",
+		"bad operation":  "message: m\ncollections: [c1]\noperations: [chmod]\n",
+		"end before":     "message: m\ncollections: [c1]\nstart_time: 2026-11-01T06:00:00Z\nend_time: 2026-11-01T05:00:00Z\n",
+		"unknown field":  "message: m\ncollections: [c1]\nend: 2026-11-01T05:00:00Z\n",
+	}
+	for name, body := range cases {
+		file := filepath.Join(dir, "w.yaml")
+		if err := os.WriteFile(file, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadPauseWindow(file); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	file := filepath.Join(dir, "ok.yaml")
+	body := "message: m\ncollections: [c1, c2]\nstart_time: 2026-11-01T06:00:00Z\nend_time: 2026-11-01T18:00:00Z\noperations: [write]\n"
+	if err := os.WriteFile(file, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w, err := loadPauseWindow(file)
+	if err != nil {
+		t.Fatalf("loadPauseWindow: %v", err)
+	}
+	rules, err := w.rules(context.Background(), nil)
+	if err != nil || len(rules) != 2 || rules[1].EndpointID != "c2" || !rules[0].Operations["write"] || rules[0].Operations["read"] {
+		t.Errorf("rules = %+v, %v", rules, err)
+	}
+}
+
+// TestPlanPauseRules covers create, update, no-op and removal.
+func TestPlanPauseRules(t *testing.T) {
+	all, _ := pauseOperationDoc(nil)
+	resp := map[string]interface{}{"DATA": []interface{}{
+		map[string]interface{}{"id": "r1", "endpoint_id": "c1", "identity_id": nil, "message": "m", "start_time": "2026-11-01T06:00:00+00:00", "pause_task_transfer_write": true},
+		map[string]interface{}{"id": "r2", "endpoint_id": "c2", "identity_id": nil, "message": "m", "start_time": "2026-11-01T06:00:00+00:00", "pause_task_transfer_write": true},
+		map[string]interface{}{"id": "r9", "endpoint_id": "c1", "identity_id": nil, "message": "other window"},
+	}}
+	for k, v := range all {
+		resp["DATA"].([]interface{})[1].(map[string]interface{})[k] = v
+	}
+	live := livePauseRules(resp)
+
+	writeOnly := map[string]bool{"write": true}
+	desired := []pauseRule{
+		{EndpointID: "c1", Message: "m", StartTime: "2026-11-01T06:00:00Z", Operations: writeOnly},
+		{EndpointID: "c2", Message: "m", StartTime: "2026-11-01T06:00:00Z", Operations: writeOnly},
+		{EndpointID: "c3", Message: "m", StartTime: "2026-11-01T06:00:00Z", Operations: writeOnly},
+	}
+
+	// r2 pauses every operation, so it belongs to another window.
+	plan := planPauseRules(desired, live, false)
+	if len(plan) != 2 || plan[0].Action != "create" || plan[0].Rule.EndpointID != "c2" || plan[1].Action != "create" || plan[1].Rule.EndpointID != "c3" {
+		t.Errorf("apply plan = %+v", plan)
+	}
+
+	desired[0].Message = "m, extended to 20:00"
+	plan = planPauseRules(desired[:1], live, false)
+	if len(plan) != 1 || plan[0].Action != "update" || plan[0].Current.ID != "r1" {
+		t.Errorf("message edit plan = %+v", plan)
+	}
+
+	plan = planPauseRules(desired, live, true)
+	if len(plan) != 1 || plan[0].Action != "delete" || plan[0].Current.ID != "r1" {
+		t.Errorf("remove plan = %+v", plan)
+	}
+
+	plan = planPauseRules(desired[2:], nil, true)
+	if data, _ := json.Marshal(plan); string(data) != "[]" {
+		t.Errorf("empty plan = %s", data)
+	}
+}
+
+// TestParsePauseEnd checks that an end time must follow the start and still
+// be ahead.
+func TestParsePauseEnd(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC)
+	if end, err := parsePauseEnd("2026-11-01T18:00:00Z", start, now); err != nil || !end.Equal(start.Add(12*time.Hour)) {
+		t.Errorf("end = %v, err = %v", end, err)
+	}
+	if _, err := parsePauseEnd("2026-11-01T05:00:00Z", start, now); err == nil {
+		t.Error("end before start: expected an error")
+	}
+	if _, err := parsePauseEnd("2026-09-01T00:00:00Z", time.Time{}, now); err == nil || !strings.Contains(err.Error(), "already passed") {
+		t.Errorf("past end: err = %v", err)
+	}
+}
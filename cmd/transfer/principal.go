@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/ids"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/core"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-sdk/v4/pkg/services/groups"
+)
+
+// identityBatchSize bounds the IDs sent in one Auth identities lookup.
+const identityBatchSize = 100
+
+// principalResolver maps usernames and group names to the IDs access rules
+// are keyed by, and back. Auth and Groups clients are only built on first
+// use, so files that name principals by UUID need no extra tokens; lookups
+// are cached for the life of the resolver.
+type principalResolver struct {
+	lookupIdentities func(ctx context.Context, opts *auth.GetIdentitiesOptions) ([]auth.Identity, error)
+	listGroups       func(ctx context.Context) ([]groups.Group, error)
+	getGroup         func(ctx context.Context, id string) (*groups.Group, error)
+
+	identityIDs map[string]string
+	identities  map[string]*auth.Identity
+	groupsByID  map[string]*groups.Group
+	myGroups    []groups.Group
+	groupsReady bool
+}
+
+// newPrincipalResolver returns a resolver backed by the current profile's
+// Auth and Groups tokens.
+func newPrincipalResolver() *principalResolver {
+	var authClient *auth.Client
+	var groupsClient *groups.Client
+	groupsFor := func(ctx context.Context) (*groups.Client, error) {
+		if groupsClient == nil {
+			c, err := getGroupsClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			groupsClient = c
+		}
+		return groupsClient, nil
+	}
+	return &principalResolver{
+		lookupIdentities: func(ctx context.Context, opts *auth.GetIdentitiesOptions) ([]auth.Identity, error) {
+			if authClient == nil {
+				c, err := getAuthClient(ctx)
+				if err != nil {
+					return nil, err
+				}
+				authClient = c
+			}
+			return authClient.GetIdentities(ctx, opts)
+		},
+		listGroups: func(ctx context.Context) ([]groups.Group, error) {
+			c, err := groupsFor(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return c.GetMyGroups(ctx, nil)
+		},
+		getGroup: func(ctx context.Context, id string) (*groups.Group, error) {
+			c, err := groupsFor(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return c.GetGroup(ctx, id, nil)
+		},
+	}
+}
+
+// identityID returns the identity ID for a username or identity UUID.
+// Unknown usernames are provisioned, as 'globus get-identities --provision'
+// would, so rules can be granted before a user first logs in.
+func (r *principalResolver) identityID(ctx context.Context, v string) (string, error) {
+	if ids.IsUUID(v) {
+		return strings.ToLower(v), nil
+	}
+	key := strings.ToLower(v)
+	if id, ok := r.identityIDs[key]; ok {
+		return id, nil
+	}
+
+	identities, err := r.lookupIdentities(ctx, &auth.GetIdentitiesOptions{
+		Usernames: []string{v},
+		Provision: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve identity %q: %w", v, err)
+	}
+	if len(identities) == 0 || identities[0].ID == "" {
+		return "", fmt.Errorf("no identity found for username %q", v)
+	}
+
+	if r.identityIDs == nil {
+		r.identityIDs = map[string]string{}
+	}
+	r.identityIDs[key] = identities[0].ID
+	return identities[0].ID, nil
+}
+
+// groupID returns the group ID for a group name or group UUID. Names are
+// matched against the caller's own groups, since Groups offers no global
+// name search; a name shared by several of them is an error.
+func (r *principalResolver) groupID(ctx context.Context, v string) (string, error) {
+	if ids.IsUUID(v) {
+		return strings.ToLower(v), nil
+	}
+	if err := r.loadGroups(ctx); err != nil {
+		return "", err
+	}
+
+	var groupIDs []string
+	for _, g := range r.myGroups {
+		if g.Name == v {
+			groupIDs = append(groupIDs, g.ID)
+		}
+	}
+	switch len(groupIDs) {
+	case 0:
+		return "", fmt.Errorf("no group named %q among your groups (use the group ID instead)", v)
+	case 1:
+		return groupIDs[0], nil
+	default:
+		return "", fmt.Errorf("group name %q is ambiguous (%s); use the group ID instead", v, strings.Join(groupIDs, ", "))
+	}
+}
+
+// loadGroups fetches the caller's groups once.
+func (r *principalResolver) loadGroups(ctx context.Context) error {
+	if r.groupsReady {
+		return nil
+	}
+	myGroups, err := r.listGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %w", err)
+	}
+	r.myGroups, r.groupsReady = myGroups, true
+	return nil
+}
+
+// describeIdentities looks up identity IDs in batches, returning the
+// identities Auth knows. IDs missing from the result could not be resolved.
+func (r *principalResolver) describeIdentities(ctx context.Context, identityIDs []string) (map[string]*auth.Identity, error) {
+	if r.identities == nil {
+		r.identities = map[string]*auth.Identity{}
+	}
+
+	var pending []string
+	for _, id := range identityIDs {
+		if _, ok := r.identities[id]; !ok {
+			pending = append(pending, id)
+		}
+	}
+	for start := 0; start < len(pending); start += identityBatchSize {
+		end := start + identityBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+		found, err := r.lookupIdentities(ctx, &auth.GetIdentitiesOptions{IDs: batch})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up identities: %w", err)
+		}
+		// Record misses too, so they are not looked up again.
+		for _, id := range batch {
+			r.identities[id] = nil
+		}
+		for i := range found {
+			r.identities[strings.ToLower(found[i].ID)] = &found[i]
+		}
+	}
+
+	out := make(map[string]*auth.Identity, len(identityIDs))
+	for _, id := range identityIDs {
+		if ident := r.identities[id]; ident != nil {
+			out[id] = ident
+		}
+	}
+	return out, nil
+}
+
+// describeGroup returns the group with the given ID, or nil if Groups reports
+// it missing or not visible to the caller.
+func (r *principalResolver) describeGroup(ctx context.Context, id string) (*groups.Group, error) {
+	if g, ok := r.groupsByID[id]; ok {
+		return g, nil
+	}
+	if r.groupsByID == nil {
+		r.groupsByID = map[string]*groups.Group{}
+	}
+
+	// The caller's own groups are usually enough, without a request each.
+	if err := r.loadGroups(ctx); err == nil {
+		for i := range r.myGroups {
+			if r.myGroups[i].ID == id {
+				r.groupsByID[id] = &r.myGroups[i]
+				return &r.myGroups[i], nil
+			}
+		}
+	}
+
+	g, err := r.getGroup(ctx, id)
+	if err != nil {
+		var apiErr *core.APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusForbidden) {
+			r.groupsByID[id] = nil
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up group %s: %w", id, err)
+	}
+	r.groupsByID[id] = g
+	return g, nil
+}
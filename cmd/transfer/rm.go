@@ -21,6 +21,7 @@ var (
 	rmLabel         string
 	rmDeadline      string
 	rmLocalUser     string
+	rmNoGlob        bool
 	rmEnableGlobs   bool
 	rmNotify        []string
 )
@@ -28,27 +29,39 @@ var (
 // RmCmd returns the rm command
 func RmCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rm ENDPOINT_ID:PATH",
+		Use:   "rm ENDPOINT_ID:PATH...",
 		Short: "Remove a file or directory on an endpoint",
 		Long: `Remove a file or directory on a Globus endpoint.
 
 This command deletes a file or directory on the specified Globus endpoint.
 If --recursive is specified, it will delete directories and their contents.
 
+Several paths on the same endpoint may be given, and shell-style globs in
+them are expanded on the endpoint before deleting (quote them so your local
+shell does not). Escape a metacharacter with a backslash ('a\[1\].txt'),
+or give --no-glob to take every path literally. With --enable-globs the
+patterns are instead sent as-is for the Transfer service to interpret.
+
 Examples:
   globus transfer rm ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/file
-  globus transfer rm --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/directory`,
-		Args: cobra.ExactArgs(1),
+  globus transfer rm --recursive ddb59aef-6d04-11e5-ba46-22000b92c6ec:/path/to/directory
+  globus transfer rm 'ddb59aef-6d04-11e5-ba46-22000b92c6ec:/scratch/*.log'`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Parse endpoint ID and path
-			endpointID, path := parseEndpointAndPath(args[0])
+			// Parse endpoint ID and paths
+			endpointID, paths, err := splitEndpointArgs(args)
+			if err != nil {
+				return err
+			}
 
-			// Check that path is specified
-			if path == "/" {
-				return fmt.Errorf("path must be specified for rm command")
+			// Check that each path is specified
+			for _, path := range paths {
+				if path == "/" {
+					return fmt.Errorf("path must be specified for rm command")
+				}
 			}
 
-			return removeItem(cmd, endpointID, path)
+			return removeItem(cmd, endpointID, paths)
 		},
 	}
 
@@ -59,14 +72,15 @@ Examples:
 	cmd.Flags().StringVar(&rmLabel, "label", "", "Set a label for this task")
 	cmd.Flags().StringVar(&rmDeadline, "deadline", "", "Deadline for the task (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&rmLocalUser, "local-user", "", "Local user to map to (GCSv5 mapped collections)")
-	cmd.Flags().BoolVar(&rmEnableGlobs, "enable-globs", false, "Interpret shell-style globs in the path")
+	cmd.Flags().BoolVar(&rmNoGlob, "no-glob", false, "Treat '*', '?' and '[' in paths literally instead of expanding them")
+	cmd.Flags().BoolVar(&rmEnableGlobs, "enable-globs", false, "Have the Transfer service interpret shell-style globs instead of expanding them locally")
 	cmd.Flags().StringSliceVar(&rmNotify, "notify", nil, "Notification settings: any of on, off, succeeded, failed, inactive")
 
 	return cmd
 }
 
-// removeItem removes a file or directory on an endpoint
-func removeItem(cmd *cobra.Command, endpointID, path string) error {
+// removeItem removes files or directories on an endpoint
+func removeItem(cmd *cobra.Command, endpointID string, paths []string) error {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -77,8 +91,25 @@ func removeItem(cmd *cobra.Command, endpointID, path string) error {
 		return err
 	}
 
+	matches, err := resolveDeletePaths(ctx, transferClient, endpointID, paths, rmLocalUser, rmNoGlob, rmEnableGlobs, rmRecursive)
+	if err != nil {
+		return err
+	}
+
+	// Several paths (or a glob) get a single confirmation listing them all.
+	if !rmForce && (len(matches) > 1 || !matches[0].Literal) {
+		fmt.Printf("Paths to delete on %s:\n", endpointID)
+		for _, m := range matches {
+			fmt.Printf("  %s\n", m.Path)
+		}
+		if !confirmAction(fmt.Sprintf("Are you sure you want to delete these %d paths?", len(matches))) {
+			fmt.Println("Operation canceled.")
+			return nil
+		}
+	}
+
 	// Check if we need to prompt for confirmation
-	if !rmForce {
+	if path := matches[0].Path; !rmForce && len(matches) == 1 && matches[0].Literal {
 		// Get file/directory info
 		options := &transfer.ListDirectoryOptions{}
 
@@ -156,9 +187,7 @@ func removeItem(cmd *cobra.Command, endpointID, path string) error {
 		NotifyOnSucceeded: notifySucceeded,
 		NotifyOnFailed:    notifyFailed,
 		NotifyOnInactive:  notifyInactive,
-		Items: []transfer.DeleteItem{
-			{DATA_TYPE: "delete_item", Path: path},
-		},
+		Items:             deleteItems(matches),
 	}
 
 	// Create a delete task
@@ -169,7 +198,9 @@ func removeItem(cmd *cobra.Command, endpointID, path string) error {
 
 	fmt.Printf("Delete task submitted. Task ID: %s\n", taskResponse.TaskID)
 
-	fmt.Printf("Successfully deleted %s:%s\n", endpointID, path)
+	for _, m := range matches {
+		fmt.Printf("Successfully deleted %s:%s\n", endpointID, m.Path)
+	}
 	return nil
 }
 
@@ -24,6 +24,7 @@ var (
 	taskFilter          string
 	taskFilterStatus    []string
 	taskOrderBy         []string
+	limit               int
 )
 
 // TaskCmd returns the task command
@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/scttfrdmn/globus-go-cli/pkg/config"
+	"github.com/scttfrdmn/globus-go-cli/pkg/output"
+)
+
+// TemplateCmd returns the template command group, attached under the
+// top-level `transfer` verb (globus transfer template ...).
+func TemplateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage saved transfer option templates",
+		Long: `Manage named sets of transfer options saved in the current profile.
+
+A template records any of the options 'globus transfer' accepts (sync level,
+timestamps, encryption, notifications, filters, local users, ...). Apply one
+with 'globus transfer --template NAME SRC DEST' or
+'globus timer create transfer --template NAME ...'; flags given explicitly on
+the command line override the template.
+
+Templates are stored per profile in ~/.globus-cli/profiles/<profile>.yaml.`,
+	}
+
+	cmd.AddCommand(
+		templateSaveCmd(),
+		templateListCmd(),
+		templateShowCmd(),
+		templateDeleteCmd(),
+	)
+
+	return cmd
+}
+
+// templateSaveCmd returns the template save command.
+func templateSaveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save NAME [flags]",
+		Short: "Save transfer options as a named template",
+		Long: `Save the given transfer options as a named template in the current profile.
+
+Only the flags you pass are recorded. Saving over an existing name replaces it.
+
+Examples:
+  globus transfer template save nightly --sync-level checksum --preserve-timestamp \
+    --encrypt-data --notify failed --exclude '*.tmp' --skip-source-errors`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return saveTemplate(cmd, args[0])
+		},
+	}
+
+	addTransferOptionFlags(cmd)
+
+	return cmd
+}
+
+// templateListCmd returns the template list command.
+func templateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List saved transfer templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listTemplates(cmd)
+		},
+	}
+}
+
+// templateShowCmd returns the template show command.
+func templateShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show NAME",
+		Short: "Show the options saved in a transfer template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showTemplate(cmd, args[0])
+		},
+	}
+}
+
+// templateDeleteCmd returns the template delete command.
+func templateDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Delete a saved transfer template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteTemplate(args[0])
+		},
+	}
+}
+
+// saveTemplate records the flags changed on cmd as template name.
+func saveTemplate(cmd *cobra.Command, name string) error {
+	if err := config.ValidateTemplateName(name); err != nil {
+		return err
+	}
+
+	tmpl, err := templateFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if len(templateFlagArgs(tmpl)) == 0 {
+		return fmt.Errorf("no transfer options given; pass the flags to save (see 'globus transfer template save --help')")
+	}
+
+	pc, err := config.LoadProfileConfig()
+	if err != nil {
+		return err
+	}
+	_, replaced := pc.TransferTemplates[name]
+	pc.TransferTemplates[name] = *tmpl
+	if err := config.SaveProfileConfig(pc); err != nil {
+		return err
+	}
+
+	if replaced {
+		fmt.Printf("Updated transfer template %s\n", name)
+	} else {
+		fmt.Printf("Saved transfer template %s\n", name)
+	}
+	return nil
+}
+
+// listTemplates prints the templates saved in the current profile.
+func listTemplates(cmd *cobra.Command) error {
+	pc, err := config.LoadProfileConfig()
+	if err != nil {
+		return err
+	}
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON {
+		return formatter.FormatOutput(pc.TransferTemplates, nil)
+	}
+
+	type templateRow struct {
+		Name    string
+		Options string
+	}
+	rows := make([]templateRow, 0, len(pc.TransferTemplates))
+	for _, name := range pc.TemplateNames() {
+		tmpl := pc.TransferTemplates[name]
+		rows = append(rows, templateRow{Name: name, Options: strings.Join(templateFlagArgs(&tmpl), " ")})
+	}
+	return formatter.FormatOutput(rows, []string{"Name", "Options"})
+}
+
+// showTemplate prints a single template.
+func showTemplate(cmd *cobra.Command, name string) error {
+	tmpl, err := config.LoadTransferTemplate(name)
+	if err != nil {
+		return err
+	}
+
+	format := viper.GetString("format")
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if formatter.Format == output.FormatJSON || formatter.Format == output.FormatUnix {
+		return formatter.FormatOutput(tmpl, nil)
+	}
+
+	fmt.Printf("Transfer Template: %s\n", name)
+	for _, arg := range templateFlagArgs(tmpl) {
+		fmt.Printf("  %s\n", arg)
+	}
+	return nil
+}
+
+// deleteTemplate removes a template from the current profile.
+func deleteTemplate(name string) error {
+	pc, err := config.LoadProfileConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := pc.TransferTemplates[name]; !ok {
+		return fmt.Errorf("no transfer template named %q in profile %q", name, viper.GetString("profile"))
+	}
+	delete(pc.TransferTemplates, name)
+	if err := config.SaveProfileConfig(pc); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted transfer template %s\n", name)
+	return nil
+}
+
+// templateFromFlags builds a template from the transfer option flags the user
+// explicitly set on cmd.
+func templateFromFlags(cmd *cobra.Command) (*config.TransferTemplate, error) {
+	flags := cmd.Flags()
+	tmpl := &config.TransferTemplate{}
+
+	boolIfChanged := func(v bool, names ...string) *bool {
+		for _, n := range names {
+			if flags.Changed(n) {
+				return &v
+			}
+		}
+		return nil
+	}
+
+	if flags.Changed("sync-level") {
+		lvl, err := parseSyncLevel(transferSyncLevel)
+		if err != nil {
+			return nil, err
+		}
+		tmpl.SyncLevel = &lvl
+	}
+	if flags.Changed("notify") {
+		if _, _, _, err := parseNotify(transferNotify); err != nil {
+			return nil, err
+		}
+		tmpl.Notify = transferNotify
+	}
+
+	tmpl.Recursive = boolIfChanged(transferRecursive, "recursive")
+	tmpl.VerifyChecksum = boolIfChanged(transferVerify, "verify-checksum")
+	tmpl.PreserveTimestamp = boolIfChanged(transferPreserveTime, "preserve-timestamp", "preserve-mtime")
+	tmpl.EncryptData = boolIfChanged(transferEncrypt, "encrypt-data")
+	tmpl.DeleteDestinationExtra = boolIfChanged(transferDeleteDestExtra, "delete-destination-extra")
+	tmpl.SkipSourceErrors = boolIfChanged(transferSkipSourceErrors, "skip-source-errors")
+	tmpl.FailOnQuotaErrors = boolIfChanged(transferFailOnQuota, "fail-on-quota-errors")
+	tmpl.Label = transferLabel
+	tmpl.SourceLocalUser = transferSourceLocalUser
+	tmpl.DestinationLocalUser = transferDestLocalUser
+	tmpl.ChecksumAlgorithm = transferChecksumAlgo
+	tmpl.Deadline = transferDeadline
+	if flags.Changed("include") {
+		tmpl.Include = transferInclude
+	}
+	if flags.Changed("exclude") {
+		tmpl.Exclude = transferExclude
+	}
+	return tmpl, nil
+}
+
+// applyTransferTemplate copies template options into the transfer flag
+// variables for every option the user did not set explicitly on cmd.
+func applyTransferTemplate(cmd *cobra.Command, tmpl *config.TransferTemplate) {
+	flags := cmd.Flags()
+	unset := func(names ...string) bool {
+		for _, n := range names {
+			if flags.Changed(n) {
+				return false
+			}
+		}
+		return true
+	}
+	setBool := func(dst *bool, v *bool, names ...string) {
+		if v != nil && unset(names...) {
+			*dst = *v
+		}
+	}
+	setString := func(dst *string, v string, name string) {
+		if v != "" && unset(name) {
+			*dst = v
+		}
+	}
+
+	if tmpl.SyncLevel != nil && unset("sync-level") {
+		transferSync = *tmpl.SyncLevel
+	}
+	setBool(&transferRecursive, tmpl.Recursive, "recursive")
+	setBool(&transferVerify, tmpl.VerifyChecksum, "verify-checksum")
+	setBool(&transferPreserveTime, tmpl.PreserveTimestamp, "preserve-timestamp", "preserve-mtime")
+	setBool(&transferEncrypt, tmpl.EncryptData, "encrypt-data")
+	setBool(&transferDeleteDestExtra, tmpl.DeleteDestinationExtra, "delete-destination-extra")
+	setBool(&transferSkipSourceErrors, tmpl.SkipSourceErrors, "skip-source-errors")
+	setBool(&transferFailOnQuota, tmpl.FailOnQuotaErrors, "fail-on-quota-errors")
+	setString(&transferLabel, tmpl.Label, "label")
+	setString(&transferSourceLocalUser, tmpl.SourceLocalUser, "source-local-user")
+	setString(&transferDestLocalUser, tmpl.DestinationLocalUser, "destination-local-user")
+	setString(&transferChecksumAlgo, tmpl.ChecksumAlgorithm, "checksum-algorithm")
+	setString(&transferDeadline, tmpl.Deadline, "deadline")
+	if len(tmpl.Notify) > 0 && unset("notify") {
+		transferNotify = tmpl.Notify
+	}
+	if len(tmpl.Include) > 0 && unset("include") {
+		transferInclude = tmpl.Include
+	}
+	if len(tmpl.Exclude) > 0 && unset("exclude") {
+		transferExclude = tmpl.Exclude
+	}
+}
+
+// templateFlagArgs renders a template as the equivalent `globus transfer`
+// flags, for list/show output.
+func templateFlagArgs(tmpl *config.TransferTemplate) []string {
+	var args []string
+	addBool := func(name string, v *bool) {
+		if v != nil {
+			args = append(args, fmt.Sprintf("--%s=%t", name, *v))
+		}
+	}
+	addString := func(name, v string) {
+		if v != "" {
+			args = append(args, fmt.Sprintf("--%s %q", name, v))
+		}
+	}
+
+	addBool("recursive", tmpl.Recursive)
+	if tmpl.SyncLevel != nil {
+		args = append(args, "--sync-level "+syncLevelName(*tmpl.SyncLevel))
+	}
+	addBool("preserve-timestamp", tmpl.PreserveTimestamp)
+	addBool("verify-checksum", tmpl.VerifyChecksum)
+	addBool("encrypt-data", tmpl.EncryptData)
+	addBool("delete-destination-extra", tmpl.DeleteDestinationExtra)
+	addBool("skip-source-errors", tmpl.SkipSourceErrors)
+	addBool("fail-on-quota-errors", tmpl.FailOnQuotaErrors)
+	addString("label", tmpl.Label)
+	if len(tmpl.Notify) > 0 {
+		args = append(args, "--notify "+strings.Join(tmpl.Notify, ","))
+	}
+	for _, p := range tmpl.Include {
+		addString("include", p)
+	}
+	for _, p := range tmpl.Exclude {
+		addString("exclude", p)
+	}
+	addString("checksum-algorithm", tmpl.ChecksumAlgorithm)
+	addString("source-local-user", tmpl.SourceLocalUser)
+	addString("destination-local-user", tmpl.DestinationLocalUser)
+	addString("deadline", tmpl.Deadline)
+	return args
+}
+
+// syncLevelName is the inverse of parseSyncLevel.
+func syncLevelName(level int) string {
+	switch level {
+	case 0:
+		return "exists"
+	case 1:
+		return "size"
+	case 2:
+		return "mtime"
+	case 3:
+		return "checksum"
+	default:
+		return fmt.Sprintf("%d", level)
+	}
+}
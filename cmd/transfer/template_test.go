@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package transfer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// resetTransferOptions restores the shared transfer flag variables to their
+// zero values so tests don't leak state into each other.
+func resetTransferOptions() {
+	transferRecursive, transferSync, transferSyncLevel = false, 0, ""
+	transferPreserveTime, transferVerify, transferEncrypt = false, true, false
+	transferLabel, transferChecksumAlgo, transferDeadline = "", "", ""
+	transferNotify, transferInclude, transferExclude = nil, nil, nil
+	transferSkipSourceErrors, transferFailOnQuota, transferDeleteDestExtra = false, false, false
+	transferSourceLocalUser, transferDestLocalUser = "", ""
+	transferManifest, transferExternalChecksum, transferNoGlob = "", "", false
+}
+
+// TestTemplateSaveAndApply saves a template from flags and applies it to a
+// transfer command, checking that explicit flags win over the template.
+func TestTemplateSaveAndApply(t *testing.T) {
+	defer resetTransferOptions()
+	resetTransferOptions()
+
+	save := &cobra.Command{Use: "save"}
+	addTransferOptionFlags(save)
+	if err := save.ParseFlags([]string{
+		"--sync-level", "checksum", "--preserve-timestamp", "--encrypt-data",
+		"--notify", "failed", "--exclude", "*.tmp", "--skip-source-errors",
+		"--verify-checksum=false",
+	}); err != nil {
+		t.Fatalf("parse save flags: %v", err)
+	}
+	tmpl, err := templateFromFlags(save)
+	if err != nil {
+		t.Fatalf("templateFromFlags: %v", err)
+	}
+	if tmpl.Recursive != nil || tmpl.Label != "" {
+		t.Errorf("unset options should not be recorded: %+v", tmpl)
+	}
+	want := []string{
+		"--sync-level checksum", "--preserve-timestamp=true", "--verify-checksum=false",
+		"--encrypt-data=true", "--skip-source-errors=true", "--notify failed", `--exclude "*.tmp"`,
+	}
+	if got := templateFlagArgs(tmpl); !reflect.DeepEqual(got, want) {
+		t.Errorf("templateFlagArgs = %v, want %v", got, want)
+	}
+
+	resetTransferOptions()
+	cp := &cobra.Command{Use: "transfer"}
+	addTransferOptionFlags(cp)
+	if err := cp.ParseFlags([]string{"--encrypt-data=false", "--label", "run 7"}); err != nil {
+		t.Fatalf("parse transfer flags: %v", err)
+	}
+	applyTransferTemplate(cp, tmpl)
+
+	if transferSync != 3 || !transferPreserveTime || transferVerify || !transferSkipSourceErrors {
+		t.Errorf("template options not applied: sync=%d preserve=%t verify=%t skip=%t",
+			transferSync, transferPreserveTime, transferVerify, transferSkipSourceErrors)
+	}
+	if transferEncrypt {
+		t.Error("explicit --encrypt-data=false should override the template")
+	}
+	if transferLabel != "run 7" {
+		t.Errorf("label = %q, want the explicit flag value", transferLabel)
+	}
+	if !reflect.DeepEqual(transferExclude, []string{"*.tmp"}) || !reflect.DeepEqual(transferNotify, []string{"failed"}) {
+		t.Errorf("list options not applied: exclude=%v notify=%v", transferExclude, transferNotify)
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// TransferTemplate is a named, reusable set of transfer options. Only the
+// options that were explicitly set when the template was saved are recorded;
+// pointer fields distinguish "unset" from false/zero so that applying a
+// template never clobbers a command's own defaults (e.g. verify_checksum).
+type TransferTemplate struct {
+	Label                  string   `yaml:"label,omitempty" json:"label,omitempty"`
+	Recursive              *bool    `yaml:"recursive,omitempty" json:"recursive,omitempty"`
+	SyncLevel              *int     `yaml:"sync_level,omitempty" json:"sync_level,omitempty"`
+	VerifyChecksum         *bool    `yaml:"verify_checksum,omitempty" json:"verify_checksum,omitempty"`
+	PreserveTimestamp      *bool    `yaml:"preserve_timestamp,omitempty" json:"preserve_timestamp,omitempty"`
+	EncryptData            *bool    `yaml:"encrypt_data,omitempty" json:"encrypt_data,omitempty"`
+	DeleteDestinationExtra *bool    `yaml:"delete_destination_extra,omitempty" json:"delete_destination_extra,omitempty"`
+	SkipSourceErrors       *bool    `yaml:"skip_source_errors,omitempty" json:"skip_source_errors,omitempty"`
+	FailOnQuotaErrors      *bool    `yaml:"fail_on_quota_errors,omitempty" json:"fail_on_quota_errors,omitempty"`
+	SourceLocalUser        string   `yaml:"source_local_user,omitempty" json:"source_local_user,omitempty"`
+	DestinationLocalUser   string   `yaml:"destination_local_user,omitempty" json:"destination_local_user,omitempty"`
+	Notify                 []string `yaml:"notify,omitempty" json:"notify,omitempty"`
+	Include                []string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude                []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	ChecksumAlgorithm      string   `yaml:"checksum_algorithm,omitempty" json:"checksum_algorithm,omitempty"`
+	Deadline               string   `yaml:"deadline,omitempty" json:"deadline,omitempty"`
+}
+
+// ProfileConfig is the per-profile settings document stored at
+// ~/.globus-cli/profiles/<profile>.yaml.
+type ProfileConfig struct {
+	TransferTemplates map[string]TransferTemplate `yaml:"transfer_templates,omitempty"`
+}
+
+// templateNamePattern restricts template names to something that is safe to
+// type unquoted on a command line.
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateTemplateName reports whether name is usable as a template name.
+func ValidateTemplateName(name string) error {
+	if !templateNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid template name %q (use letters, digits, '.', '_' and '-')", name)
+	}
+	return nil
+}
+
+// profileConfigPath returns the settings file for the active profile.
+func profileConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	profile := viper.GetString("profile")
+	if profile == "" {
+		profile = "default"
+	}
+	return filepath.Join(home, ".globus-cli", "profiles", profile+".yaml"), nil
+}
+
+// LoadProfileConfig reads the active profile's settings, returning an empty
+// (but non-nil) config if the file does not exist yet.
+func LoadProfileConfig() (*ProfileConfig, error) {
+	path, err := profileConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	pc := &ProfileConfig{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read profile config: %w", err)
+	}
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, pc); err != nil {
+			return nil, fmt.Errorf("invalid profile config %s: %w", path, err)
+		}
+	}
+	if pc.TransferTemplates == nil {
+		pc.TransferTemplates = map[string]TransferTemplate{}
+	}
+	return pc, nil
+}
+
+// SaveProfileConfig writes the active profile's settings atomically (0600).
+func SaveProfileConfig(pc *ProfileConfig) error {
+	path, err := profileConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	data, err := yaml.Marshal(pc)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile config: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write profile config: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save profile config: %w", err)
+	}
+	return nil
+}
+
+// LoadTransferTemplate returns the named transfer template from the active
+// profile.
+func LoadTransferTemplate(name string) (*TransferTemplate, error) {
+	pc, err := LoadProfileConfig()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, ok := pc.TransferTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("no transfer template named %q in profile %q (see 'globus transfer template list')", name, viper.GetString("profile"))
+	}
+	return &tmpl, nil
+}
+
+// TemplateNames returns the profile's template names in sorted order.
+func (pc *ProfileConfig) TemplateNames() []string {
+	names := make([]string, 0, len(pc.TransferTemplates))
+	for name := range pc.TransferTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
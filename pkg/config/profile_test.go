@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestProfileConfigRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	origProfile := viper.GetString("profile")
+	viper.Set("profile", "lab")
+	defer viper.Set("profile", origProfile)
+
+	// A missing file yields an empty, usable config.
+	pc, err := LoadProfileConfig()
+	if err != nil {
+		t.Fatalf("LoadProfileConfig: %v", err)
+	}
+	if len(pc.TransferTemplates) != 0 {
+		t.Fatalf("expected no templates, got %v", pc.TransferTemplates)
+	}
+
+	level, no := 3, false
+	pc.TransferTemplates["nightly"] = TransferTemplate{
+		SyncLevel:      &level,
+		VerifyChecksum: &no,
+		Exclude:        []string{"*.tmp"},
+		Notify:         []string{"failed"},
+	}
+	if err := SaveProfileConfig(pc); err != nil {
+		t.Fatalf("SaveProfileConfig: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".globus-cli", "profiles", "lab.yaml")); err != nil {
+		t.Fatalf("expected per-profile file: %v", err)
+	}
+
+	got, err := LoadTransferTemplate("nightly")
+	if err != nil {
+		t.Fatalf("LoadTransferTemplate: %v", err)
+	}
+	if !reflect.DeepEqual(*got, pc.TransferTemplates["nightly"]) {
+		t.Errorf("template = %+v, want %+v", *got, pc.TransferTemplates["nightly"])
+	}
+
+	if _, err := LoadTransferTemplate("missing"); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+}
+
+func TestValidateTemplateName(t *testing.T) {
+	for _, name := range []string{"nightly", "lab-sync_2", "v1.0"} {
+		if err := ValidateTemplateName(name); err != nil {
+			t.Errorf("ValidateTemplateName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "-x", "two words", "a/b"} {
+		if err := ValidateTemplateName(name); err == nil {
+			t.Errorf("ValidateTemplateName(%q) = nil, want error", name)
+		}
+	}
+}
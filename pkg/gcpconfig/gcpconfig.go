@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+
+// Package gcpconfig reads the local Globus Connect Personal configuration
+// directory, mirroring globus_sdk.LocalGlobusConnectPersonal.
+package gcpconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultDir returns the current user's GCP configuration directory,
+// ~/.globusonline.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".globusonline"), nil
+}
+
+// AgentDir returns the directory of configDir holding the agent's files:
+// <config>/lta, or <config> itself on Windows.
+func AgentDir(configDir string) string {
+	if runtime.GOOS == "windows" {
+		return configDir
+	}
+	return filepath.Join(configDir, "lta")
+}
+
+// EndpointIDFile returns the file holding the endpoint ID in configDir.
+func EndpointIDFile(configDir string) string {
+	return filepath.Join(AgentDir(configDir), "client-id.txt")
+}
+
+// ReadEndpointID reads the endpoint ID of the installation in configDir. A
+// missing ID file yields ("", nil).
+func ReadEndpointID(configDir string) (string, error) {
+	data, err := os.ReadFile(EndpointIDFile(configDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("cannot read local endpoint ID: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
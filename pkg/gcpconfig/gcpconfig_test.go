@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package gcpconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReadEndpointID checks a missing installation and a trimmed ID.
+func TestReadEndpointID(t *testing.T) {
+	dir := t.TempDir()
+	if id, err := ReadEndpointID(dir); id != "" || err != nil {
+		t.Fatalf("empty dir: id = %q, err = %v", id, err)
+	}
+
+	if err := os.MkdirAll(AgentDir(dir), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(EndpointIDFile(dir), []byte("ep-123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := ReadEndpointID(dir); id != "ep-123" || err != nil {
+		t.Errorf("id = %q, err = %v", id, err)
+	}
+	if filepath.Base(EndpointIDFile(dir)) != "client-id.txt" {
+		t.Errorf("EndpointIDFile = %q", EndpointIDFile(dir))
+	}
+}
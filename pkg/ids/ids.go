@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+
+// Package ids recognizes the identifiers Globus services use.
+package ids
+
+import "regexp"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID reports whether s is a bare UUID, the form Globus uses for
+// endpoint, collection, identity, group and connector IDs.
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025-2026 Scott Friedman and Project Contributors
+package ids
+
+import "testing"
+
+// TestIsUUID checks bare UUIDs in either case and rejects everything else.
+func TestIsUUID(t *testing.T) {
+	for s, want := range map[string]bool{
+		"6c54cade-bde5-45c1-bdea-f4bd71dba2cc":                          true,
+		"6C54CADE-BDE5-45C1-BDEA-F4BD71DBA2CC":                          true,
+		"urn:globus:auth:identity:6c54cade-bde5-45c1-bdea-f4bd71dba2cc": false,
+		"6c54cadebde545c1bdeaf4bd71dba2cc":                              false,
+		"alice@example.org":                                             false,
+		"":                                                              false,
+	} {
+		if got := IsUUID(s); got != want {
+			t.Errorf("IsUUID(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package output
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -118,3 +119,19 @@ func TestExitCodeForError(t *testing.T) {
 		t.Error("nil error should give ok=false")
 	}
 }
+
+func TestExitCodeError(t *testing.T) {
+	inner := errors.New("check failed")
+	err := fmt.Errorf("wrapped: %w", &ExitCodeError{Code: 2, Err: inner})
+
+	var exitErr *ExitCodeError
+	if !errors.As(err, &exitErr) || exitErr.Code != 2 {
+		t.Fatalf("errors.As = %v, %+v", err, exitErr)
+	}
+	if !errors.Is(err, inner) {
+		t.Error("ExitCodeError should unwrap to its Err")
+	}
+	if (&ExitCodeError{Code: 2}).Error() != "exit status 2" {
+		t.Error("nil Err should describe the exit status")
+	}
+}
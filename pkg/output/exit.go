@@ -82,3 +82,20 @@ func httpStatusOf(err error) int {
 	}
 	return 0
 }
+
+// ExitCodeError asks the CLI to exit with Code. Commands whose outcome is a
+// verdict rather than an error (for example a health check that only warns)
+// return one with a nil Err, in which case nothing is printed.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error { return e.Err }